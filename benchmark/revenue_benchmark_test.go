@@ -0,0 +1,215 @@
+//go:build benchmark
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/govalues/decimal"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+)
+
+// Seeded bookings are tagged with this customer_id so they can be removed afterwards.
+const benchmarkCustomerID = -31
+
+const defaultBenchmarkBookings = 20000
+
+// BenchmarkRevenueBySlot compares the SQL revenue aggregation with the previous in-memory
+// strategy on the database configured in .env. It only builds with the benchmark tag:
+//
+//	go test -tags benchmark -run '^$' -bench RevenueBySlot ./benchmark
+//
+// REVENUE_BENCHMARK_BOOKINGS sets how many bookings are seeded and REVENUE_BENCHMARK_KEEP=true
+// leaves them in place afterwards.
+func BenchmarkRevenueBySlot(b *testing.B) {
+	if err := godotenv.Load("../.env"); err != nil {
+		b.Log("Warning: .env file not found")
+	}
+
+	bookings := defaultBenchmarkBookings
+	if value := os.Getenv("REVENUE_BENCHMARK_BOOKINGS"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			b.Fatalf("REVENUE_BENCHMARK_BOOKINGS must be a positive integer, got %q", value)
+		}
+		bookings = parsed
+	}
+
+	db := config.GetDBConnection()
+	b.Cleanup(config.CloseDBConnection)
+
+	ctx := context.Background()
+
+	if err := seedBookings(ctx, db, bookings); err != nil {
+		b.Fatalf("Error seeding bookings: %v", err)
+	}
+	if os.Getenv("REVENUE_BENCHMARK_KEEP") != "true" {
+		b.Cleanup(func() { cleanupBookings(ctx, b, db) })
+	}
+
+	bookingRepository := repositories.NewBookingRepository(db)
+	showRepository := repositories.NewShowRepository(db)
+	slotRepository := repositories.NewSlotRepository(db)
+	reportingRepository := repositories.NewReportingRepository(db)
+
+	filter := models.RevenueFilter{Statuses: []string{"Confirmed", "CheckedIn"}}
+
+	legacy, err := legacyRevenueBySlot(ctx, bookingRepository, showRepository, slotRepository)
+	if err != nil {
+		b.Fatalf("Error running in-memory aggregation: %v", err)
+	}
+	sql, err := sqlRevenueBySlot(ctx, reportingRepository, filter)
+	if err != nil {
+		b.Fatalf("Error running SQL aggregation: %v", err)
+	}
+	if mismatches := compareGroups(b, legacy, sql); mismatches > 0 {
+		b.Fatalf("%d slot group(s) differ between strategies", mismatches)
+	}
+
+	b.Run("in-memory", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := legacyRevenueBySlot(ctx, bookingRepository, showRepository, slotRepository); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("sql", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sqlRevenueBySlot(ctx, reportingRepository, filter); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func seedBookings(ctx context.Context, db *pgxpool.Pool, count int) error {
+	query := `
+		INSERT INTO booking (date, show_id, customer_id, no_of_seats, amount_paid, status, booking_time, payment_type)
+		SELECT s.date, s.id, $1, seats, s.cost * seats, 'Confirmed', NOW() - (random() * INTERVAL '730 days'), 'Cash'
+		FROM generate_series(1, $2) AS g
+		CROSS JOIN LATERAL (SELECT id, date, cost FROM show WHERE g > 0 ORDER BY random() LIMIT 1) s
+		CROSS JOIN LATERAL (SELECT 1 + floor(random() * 10)::int AS seats WHERE g > 0) n
+	`
+	result, err := db.Exec(ctx, query, benchmarkCustomerID, count)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("no shows found, run make seedData first")
+	}
+	return nil
+}
+
+func cleanupBookings(ctx context.Context, b *testing.B, db *pgxpool.Pool) {
+	if _, err := db.Exec(ctx, `DELETE FROM booking WHERE customer_id = $1`, benchmarkCustomerID); err != nil {
+		b.Errorf("Error removing seeded bookings: %v", err)
+	}
+}
+
+func sqlRevenueBySlot(ctx context.Context, reportingRepo repositories.ReportingRepository, filter models.RevenueFilter) (map[string]models.RevenueAggregate, error) {
+	groups, err := reportingRepo.GetRevenueGroups(ctx, filter, []models.RevenueGroupKey{models.RevenueGroupBySlot}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]models.RevenueAggregate, len(groups))
+	for _, g := range groups {
+		result[g.SlotName] = g
+	}
+	return result, nil
+}
+
+// legacyRevenueBySlot reproduces the previous dashboard strategy: load every booking,
+// resolve shows and slots one by one, and aggregate in Go.
+func legacyRevenueBySlot(ctx context.Context, bookingRepo repositories.BookingRepository, showRepo repositories.ShowRepository, slotRepo repositories.SlotRepository) (map[string]models.RevenueAggregate, error) {
+	bookings, err := bookingRepo.FindBookingsByStatus(ctx, []string{"Confirmed", "CheckedIn"})
+	if err != nil {
+		return nil, err
+	}
+
+	slotNames := make(map[int]string)
+	groups := make(map[string][]*models.Booking)
+	for _, booking := range bookings {
+		show, err := showRepo.FindById(ctx, booking.ShowId)
+		if err != nil || show == nil {
+			continue
+		}
+		name, exists := slotNames[show.SlotId]
+		if !exists {
+			slot, err := slotRepo.GetSlotById(ctx, show.SlotId)
+			if err != nil || slot == nil {
+				continue
+			}
+			name = slot.Name
+			slotNames[show.SlotId] = name
+		}
+		groups[name] = append(groups[name], booking)
+	}
+
+	result := make(map[string]models.RevenueAggregate, len(groups))
+	for name, group := range groups {
+		total := decimal.Zero
+		amounts := make([]decimal.Decimal, 0, len(group))
+		seats := 0
+		for _, booking := range group {
+			total, _ = total.Add(booking.AmountPaid)
+			amounts = append(amounts, booking.AmountPaid)
+			seats += booking.NoOfSeats
+		}
+		sort.Slice(amounts, func(i, j int) bool { return amounts[i].Cmp(amounts[j]) < 0 })
+
+		middle := len(amounts) / 2
+		median := amounts[middle]
+		if len(amounts)%2 == 0 {
+			sum, _ := amounts[middle-1].Add(amounts[middle])
+			median, _ = sum.Quo(decimal.Two)
+		}
+
+		totalFloat, _ := total.Float64()
+		medianFloat, _ := median.Float64()
+		result[name] = models.RevenueAggregate{
+			SlotName:      name,
+			TotalRevenue:  totalFloat,
+			MedianRevenue: medianFloat,
+			TotalBookings: len(group),
+			TotalSeats:    seats,
+		}
+	}
+
+	return result, nil
+}
+
+func compareGroups(b *testing.B, legacy, sql map[string]models.RevenueAggregate) int {
+	mismatches := 0
+	for name, want := range legacy {
+		got, exists := sql[name]
+		if !exists || got.TotalBookings != want.TotalBookings || got.TotalSeats != want.TotalSeats ||
+			!closeEnough(got.TotalRevenue, want.TotalRevenue) || !closeEnough(got.MedianRevenue, want.MedianRevenue) {
+			b.Logf("%-20s in-memory=%+v sql=%+v", name, want, got)
+			mismatches++
+		}
+	}
+	for name, got := range sql {
+		if _, exists := legacy[name]; !exists {
+			b.Logf("%-20s in-memory=<missing> sql=%+v", name, got)
+			mismatches++
+		}
+	}
+	return mismatches
+}
+
+func closeEnough(a, b float64) bool {
+	diff := a - b
+	return diff < 0.01 && diff > -0.01
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LoyaltyTier struct {
+	Name      string
+	MinPoints int
+}
+
+type LoyaltyConfig struct {
+	PointsPerRupee float64
+	RupeesPerPoint float64
+	// Tiers are ordered from the lowest threshold to the highest.
+	Tiers []LoyaltyTier
+}
+
+func GetLoyaltyConfig() LoyaltyConfig {
+	return LoyaltyConfig{
+		PointsPerRupee: getFloatEnvOrDefault("LOYALTY_POINTS_PER_RUPEE", 1),
+		RupeesPerPoint: getFloatEnvOrDefault("LOYALTY_RUPEES_PER_POINT", 0.1),
+		Tiers: []LoyaltyTier{
+			{Name: "Bronze", MinPoints: 0},
+			{Name: "Silver", MinPoints: getIntEnvOrDefault("LOYALTY_SILVER_THRESHOLD", 2000)},
+			{Name: "Gold", MinPoints: getIntEnvOrDefault("LOYALTY_GOLD_THRESHOLD", 5000)},
+			{Name: "Platinum", MinPoints: getIntEnvOrDefault("LOYALTY_PLATINUM_THRESHOLD", 15000)},
+		},
+	}
+}
+
+func getFloatEnvOrDefault(key string, defaultValue float64) float64 {
+	value := getEnvOrDefault(key, "")
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 {
+		log.Warn().Str("key", key).Str("value", value).Msg("Invalid number in environment, using default")
+		return defaultValue
+	}
+	return parsed
+}
+
+func getIntEnvOrDefault(key string, defaultValue int) int {
+	value := getEnvOrDefault(key, "")
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		log.Warn().Str("key", key).Str("value", value).Msg("Invalid integer in environment, using default")
+		return defaultValue
+	}
+	return parsed
+}
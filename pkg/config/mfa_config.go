@@ -0,0 +1,13 @@
+package config
+
+type MFAConfig struct {
+	// SecretKey encrypts TOTP secrets at rest. It is a base64 encoded 32 byte key; changing it
+	// makes existing enrollments unreadable, so every user would have to enroll again.
+	SecretKey string
+}
+
+func GetMFAConfig() MFAConfig {
+	return MFAConfig{
+		SecretKey: getEnvOrDefault("MFA_SECRET_KEY", ""),
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// PasswordPolicy holds the password rules for one role.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSpecial   bool
+	// HistoryDepth is how many of the most recent passwords, counting the current one, cannot
+	// be chosen again.
+	HistoryDepth int
+	// MaxAge forces a password change at login once the password is older than this. Zero
+	// means passwords never expire.
+	MaxAge time.Duration
+	// RejectBreached refuses passwords found in the bundled breached-password list.
+	RejectBreached bool
+}
+
+type PasswordPolicyConfig struct {
+	Policies map[string]PasswordPolicy
+}
+
+// ForRole returns the policy for role, falling back to the customer policy for unknown roles.
+func (c PasswordPolicyConfig) ForRole(role string) PasswordPolicy {
+	if policy, ok := c.Policies[role]; ok {
+		return policy
+	}
+	return c.Policies["customer"]
+}
+
+// GetPasswordPolicyConfig reads each role's policy from PASSWORD_<ROLE>_* variables. The
+// customer defaults match the rules signup has always used; admin and staff accounts get a
+// stricter policy with expiry.
+func GetPasswordPolicyConfig() PasswordPolicyConfig {
+	return PasswordPolicyConfig{
+		Policies: map[string]PasswordPolicy{
+			"customer": getPasswordPolicy("customer", PasswordPolicy{
+				MinLength:        8,
+				RequireUppercase: true,
+				RequireLowercase: true,
+				RequireSpecial:   true,
+				HistoryDepth:     3,
+				RejectBreached:   true,
+			}),
+			"staff": getPasswordPolicy("staff", PasswordPolicy{
+				MinLength:        12,
+				RequireUppercase: true,
+				RequireLowercase: true,
+				RequireDigit:     true,
+				RequireSpecial:   true,
+				HistoryDepth:     5,
+				MaxAge:           90 * 24 * time.Hour,
+				RejectBreached:   true,
+			}),
+			"admin": getPasswordPolicy("admin", PasswordPolicy{
+				MinLength:        12,
+				RequireUppercase: true,
+				RequireLowercase: true,
+				RequireDigit:     true,
+				RequireSpecial:   true,
+				HistoryDepth:     5,
+				MaxAge:           90 * 24 * time.Hour,
+				RejectBreached:   true,
+			}),
+		},
+	}
+}
+
+func getPasswordPolicy(role string, defaults PasswordPolicy) PasswordPolicy {
+	prefix := "PASSWORD_" + strings.ToUpper(role) + "_"
+	defaultMaxAgeDays := int(defaults.MaxAge / (24 * time.Hour))
+
+	policy := PasswordPolicy{
+		MinLength:        getIntEnvOrDefault(prefix+"MIN_LENGTH", defaults.MinLength),
+		RequireUppercase: getBoolEnvOrDefault(prefix+"REQUIRE_UPPERCASE", defaults.RequireUppercase),
+		RequireLowercase: getBoolEnvOrDefault(prefix+"REQUIRE_LOWERCASE", defaults.RequireLowercase),
+		RequireDigit:     getBoolEnvOrDefault(prefix+"REQUIRE_DIGIT", defaults.RequireDigit),
+		RequireSpecial:   getBoolEnvOrDefault(prefix+"REQUIRE_SPECIAL", defaults.RequireSpecial),
+		HistoryDepth:     getIntEnvOrDefault(prefix+"HISTORY_DEPTH", defaults.HistoryDepth),
+		MaxAge:           time.Duration(getIntEnvOrDefault(prefix+"MAX_AGE_DAYS", defaultMaxAgeDays)) * 24 * time.Hour,
+		RejectBreached:   getBoolEnvOrDefault(prefix+"REJECT_BREACHED", defaults.RejectBreached),
+	}
+
+	// The current password always counts as history, and an empty password is never allowed.
+	if policy.HistoryDepth < 1 {
+		policy.HistoryDepth = 1
+	}
+	if policy.MinLength < 1 {
+		policy.MinLength = 1
+	}
+
+	return policy
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+type PublicAPIConfig struct {
+	// RateLimitPerMinute and RateLimitBurst size the per-client token bucket for the public
+	// API. They do not affect the authenticated APIs.
+	RateLimitPerMinute int
+	RateLimitBurst     int
+	// CacheMaxAge is sent as the Cache-Control max-age of public responses.
+	CacheMaxAge time.Duration
+	// TrustedProxies lists the proxy IPs or CIDRs whose X-Forwarded-For header is believed when
+	// working out the client IP. When empty the connecting address is used, so clients cannot
+	// pick their own rate limit bucket.
+	TrustedProxies []string
+}
+
+func GetPublicAPIConfig() PublicAPIConfig {
+	return PublicAPIConfig{
+		RateLimitPerMinute: getIntEnvOrDefault("PUBLIC_API_RATE_LIMIT_PER_MINUTE", 120),
+		RateLimitBurst:     getIntEnvOrDefault("PUBLIC_API_RATE_LIMIT_BURST", 30),
+		CacheMaxAge:        time.Duration(getIntEnvOrDefault("PUBLIC_API_CACHE_MAX_AGE_SECONDS", 60)) * time.Second,
+		TrustedProxies:     getListEnv("TRUSTED_PROXIES"),
+	}
+}
+
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnvOrDefault(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
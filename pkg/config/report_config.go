@@ -0,0 +1,14 @@
+package config
+
+type ReportConfig struct {
+	// Sender picks how finished reports are delivered: "log" or "webhook".
+	Sender     string
+	WebhookURL string
+}
+
+func GetReportConfig() ReportConfig {
+	return ReportConfig{
+		Sender:     getEnvOrDefault("REPORT_SENDER", "log"),
+		WebhookURL: getEnvOrDefault("REPORT_WEBHOOK_URL", ""),
+	}
+}
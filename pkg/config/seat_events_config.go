@@ -0,0 +1,13 @@
+package config
+
+type SeatEventsConfig struct {
+	// Backend picks how seat events reach seat map streams: "memory" delivers them within
+	// this instance only, "postgres" relays them between instances with LISTEN/NOTIFY.
+	Backend string
+}
+
+func GetSeatEventsConfig() SeatEventsConfig {
+	return SeatEventsConfig{
+		Backend: getEnvOrDefault("SEAT_EVENTS_BACKEND", "memory"),
+	}
+}
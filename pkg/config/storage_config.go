@@ -0,0 +1,28 @@
+package config
+
+type StorageConfig struct {
+	// Driver picks where profile images and reports are stored: "s3" (AWS), "minio" (any
+	// S3-compatible endpoint) or "local" (a directory served through signed Skyfox URLs). When
+	// empty, s3 is used if S3_BUCKET is set and local otherwise.
+	Driver   string
+	Region   string
+	Bucket   string
+	Endpoint string
+	// LocalDir, PublicURL and SigningSecret are used by the local driver only. PublicURL is the
+	// externally reachable base URL of this server; signed file URLs are built on it.
+	LocalDir      string
+	PublicURL     string
+	SigningSecret string
+}
+
+func GetStorageConfig() StorageConfig {
+	return StorageConfig{
+		Driver:        getEnvOrDefault("STORAGE_DRIVER", ""),
+		Region:        getEnvOrDefault("AWS_REGION", ""),
+		Bucket:        getEnvOrDefault("S3_BUCKET", ""),
+		Endpoint:      getEnvOrDefault("S3_ENDPOINT", ""),
+		LocalDir:      getEnvOrDefault("STORAGE_LOCAL_DIR", "./storage"),
+		PublicURL:     getEnvOrDefault("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		SigningSecret: getEnvOrDefault("STORAGE_SIGNING_SECRET", ""),
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+type VerificationConfig struct {
+	// Sender picks how verification codes reach customers: "log" or "webhook".
+	Sender     string
+	WebhookURL string
+	// RequireForBooking and RequireForWalletTopUp block those actions until the customer has
+	// verified their email or phone number.
+	RequireForBooking     bool
+	RequireForWalletTopUp bool
+}
+
+func GetVerificationConfig() VerificationConfig {
+	return VerificationConfig{
+		Sender:                getEnvOrDefault("VERIFICATION_SENDER", "log"),
+		WebhookURL:            getEnvOrDefault("VERIFICATION_WEBHOOK_URL", ""),
+		RequireForBooking:     getBoolEnvOrDefault("REQUIRE_VERIFIED_CONTACT_FOR_BOOKING", false),
+		RequireForWalletTopUp: getBoolEnvOrDefault("REQUIRE_VERIFIED_CONTACT_FOR_WALLET_TOP_UP", false),
+	}
+}
+
+func getBoolEnvOrDefault(key string, defaultValue bool) bool {
+	value := getEnvOrDefault(key, "")
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warn().Str("key", key).Str("value", value).Msg("Invalid boolean in environment, using default")
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,15 @@
+package config
+
+type WatchlistConfig struct {
+	// Notifier picks how new-show alerts reach customers outside the app: "log" or "webhook".
+	// Alerts are always kept for the in-app feed as well.
+	Notifier   string
+	WebhookURL string
+}
+
+func GetWatchlistConfig() WatchlistConfig {
+	return WatchlistConfig{
+		Notifier:   getEnvOrDefault("WATCHLIST_NOTIFIER", "log"),
+		WebhookURL: getEnvOrDefault("WATCHLIST_WEBHOOK_URL", ""),
+	}
+}
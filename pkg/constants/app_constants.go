@@ -1,13 +1,23 @@
 package constants
 
+import "time"
+
 const (
 	// No Auth Routes
 	LoginEndPoint                = "/login"
+	MFALoginEndPoint             = "/login/mfa"
 	SkyCustomerSignUpEndPoint    = "/customer/signup"
 	SecurityQuestionsEndPoint    = "/security-questions"
 	ByEmailEndPoint              = "/by-email"
 	VerifySecurityAnswerEndPoint = "/verify-security-answer"
 	ForgotPasswordEndPoint       = "/forgot-password"
+	FilesEndPoint                = "/files"
+	FileKeyEndPoint              = "/*key"
+	// Public Listings
+	PublicEndPoint        = "/public"
+	PublicShowsEndPoint   = "/shows"
+	PublicMoviesEndPoint  = "/movies"
+	PublicMovieIdEndPoint = "/movies/:movie_id"
 	// Shows Page
 	ShowsEndPoint          = "/shows"
 	ShowEndPoint           = "show"
@@ -15,6 +25,8 @@ const (
 	AllSlotEndPoint        = "/slot-all"
 	MoviesEndPoint         = "/movies"
 	BookingSeatMapEndPoint = "/:show_id/seat-map"
+	SeatMapStreamEndPoint  = "/:show_id/seat-map/stream"
+	ShowSearchEndPoint     = "/search"
 	// Role Endpoints
 	SkyCustomerEndPoint = "/customer"
 	AdminEndPoint       = "/admin"
@@ -25,10 +37,24 @@ const (
 	ProfileEndPoint            = "/profile"
 	UpdateProfileEndPoint      = "/update-profile"
 	UpdateProfileImageEndPoint = "/update-profile-image"
+	DataExportEndPoint         = "/data-export"
+	DeleteAccountEndPoint      = "/delete-account"
+	// MFA Endpoints
+	MFAEndPoint              = "/mfa"
+	MFAEnrollEndPoint        = "/enroll"
+	MFAEnrollConfirmEndPoint = "/enroll/confirm"
+	MFARecoveryCodesEndPoint = "/recovery-codes"
+	MFADisableEndPoint       = "/disable"
+	MFAPolicyEndPoint        = "/mfa-policy"
+	// Contact Verification Endpoints
+	VerificationEndPoint        = "/verification"
+	RequestVerificationEndPoint = "/request"
+	ConfirmVerificationEndPoint = "/confirm"
 	// Booking Related Endpoints
 	BookingsEndpoint              = "/bookings"
 	LatestBookingsEndpoint        = "/latest"
 	CreateCustomerBookingEndpoint = "/create-customer-booking"
+	RefundBookingEndpoint         = "/booking/refund"
 	BookingEndpoint               = "/booking"
 	BookingIdEndpoint             = "/booking/:id"
 	QREndpoint                    = "/qr"
@@ -37,18 +63,159 @@ const (
 	CancelBookingEndpoint         = "/:id/cancel"
 	PaymentEndpoint               = "/payment"
 	// Checkin Related Endpoints
-	CheckinEndpoint = "/check-in"
+	CheckinEndpoint        = "/check-in"
+	CheckinSummaryEndpoint = "/summary"
+	// Booking Console Endpoints
+	ConsoleEndpoint          = "/console"
+	ConsoleBookingIdEndpoint = "/bookings/:id"
 	// Admin Dashboard Related Endpoints
 	RevenueEndpoint    = "/revenue"
+	OccupancyEndpoint  = "/occupancy"
+	ForecastEndpoint   = "/forecast"
 	BookingCSVEndpoint = "/booking-csv"
+	// Scheduled Report Endpoints
+	ReportsEndpoint           = "/reports"
+	ReportSchedulesEndpoint   = "/schedules"
+	ReportScheduleIdEndpoint  = "/schedules/:id"
+	RunReportScheduleEndpoint = "/schedules/:id/run"
+	ReportRunsEndpoint        = "/runs"
+	ReportDownloadEndpoint    = "/runs/:id/download"
 	// Wallet Related Endpoints
-	WalletEndpoint       = "/wallet"
-	AddFundsEndpoint     = "/add-funds"
-	TransactionsEndpoint = "/transactions"
+	WalletEndpoint          = "/wallet"
+	AddFundsEndpoint        = "/add-funds"
+	TransactionsEndpoint    = "/transactions"
+	AdjustmentEndpoint      = "/adjustment"
+	CreditEndpoint          = "/credit"
+	TransfersEndpoint       = "/transfers"
+	ConfirmTransferEndpoint = "/transfers/:id/confirm"
+	StatementEndpoint       = "/statement"
+	// Loyalty Related Endpoints
+	LoyaltyEndpoint        = "/loyalty"
+	LoyaltyReverseEndpoint = "/loyalty/reverse"
+	// Saved Card Related Endpoints
+	SavedCardsEndpoint  = "/saved-cards"
+	SavedCardIdEndpoint = "/:id"
+	// Watchlist Related Endpoints
+	WatchlistEndpoint           = "/watchlist"
+	WatchlistMovieIdEndpoint    = "/:movie_id"
+	WatchlistFeedEndpoint       = "/feed"
+	WatchlistAlertsEndpoint     = "/alerts"
+	WatchlistAlertsReadEndpoint = "/alerts/read"
+	// Movie Review Related Endpoints
+	MovieReviewsEndpoint  = "/movies/:movie_id/reviews"
+	ReviewsEndpoint       = "/reviews"
+	ReviewMovieIdEndpoint = "/:movie_id"
+	HideReviewEndpoint    = "/:id/hide"
+	UnhideReviewEndpoint  = "/:id/unhide"
+)
+
+const (
+	TOTAL_NO_OF_SEATS             = 100
+	MAX_NO_OF_SEATS_PER_BOOKING   = 10
+	DELUXE_OFFSET                 = 150.0
+	MAX_SAVED_CARDS_PER_CUSTOMER  = 5
+	MIN_WALLET_TRANSFER_AMOUNT    = 1.0
+	MAX_WALLET_TRANSFER_AMOUNT    = 5000.0
+	MAX_DAILY_WALLET_TRANSFER     = 10000.0
+	DEFAULT_REVENUE_FORECAST_DAYS = 14
+	REVENUE_FORECAST_HISTORY_DAYS = 180
+	DEFAULT_HISTORY_PAGE_SIZE     = 20
+)
+
+const WALLET_CREDIT_EXPIRY_SWEEP_INTERVAL = 15 * time.Minute
+
+const (
+	REPORT_SCHEDULER_INTERVAL  = time.Minute
+	REPORT_DOWNLOAD_URL_EXPIRY = 15 * time.Minute
+	REPORT_DELIVERY_URL_EXPIRY = 7 * 24 * time.Hour
+	DEFAULT_REPORT_RUNS_LIMIT  = 50
+	REPORT_RUN_MAX_ATTEMPTS    = 4
+	REPORT_RUN_RETRY_DELAY     = 5 * time.Minute
+)
+
+const (
+	PROFILE_IMAGE_MAX_BYTES       = 5 << 20
+	PROFILE_IMAGE_MAX_DIMENSION   = 6000
+	PROFILE_IMAGE_MIN_DIMENSION   = 96
+	PROFILE_IMAGE_THUMBNAIL_SIZE  = 96
+	PROFILE_IMAGE_MEDIUM_SIZE     = 320
+	PROFILE_IMAGE_DEFAULT_VARIANT = "medium"
+
+	PROFILE_IMAGE_CLEANUP_INTERVAL    = 6 * time.Hour
+	PROFILE_IMAGE_ORPHAN_GRACE_PERIOD = time.Hour
+)
+
+const (
+	MAX_WATCHLIST_MOVIES_PER_CUSTOMER = 50
+	WATCHLIST_FEED_LIMIT              = 50
+	WATCHLIST_ALERTS_LIMIT            = 50
+
+	WATCHLIST_ALERT_DELIVERY_INTERVAL     = time.Minute
+	WATCHLIST_ALERT_DELIVERY_BATCH_SIZE   = 500
+	WATCHLIST_ALERT_DELIVERY_LEASE        = 5 * time.Minute
+	WATCHLIST_ALERT_MAX_DELIVERY_ATTEMPTS = 10
+)
+
+const DEFAULT_REVIEW_PAGE_SIZE = 20
+
+// Customers can view and book shows from today up to CUSTOMER_BOOKING_WINDOW_DAYS-1 days ahead.
+const CUSTOMER_BOOKING_WINDOW_DAYS = 7
+
+// Public listings cover the same days customers can book.
+const (
+	PUBLIC_LISTING_DAYS      = CUSTOMER_BOOKING_WINDOW_DAYS
+	PUBLIC_MOVIE_SHOWS_LIMIT = 50
+)
+
+// Customers search the same days they can book, while admins and staff may search up to
+// SHOW_SEARCH_MAX_DAYS at a time.
+const (
+	DEFAULT_SHOW_SEARCH_PAGE_SIZE = 20
+	SHOW_SEARCH_MAX_DAYS          = 31
+)
+
+const (
+	DATA_EXPORT_PAGE_SIZE   = 500
+	DELETED_CUSTOMER_NAME   = "Deleted Customer"
+	DELETED_CUSTOMER_NUMBER = "0000000000"
+)
+
+// Scoped JWTs carry a "purpose" claim and are only accepted where that purpose is expected.
+const (
+	TOKEN_PURPOSE_MFA_CHALLENGE   = "mfa_challenge"
+	TOKEN_PURPOSE_MFA_ENROLLMENT  = "mfa_enrollment"
+	TOKEN_PURPOSE_PASSWORD_CHANGE = "password_change"
+)
+
+// Issued at login instead of a session token when the password is older than the role allows.
+const PASSWORD_CHANGE_TOKEN_TTL = 15 * time.Minute
+
+const (
+	MFA_ISSUER               = "Skyfox"
+	MFA_CHALLENGE_TOKEN_TTL  = 5 * time.Minute
+	MFA_ENROLLMENT_TOKEN_TTL = 15 * time.Minute
+	MFA_RECOVERY_CODE_COUNT  = 10
+	MFA_MAX_FAILED_ATTEMPTS  = 5
+	MFA_LOCKOUT_DURATION     = 15 * time.Minute
+)
+
+const (
+	VERIFICATION_CODE_LENGTH          = 6
+	VERIFICATION_CODE_TTL             = 10 * time.Minute
+	VERIFICATION_CODE_RESEND_INTERVAL = time.Minute
+	VERIFICATION_CODE_MAX_ATTEMPTS    = 5
+)
+
+const (
+	SEAT_EVENTS_HEARTBEAT_INTERVAL = 20 * time.Second
+	SEAT_EVENTS_RECONNECT_DELAY    = 5 * time.Second
+	SEAT_EVENTS_SUBSCRIBER_BUFFER  = 64
 )
 
 const (
-	TOTAL_NO_OF_SEATS           = 100
-	MAX_NO_OF_SEATS_PER_BOOKING = 10
-	DELUXE_OFFSET               = 150.0
+	LEDGER_ACCOUNT_OPENING_BALANCE = "OPENING_BALANCE"
+	LEDGER_ACCOUNT_CARD_CLEARING   = "CARD_CLEARING"
+	LEDGER_ACCOUNT_BOOKING_REVENUE = "BOOKING_REVENUE"
+	LEDGER_ACCOUNT_ADJUSTMENTS     = "ADJUSTMENTS"
+	LEDGER_ACCOUNT_GOODWILL        = "GOODWILL"
 )
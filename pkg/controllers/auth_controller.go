@@ -27,19 +27,53 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	user, token, err := c.userService.Login(ctx, loginRequest.Username, loginRequest.Password)
+	result, err := c.userService.Login(ctx, loginRequest.Username, loginRequest.Password)
 	if err != nil {
 		utils.HandleErrorResponse(ctx, err, requestID)
 		return
 	}
 
-	loginResponse := response.LoginResponse{
+	message := "Login successful"
+	if result.MFARequired {
+		message = "MFA code required"
+	} else if result.MFAEnrollmentRequired {
+		message = "MFA enrollment required"
+	} else if result.PasswordChangeRequired {
+		message = "Password has expired and must be changed"
+	}
+
+	utils.SendOKResponse(ctx, message, requestID, toLoginResponse(result))
+}
+
+func (c *AuthController) LoginMFA(ctx *gin.Context) {
+	var mfaLoginRequest request.MFALoginRequest
+	requestID := utils.GetRequestID(ctx)
+
+	if err := ctx.ShouldBindJSON(&mfaLoginRequest); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request body", err), requestID)
+		return
+	}
+
+	result, err := c.userService.CompleteMFALogin(ctx, mfaLoginRequest.MFAToken, mfaLoginRequest.Code)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Login successful", requestID, toLoginResponse(result))
+}
+
+func toLoginResponse(result *services.LoginResult) response.LoginResponse {
+	return response.LoginResponse{
 		User: response.UserInfo{
-			Username: user.Username,
-			Role:     user.Role,
+			Username: result.User.Username,
+			Role:     result.User.Role,
 		},
-		Token: token,
+		Token:                  result.Token,
+		MFARequired:            result.MFARequired,
+		MFAEnrollmentRequired:  result.MFAEnrollmentRequired,
+		MFAToken:               result.MFAToken,
+		PasswordChangeRequired: result.PasswordChangeRequired,
+		PasswordChangeToken:    result.PasswordChangeToken,
 	}
-
-	utils.SendOKResponse(ctx, "Login successful", requestID, loginResponse)
 }
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+type BookingConsoleController struct {
+	bookingConsoleService services.BookingConsoleService
+}
+
+func NewBookingConsoleController(bookingConsoleService services.BookingConsoleService) *BookingConsoleController {
+	return &BookingConsoleController{
+		bookingConsoleService: bookingConsoleService,
+	}
+}
+
+func (bc *BookingConsoleController) SearchBookings(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.BookingSearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	bookings, err := bc.bookingConsoleService.SearchBookings(ctx, req)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Bookings fetched successfully", requestID, bookings)
+}
+
+func (bc *BookingConsoleController) GetBookingDetail(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	bookingID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || bookingID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_BOOKING_ID", "Booking ID must be a valid positive integer", err), requestID)
+		return
+	}
+
+	detail, err := bc.bookingConsoleService.GetBookingDetail(ctx, bookingID)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Booking details fetched successfully", requestID, detail)
+}
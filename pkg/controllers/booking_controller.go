@@ -3,7 +3,6 @@ package controllers
 import (
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -90,7 +89,14 @@ func (bc *BookingController) CreateAdminBooking(ctx *gin.Context) {
 		return
 	}
 
-	booking, err := bc.adminBookingService.CreateAdminBooking(ctx.Request.Context(), bookingRequest)
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+	adminUsername := claims["username"].(string)
+
+	booking, err := bc.adminBookingService.CreateAdminBooking(ctx.Request.Context(), adminUsername, bookingRequest)
 	if err != nil {
 		log.Error().Err(err).Interface("request", bookingRequest).Msg("Failed to create admin booking")
 		utils.HandleErrorResponse(ctx, err, requestID)
@@ -100,6 +106,36 @@ func (bc *BookingController) CreateAdminBooking(ctx *gin.Context) {
 	utils.SendCreatedResponse(ctx, "Booking created successfully", requestID, booking)
 }
 
+func (bc *BookingController) RefundBooking(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var refundRequest request.BookingRefundRequest
+	if err := ctx.ShouldBindJSON(&refundRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+	adminUsername := claims["username"].(string)
+
+	refund, err := bc.adminBookingService.RefundBooking(ctx.Request.Context(), adminUsername, refundRequest)
+	if err != nil {
+		log.Error().Err(err).Str("admin", adminUsername).Int("bookingID", refundRequest.BookingID).Msg("Failed to refund booking")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Booking refunded successfully", requestID, refund)
+}
+
 func (bc *BookingController) InitializeCustomerBooking(ctx *gin.Context) {
 	requestID := utils.GetRequestID(ctx)
 
@@ -139,7 +175,7 @@ func (bc *BookingController) ProcessPayment(ctx *gin.Context) {
         if validationErrs, ok := err.(validator.ValidationErrors); ok {
             var relevantErrors validator.ValidationErrors
             for _, fieldErr := range validationErrs {
-                if fieldErr.Field() == "BookingID" || fieldErr.Field() == "PaymentMethod" {
+                if fieldErr.Field() == "BookingID" || fieldErr.Field() == "PaymentMethod" || fieldErr.Field() == "SavedCardID" {
                     relevantErrors = append(relevantErrors, fieldErr)
                 }
             }
@@ -305,7 +341,13 @@ func (c *BookingController) GetCustomerBookings(ctx *gin.Context) {
 	}
 	username, _ := claims["username"].(string)
 
-	bookings, err := c.customerBookingService.GetBookingsForCustomer(ctx, username)
+	var req request.CustomerBookingsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), utils.GetRequestID(ctx))
+		return
+	}
+
+	bookings, err := c.customerBookingService.GetBookingsForCustomer(ctx, username, req)
 	if err != nil {
 		utils.HandleErrorResponse(ctx, err, utils.GetRequestID(ctx))
 		return
@@ -330,7 +372,13 @@ func (c *BookingController) GetCustomerLatestBooking(ctx *gin.Context) {
 }
 
 func (c *BookingController) GetCheckInBookings(ctx *gin.Context) {
-	bookings, err := c.checkInService.FindConfirmedBookings(ctx)
+	var req request.CheckInBookingsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), utils.GetRequestID(ctx))
+		return
+	}
+
+	bookings, err := c.checkInService.FindCheckInBookings(ctx, req)
 	if err != nil {
 		utils.HandleErrorResponse(ctx, err, utils.GetRequestID(ctx))
 		return
@@ -338,6 +386,24 @@ func (c *BookingController) GetCheckInBookings(ctx *gin.Context) {
 	utils.SendOKResponse(ctx, "Confirmed bookings fetched successfully", utils.GetRequestID(ctx), bookings)
 }
 
+func (c *BookingController) GetCheckInSummary(ctx *gin.Context) {
+	var scope request.CheckInScope
+	if err := ctx.ShouldBindQuery(&scope); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), utils.GetRequestID(ctx))
+		return
+	}
+
+	summary, err := c.checkInService.GetCheckInSummary(ctx, scope)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, utils.GetRequestID(ctx))
+		return
+	}
+
+	// Door tablets poll this endpoint, so intermediaries must not serve stale counts.
+	ctx.Header("Cache-Control", "no-store")
+	utils.SendOKResponse(ctx, "Check-in summary fetched successfully", utils.GetRequestID(ctx), summary)
+}
+
 func (c *BookingController) BulkCheckInBookings(ctx *gin.Context) {
 	var req request.BulkCheckInRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -345,7 +411,14 @@ func (c *BookingController) BulkCheckInBookings(ctx *gin.Context) {
 		return
 	}
 
-	checkedIn, alreadyDone, invalid, err := c.checkInService.MarkBookingsCheckedIn(ctx, req.BookingIDs)
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Unauthorized", err), utils.GetRequestID(ctx))
+		return
+	}
+	staffUsername, _ := claims["username"].(string)
+
+	checkedIn, alreadyDone, invalid, err := c.checkInService.MarkBookingsCheckedIn(ctx, staffUsername, req.BookingIDs)
 	if err != nil {
 		utils.HandleErrorResponse(ctx, err, utils.GetRequestID(ctx))
 		return
@@ -366,7 +439,14 @@ func (c *BookingController) SingleCheckInBooking(ctx *gin.Context) {
 		return
 	}
 
-	checkedIn, alreadyDone, invalid, err := c.checkInService.MarkBookingsCheckedIn(ctx, []int{req.BookingID})
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Unauthorized", err), utils.GetRequestID(ctx))
+		return
+	}
+	staffUsername, _ := claims["username"].(string)
+
+	checkedIn, alreadyDone, invalid, err := c.checkInService.MarkBookingsCheckedIn(ctx, staffUsername, []int{req.BookingID})
 	if err != nil {
 		utils.HandleErrorResponse(ctx, err, utils.GetRequestID(ctx))
 		return
@@ -389,8 +469,6 @@ func (c *BookingController) SingleCheckInBooking(ctx *gin.Context) {
 func (bc *BookingController) DownloadBookingsCSV(ctx *gin.Context) {
 	requestID := utils.GetRequestID(ctx)
 
-	var month, year *int
-
 	monthStr := ctx.Query("month")
 	if monthStr != "" {
 		mVal, err := strconv.Atoi(monthStr)
@@ -398,36 +476,34 @@ func (bc *BookingController) DownloadBookingsCSV(ctx *gin.Context) {
 			utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_MONTH", "Month must be a number between 1 and 12", err), requestID)
 			return
 		}
-		month = &mVal
 	}
 
 	yearStr := ctx.Query("year")
 	if yearStr != "" {
-		yVal, err := strconv.Atoi(yearStr)
-		if err != nil {
+		if _, err := strconv.Atoi(yearStr); err != nil {
 			utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_YEAR", "Year must be a valid number", err), requestID)
 			return
 		}
-		year = &yVal
 	}
 
-	filename := "bookings.csv"
-	if month != nil && year != nil {
-		monthName := time.Month(*month).String()
-		filename = fmt.Sprintf("bookings_%s_%d.csv", monthName, *year)
-	} else if month != nil {
-		monthName := time.Month(*month).String()
-		filename = fmt.Sprintf("bookings_%s.csv", monthName)
-	} else if year != nil {
-		filename = fmt.Sprintf("bookings_%d.csv", *year)
+	var req request.BookingExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	export, err := bc.bookingCSVService.PrepareExport(req)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
 	}
 
-	ctx.Header("Content-Type", "text/csv")
-	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	ctx.Header("Content-Type", export.ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", export.FileName))
 
-	if err := bc.bookingCSVService.WriteBookingsCSV(ctx, ctx.Writer, month, year); err != nil {
+	if err := bc.bookingCSVService.WriteExport(ctx, ctx.Writer, export); err != nil {
 		ctx.Status(500)
-		log.Error().Err(err).Msg("Failed to write bookings CSV")
+		log.Error().Err(err).Msg("Failed to write bookings export")
 		return
 	}
 }
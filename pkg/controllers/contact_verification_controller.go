@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+type ContactVerificationController struct {
+	contactVerificationService services.ContactVerificationService
+}
+
+func NewContactVerificationController(contactVerificationService services.ContactVerificationService) *ContactVerificationController {
+	return &ContactVerificationController{
+		contactVerificationService: contactVerificationService,
+	}
+}
+
+func (vc *ContactVerificationController) RequestVerificationCode(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify credentials", err), requestID)
+		return
+	}
+
+	username, ok := claims["username"].(string)
+	if !ok || username == "" {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Invalid token claims", nil), requestID)
+		return
+	}
+
+	var req request.RequestVerificationCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	result, err := vc.contactVerificationService.RequestCode(ctx.Request.Context(), username, req.Channel)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Verification code sent successfully", requestID, result)
+}
+
+func (vc *ContactVerificationController) ConfirmVerificationCode(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify credentials", err), requestID)
+		return
+	}
+
+	username, ok := claims["username"].(string)
+	if !ok || username == "" {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Invalid token claims", nil), requestID)
+		return
+	}
+
+	var req request.ConfirmVerificationCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	result, err := vc.contactVerificationService.ConfirmCode(ctx.Request.Context(), username, req.Channel, req.Code)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Contact verified successfully", requestID, result)
+}
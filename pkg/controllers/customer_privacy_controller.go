@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type CustomerPrivacyController struct {
+	customerPrivacyService services.CustomerPrivacyService
+}
+
+func NewCustomerPrivacyController(customerPrivacyService services.CustomerPrivacyService) *CustomerPrivacyController {
+	return &CustomerPrivacyController{
+		customerPrivacyService: customerPrivacyService,
+	}
+}
+
+func (pc *CustomerPrivacyController) ExportCustomerData(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.CustomerDataExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+	if req.Format == "" {
+		req.Format = "json"
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify credentials", err), requestID)
+		return
+	}
+
+	username, ok := claims["username"].(string)
+	if !ok || username == "" {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Invalid token claims", nil), requestID)
+		return
+	}
+
+	export, err := pc.customerPrivacyService.ExportCustomerData(ctx.Request.Context(), username, req.Format)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to prepare customer data export")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	ctx.Header("Content-Type", export.ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", export.FileName))
+
+	if err := export.Write(ctx.Writer); err != nil {
+		ctx.Status(500)
+		log.Error().Err(err).Str("username", username).Msg("Failed to write customer data export")
+	}
+}
+
+func (pc *CustomerPrivacyController) DeleteAccount(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify credentials", err), requestID)
+		return
+	}
+
+	username, ok := claims["username"].(string)
+	if !ok || username == "" {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Invalid token claims", nil), requestID)
+		return
+	}
+
+	var req request.DeleteAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	result, err := pc.customerPrivacyService.DeleteCustomerAccount(ctx.Request.Context(), username, req.Password)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Account deleted successfully", requestID, result)
+}
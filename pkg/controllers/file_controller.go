@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+type FileController struct {
+	fileServer services.SignedFileServer
+}
+
+func NewFileController(fileServer services.SignedFileServer) *FileController {
+	return &FileController{
+		fileServer: fileServer,
+	}
+}
+
+// ServeFile serves a file from the local storage driver. The URL must carry the expires and
+// signature query parameters produced when it was presigned.
+func (fc *FileController) ServeFile(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	data, contentType, err := fc.fileServer.OpenSigned(ctx.Request.Context(), key, ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Data(200, contentType, data)
+}
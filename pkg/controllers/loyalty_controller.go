@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type LoyaltyController struct {
+	loyaltyService services.LoyaltyService
+}
+
+func NewLoyaltyController(loyaltyService services.LoyaltyService) *LoyaltyController {
+	return &LoyaltyController{
+		loyaltyService: loyaltyService,
+	}
+}
+
+func (lc *LoyaltyController) GetLoyaltySummary(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	summary, err := lc.loyaltyService.GetLoyaltySummary(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get loyalty summary")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Loyalty details retrieved successfully", requestID, summary)
+}
+
+func (lc *LoyaltyController) ReverseBookingPoints(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var reversalRequest request.LoyaltyReversalRequest
+	if err := ctx.ShouldBindJSON(&reversalRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	adminUsername, _ := claims["username"].(string)
+
+	reversal, err := lc.loyaltyService.ReverseBookingPoints(ctx.Request.Context(), adminUsername, reversalRequest)
+	if err != nil {
+		log.Error().Err(err).Str("admin", adminUsername).Int("bookingID", reversalRequest.BookingID).Msg("Failed to reverse loyalty points")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Loyalty points reversed successfully", requestID, reversal)
+}
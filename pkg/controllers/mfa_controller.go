@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+type MFAController struct {
+	mfaService services.MFAService
+}
+
+func NewMFAController(mfaService services.MFAService) *MFAController {
+	return &MFAController{
+		mfaService: mfaService,
+	}
+}
+
+func (mc *MFAController) GetStatus(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	username, role, ok := mfaTokenClaims(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	status, err := mc.mfaService.GetStatus(ctx.Request.Context(), username, role)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "MFA status retrieved successfully", requestID, status)
+}
+
+func (mc *MFAController) BeginEnrollment(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	username, _, ok := mfaTokenClaims(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	enrollment, err := mc.mfaService.BeginEnrollment(ctx.Request.Context(), username)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Scan the QR code with your authenticator app and confirm with a code", requestID, enrollment)
+}
+
+func (mc *MFAController) ConfirmEnrollment(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	username, role, ok := mfaTokenClaims(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	var req request.MFACodeRequest
+	if !bindMFARequest(ctx, &req, requestID) {
+		return
+	}
+
+	result, err := mc.mfaService.ConfirmEnrollment(ctx.Request.Context(), username, role, req.Code)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "MFA enabled successfully, store your recovery codes somewhere safe", requestID, result)
+}
+
+func (mc *MFAController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	username, _, ok := mfaTokenClaims(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	var req request.MFACodeRequest
+	if !bindMFARequest(ctx, &req, requestID) {
+		return
+	}
+
+	result, err := mc.mfaService.RegenerateRecoveryCodes(ctx.Request.Context(), username, req.Code)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Recovery codes regenerated successfully", requestID, result)
+}
+
+func (mc *MFAController) Disable(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	username, role, ok := mfaTokenClaims(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	var req request.DisableMFARequest
+	if !bindMFARequest(ctx, &req, requestID) {
+		return
+	}
+
+	if err := mc.mfaService.Disable(ctx.Request.Context(), username, role, req.Password, req.Code); err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "MFA disabled successfully", requestID, nil)
+}
+
+func (mc *MFAController) GetRolePolicies(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	policies, err := mc.mfaService.GetRolePolicies(ctx.Request.Context())
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "MFA policies retrieved successfully", requestID, policies)
+}
+
+func (mc *MFAController) UpdateRolePolicy(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	username, _, ok := mfaTokenClaims(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateMFAPolicyRequest
+	if !bindMFARequest(ctx, &req, requestID) {
+		return
+	}
+
+	policy, err := mc.mfaService.UpdateRolePolicy(ctx.Request.Context(), username, req.Role, *req.Required)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "MFA policy updated successfully", requestID, policy)
+}
+
+func mfaTokenClaims(ctx *gin.Context, requestID string) (string, string, bool) {
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify credentials", err), requestID)
+		return "", "", false
+	}
+
+	username, ok := claims["username"].(string)
+	role, roleOK := claims["role"].(string)
+	if !ok || username == "" || !roleOK {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("INVALID_TOKEN", "Invalid token claims", nil), requestID)
+		return "", "", false
+	}
+
+	return username, role, true
+}
+
+func bindMFARequest(ctx *gin.Context, req interface{}, requestID string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return false
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieReviewController struct {
+	movieReviewService services.MovieReviewService
+}
+
+func NewMovieReviewController(movieReviewService services.MovieReviewService) *MovieReviewController {
+	return &MovieReviewController{
+		movieReviewService: movieReviewService,
+	}
+}
+
+func (rc *MovieReviewController) GetMovieReviews(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.MovieReviewListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	movieID := ctx.Param("movie_id")
+
+	reviews, err := rc.movieReviewService.GetMovieReviews(ctx.Request.Context(), movieID, req)
+	if err != nil {
+		log.Error().Err(err).Str("movieId", movieID).Msg("Failed to get movie reviews")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Movie reviews retrieved successfully", requestID, reviews)
+}
+
+func (rc *MovieReviewController) GetCustomerReviews(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	reviews, err := rc.movieReviewService.GetCustomerReviews(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get customer reviews")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Reviews retrieved successfully", requestID, reviews)
+}
+
+func (rc *MovieReviewController) SubmitReview(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.SubmitMovieReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	review, err := rc.movieReviewService.SubmitReview(ctx.Request.Context(), username, req)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("movieId", req.MovieId).Msg("Failed to submit movie review")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Review submitted successfully", requestID, review)
+}
+
+func (rc *MovieReviewController) UpdateReview(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.UpdateMovieReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+	movieID := ctx.Param("movie_id")
+
+	review, err := rc.movieReviewService.UpdateReview(ctx.Request.Context(), username, movieID, req)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("movieId", movieID).Msg("Failed to update movie review")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Review updated successfully", requestID, review)
+}
+
+func (rc *MovieReviewController) ListReviews(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.AdminMovieReviewListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	reviews, err := rc.movieReviewService.ListReviews(ctx.Request.Context(), req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list movie reviews")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Reviews retrieved successfully", requestID, reviews)
+}
+
+func (rc *MovieReviewController) HideReview(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	reviewID, ok := reviewIDParam(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	var req request.HideMovieReviewRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			if validationErrs, ok := err.(validator.ValidationErrors); ok {
+				utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+				return
+			}
+			utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+			return
+		}
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	adminUsername, _ := claims["username"].(string)
+
+	review, err := rc.movieReviewService.HideReview(ctx.Request.Context(), reviewID, adminUsername, req.Reason)
+	if err != nil {
+		log.Error().Err(err).Int64("reviewId", reviewID).Msg("Failed to hide movie review")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Review hidden successfully", requestID, review)
+}
+
+func (rc *MovieReviewController) UnhideReview(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	reviewID, ok := reviewIDParam(ctx, requestID)
+	if !ok {
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	adminUsername, _ := claims["username"].(string)
+
+	review, err := rc.movieReviewService.UnhideReview(ctx.Request.Context(), reviewID, adminUsername)
+	if err != nil {
+		log.Error().Err(err).Int64("reviewId", reviewID).Msg("Failed to unhide movie review")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Review unhidden successfully", requestID, review)
+}
+
+func reviewIDParam(ctx *gin.Context, requestID string) (int64, bool) {
+	reviewID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || reviewID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REVIEW_ID", "Review ID must be a valid positive integer", err), requestID)
+		return 0, false
+	}
+	return reviewID, true
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+type DashboardOccupancyController struct {
+	occupancyService services.OccupancyService
+}
+
+func NewDashboardOccupancyController(occupancyService services.OccupancyService) *DashboardOccupancyController {
+	return &DashboardOccupancyController{
+		occupancyService: occupancyService,
+	}
+}
+
+func (c *DashboardOccupancyController) GetOccupancy(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.RevenueDashboardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	if req.Timeframe != "" && req.Timeframe != "all" && (req.Month != nil || req.Year != nil) {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Timeframe cannot be combined with month or year filters", nil), requestID)
+		return
+	}
+
+	occupancyData, err := c.occupancyService.GetOccupancy(ctx, req)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Occupancy data fetched successfully", requestID, occupancyData)
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type PublicListingController struct {
+	publicListingService services.PublicListingService
+	cacheMaxAge          time.Duration
+}
+
+func NewPublicListingController(publicListingService services.PublicListingService, cacheMaxAge time.Duration) *PublicListingController {
+	return &PublicListingController{
+		publicListingService: publicListingService,
+		cacheMaxAge:          cacheMaxAge,
+	}
+}
+
+func (pc *PublicListingController) GetShows(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.PublicShowListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	shows, err := pc.publicListingService.GetShows(ctx.Request.Context(), req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get public show listing")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCacheableOKResponse(ctx, "Shows retrieved successfully", requestID, shows, pc.cacheMaxAge)
+}
+
+func (pc *PublicListingController) GetMovies(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.PublicMovieListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	movies, err := pc.publicListingService.GetMovies(ctx.Request.Context(), req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get public movie listing")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCacheableOKResponse(ctx, "Movies retrieved successfully", requestID, movies, pc.cacheMaxAge)
+}
+
+func (pc *PublicListingController) GetMovie(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	movieID := ctx.Param("movie_id")
+
+	movie, err := pc.publicListingService.GetMovie(ctx.Request.Context(), movieID)
+	if err != nil {
+		log.Error().Err(err).Str("movieId", movieID).Msg("Failed to get public movie detail")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCacheableOKResponse(ctx, "Movie retrieved successfully", requestID, movie, pc.cacheMaxAge)
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+type ReportController struct {
+	reportService services.ReportService
+}
+
+func NewReportController(reportService services.ReportService) *ReportController {
+	return &ReportController{
+		reportService: reportService,
+	}
+}
+
+func (rc *ReportController) CreateSchedule(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.CreateReportScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+	username := claims["username"].(string)
+
+	schedule, err := rc.reportService.CreateSchedule(ctx, username, req)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Report schedule created successfully", requestID, schedule)
+}
+
+func (rc *ReportController) GetSchedules(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	schedules, err := rc.reportService.GetSchedules(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Report schedules fetched successfully", requestID, schedules)
+}
+
+func (rc *ReportController) DeleteSchedule(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	scheduleID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || scheduleID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_SCHEDULE_ID", "Schedule ID must be a valid positive integer", err), requestID)
+		return
+	}
+
+	if err := rc.reportService.DeleteSchedule(ctx, scheduleID); err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Report schedule deleted successfully", requestID, nil)
+}
+
+func (rc *ReportController) RunSchedule(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	scheduleID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || scheduleID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_SCHEDULE_ID", "Schedule ID must be a valid positive integer", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+	username := claims["username"].(string)
+
+	run, err := rc.reportService.RunSchedule(ctx, username, scheduleID)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Report run completed", requestID, run)
+}
+
+func (rc *ReportController) GetRuns(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.ReportRunsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	limit := constants.DEFAULT_REPORT_RUNS_LIMIT
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	runs, err := rc.reportService.GetRuns(ctx, req.ScheduleID, limit)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Report runs fetched successfully", requestID, runs)
+}
+
+func (rc *ReportController) DownloadRun(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	runID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || runID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_RUN_ID", "Run ID must be a valid positive integer", err), requestID)
+		return
+	}
+
+	download, err := rc.reportService.GetRunDownload(ctx, runID)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Report download link generated successfully", requestID, download)
+}
@@ -46,6 +46,23 @@ func (c *DashboardRevenueController) GetRevenue(ctx *gin.Context) {
     utils.SendOKResponse(ctx, "Revenue data fetched successfully", requestID, revenueData)
 }
 
+func (c *DashboardRevenueController) GetForecast(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.RevenueDashboardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	forecast, err := c.revenueService.GetForecast(ctx, req)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Revenue forecast fetched successfully", requestID, forecast)
+}
 
 func extractParamOrder(queryString string) []string {
 	if queryString == "" {
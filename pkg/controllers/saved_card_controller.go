@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type SavedCardController struct {
+	savedCardService services.SavedCardService
+}
+
+func NewSavedCardController(savedCardService services.SavedCardService) *SavedCardController {
+	return &SavedCardController{
+		savedCardService: savedCardService,
+	}
+}
+
+func (sc *SavedCardController) GetSavedCards(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	cards, err := sc.savedCardService.GetSavedCards(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get saved cards")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Saved cards retrieved successfully", requestID, cards)
+}
+
+func (sc *SavedCardController) SaveCard(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var saveCardRequest request.SaveCardRequest
+	if err := ctx.ShouldBindJSON(&saveCardRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	card, err := sc.savedCardService.SaveCard(ctx.Request.Context(), username, saveCardRequest)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to save card")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Card saved successfully", requestID, card)
+}
+
+func (sc *SavedCardController) DeleteSavedCard(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	cardID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || cardID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_CARD_ID", "Card ID must be a valid positive integer", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	if err := sc.savedCardService.DeleteSavedCard(ctx.Request.Context(), username, cardID); err != nil {
+		log.Error().Err(err).Str("username", username).Int64("cardID", cardID).Msg("Failed to delete saved card")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Saved card deleted successfully", requestID, nil)
+}
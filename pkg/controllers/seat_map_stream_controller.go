@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/metrics"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type SeatMapStreamController struct {
+	bookingService  services.BookingService
+	seatEventBroker services.SeatEventBroker
+}
+
+func NewSeatMapStreamController(bookingService services.BookingService, seatEventBroker services.SeatEventBroker) *SeatMapStreamController {
+	return &SeatMapStreamController{
+		bookingService:  bookingService,
+		seatEventBroker: seatEventBroker,
+	}
+}
+
+// StreamSeatMap sends the current seat map as a "snapshot" event, then a "seats" event for
+// every seat state change of the show. A "resync" event means changes may have been missed;
+// the stream ends after one if this client fell behind, and the client should reconnect.
+func (sc *SeatMapStreamController) StreamSeatMap(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	showIDStr := ctx.Param("show_id")
+	showID, err := strconv.Atoi(showIDStr)
+	if err != nil {
+		log.Error().Err(err).Str("showID", showIDStr).Msg("Invalid show ID format")
+		utils.HandleErrorResponse(ctx,
+			utils.NewBadRequestError("INVALID_SHOW_ID", "Show ID must be a valid integer", err),
+			requestID)
+		return
+	}
+
+	// Subscribe before reading the seat map so no change falls between the two.
+	events, unsubscribe := sc.seatEventBroker.Subscribe(showID)
+	defer unsubscribe()
+
+	seatMap, err := sc.bookingService.GetSeatMapForShow(ctx.Request.Context(), showID)
+	if err != nil {
+		log.Error().Err(err).Int("showID", showID).Msg("Failed to get seat map for stream")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	metrics.SeatMapStreamsOpen.Inc()
+	defer metrics.SeatMapStreamsOpen.Dec()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	ctx.SSEvent("snapshot", organizeSeatsByRow(seatMap))
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(constants.SEAT_EVENTS_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				ctx.SSEvent("resync", gin.H{"show_id": showID})
+				ctx.Writer.Flush()
+				return
+			}
+			if event.State == models.SeatStateResync {
+				ctx.SSEvent("resync", gin.H{"show_id": showID})
+			} else {
+				ctx.SSEvent("seats", event)
+			}
+			ctx.Writer.Flush()
+		case <-heartbeat.C:
+			if _, err := ctx.Writer.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
@@ -47,7 +49,7 @@ func (sh *ShowController) GetShows(ctx *gin.Context) {
 		now := time.Now()
 
 		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		maxAllowedDate := time.Date(today.Year(), today.Month(), today.Day()+6, 23, 59, 59, 0, today.Location())
+		maxAllowedDate := time.Date(today.Year(), today.Month(), today.Day()+constants.CUSTOMER_BOOKING_WINDOW_DAYS-1, 23, 59, 59, 0, today.Location())
 
 		requestDateLocal := requestDate.In(today.Location())
 		requestDateMidnight := time.Date(requestDateLocal.Year(), requestDateLocal.Month(), requestDateLocal.Day(), 0, 0, 0, 0, today.Location())
@@ -61,7 +63,7 @@ func (sh *ShowController) GetShows(ctx *gin.Context) {
 			utils.HandleErrorResponse(ctx,
 				utils.NewBadRequestError(
 					"DATE_OUT_OF_RANGE",
-					"Customers can only view shows from today to the next 6 days",
+					fmt.Sprintf("Customers can only view shows from today to the next %d days", constants.CUSTOMER_BOOKING_WINDOW_DAYS-1),
 					nil),
 				requestID)
 			return
@@ -92,6 +94,34 @@ func (sh *ShowController) GetShows(ctx *gin.Context) {
 	utils.SendOKResponse(ctx, "Shows retrieved successfully", requestID, showResponses)
 }
 
+func (sh *ShowController) SearchShows(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var req request.ShowSearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	role, _ := claims["role"].(string)
+	customerView := role != "admin" && role != "staff"
+
+	shows, err := sh.showService.SearchShows(ctx.Request.Context(), req, customerView)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search shows")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Shows retrieved successfully", requestID, shows)
+}
+
 func (sh *ShowController) GetShowById(ctx *gin.Context) {
 	requestID := utils.GetRequestID(ctx)
 	showIDStr := ctx.Query("id")
@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
@@ -22,17 +23,20 @@ type SkyCustomerController struct {
 	userService             services.UserService
 	skyCustomerService      services.SkyCustomerService
 	securityQuestionService services.SecurityQuestionService
+	passwordPolicyService   services.PasswordPolicyService
 }
 
 func NewSkyCustomerController(
 	userService services.UserService,
 	skyCustomerService services.SkyCustomerService,
 	securityQuestionService services.SecurityQuestionService,
+	passwordPolicyService services.PasswordPolicyService,
 ) *SkyCustomerController {
 	return &SkyCustomerController{
 		userService:             userService,
 		skyCustomerService:      skyCustomerService,
 		securityQuestionService: securityQuestionService,
+		passwordPolicyService:   passwordPolicyService,
 	}
 }
 
@@ -55,6 +59,11 @@ func (sk *SkyCustomerController) Signup(ctx *gin.Context) {
 		return
 	}
 
+	if err := sk.passwordPolicyService.ValidateNewPassword(ctx.Request.Context(), req.Username, "customer", "Password", req.Password); err != nil {
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		utils.HandleErrorResponse(ctx, utils.NewInternalServerError("PASSWORD_HASH_ERROR", "Failed to hash password", err), requestID)
@@ -68,7 +77,7 @@ func (sk *SkyCustomerController) Signup(ctx *gin.Context) {
 	}
 
 	user := models.NewUser(req.Username, hashedPassword, "customer")
-	passwordHistory := models.NewPasswordHistory(req.Username, hashedPassword, "", "")
+	passwordHistory := models.NewPasswordHistory(req.Username, hashedPassword)
 	skyCustomer := models.NewSkyCustomer(req.Name, req.Username, req.PhoneNumber, req.Email, imageBytes, 0, "")
 
 	if err := sk.skyCustomerService.CreateCustomer(
@@ -132,7 +141,16 @@ func (sk *SkyCustomerController) GetProfileImagePresignedURL(ctx *gin.Context) {
 		return
 	}
 
-	presignedURL, expiresAt, err := sk.skyCustomerService.GetProfileImagePresignedURL(ctx.Request.Context(), username)
+	var req request.ProfileImageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+	if req.Variant == "" {
+		req.Variant = constants.PROFILE_IMAGE_DEFAULT_VARIANT
+	}
+
+	presignedURL, expiresAt, err := sk.skyCustomerService.GetProfileImagePresignedURL(ctx.Request.Context(), username, req.Variant)
 	if err != nil {
 		utils.HandleErrorResponse(ctx, err, requestID)
 		return
@@ -140,6 +158,7 @@ func (sk *SkyCustomerController) GetProfileImagePresignedURL(ctx *gin.Context) {
 
 	response := response.ProfileImageResponse{
 		PresignedURL: presignedURL,
+		Variant:      req.Variant,
 		ExpiresAt:    expiresAt.Format(time.RFC3339),
 	}
 
@@ -1,6 +1,10 @@
 package controllers
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
@@ -11,12 +15,16 @@ import (
 )
 
 type WalletController struct {
-	walletService services.WalletService
+	walletService          services.WalletService
+	walletTransferService  services.WalletTransferService
+	walletStatementService services.WalletStatementService
 }
 
-func NewWalletController(walletService services.WalletService) *WalletController {
+func NewWalletController(walletService services.WalletService, walletTransferService services.WalletTransferService, walletStatementService services.WalletStatementService) *WalletController {
 	return &WalletController{
-		walletService: walletService,
+		walletService:          walletService,
+		walletTransferService:  walletTransferService,
+		walletStatementService: walletStatementService,
 	}
 }
 
@@ -83,7 +91,13 @@ func (wc *WalletController) GetTransactions(ctx *gin.Context) {
 	
 	username, _ := claims["username"].(string)
 	
-	transactions, err := wc.walletService.GetTransactions(ctx.Request.Context(), username)
+	var req request.WalletTransactionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_PARAMS", "Invalid query parameters", err), requestID)
+		return
+	}
+	
+	transactions, err := wc.walletService.GetTransactions(ctx.Request.Context(), username, req)
 	if err != nil {
 		log.Error().Err(err).Str("username", username).Msg("Failed to get wallet transactions")
 		utils.HandleErrorResponse(ctx, err, requestID)
@@ -92,3 +106,188 @@ func (wc *WalletController) GetTransactions(ctx *gin.Context) {
 	
 	utils.SendOKResponse(ctx, "Wallet transactions retrieved successfully", requestID, transactions)
 }
+
+func (wc *WalletController) PostAdjustment(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var adjustmentRequest request.WalletAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&adjustmentRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	adminUsername, _ := claims["username"].(string)
+
+	adjustment, err := wc.walletService.PostAdjustment(ctx.Request.Context(), adminUsername, adjustmentRequest)
+	if err != nil {
+		log.Error().Err(err).Str("admin", adminUsername).Str("username", adjustmentRequest.Username).Msg("Failed to post wallet adjustment")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Wallet adjustment posted successfully", requestID, adjustment)
+}
+
+func (wc *WalletController) InitiateTransfer(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var transferRequest request.WalletTransferRequest
+	if err := ctx.ShouldBindJSON(&transferRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	transfer, err := wc.walletTransferService.InitiateTransfer(ctx.Request.Context(), username, transferRequest)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("recipient", transferRequest.RecipientUsername).Msg("Failed to initiate wallet transfer")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Transfer created, confirm it to complete the payment", requestID, transfer)
+}
+
+func (wc *WalletController) ConfirmTransfer(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	transferID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || transferID <= 0 {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_TRANSFER_ID", "Transfer ID must be a valid positive integer", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	transfer, err := wc.walletTransferService.ConfirmTransfer(ctx.Request.Context(), username, transferID)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Int64("transferID", transferID).Msg("Failed to confirm wallet transfer")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Transfer completed successfully", requestID, transfer)
+}
+
+func (wc *WalletController) IssueCredit(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var creditRequest request.WalletCreditRequest
+	if err := ctx.ShouldBindJSON(&creditRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	adminUsername, _ := claims["username"].(string)
+
+	credit, err := wc.walletService.IssueCredit(ctx.Request.Context(), adminUsername, creditRequest)
+	if err != nil {
+		log.Error().Err(err).Str("admin", adminUsername).Str("username", creditRequest.Username).Msg("Failed to issue wallet credit")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Wallet credit issued successfully", requestID, credit)
+}
+
+func (wc *WalletController) DownloadStatement(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	format := ctx.DefaultQuery("format", "pdf")
+	if format != "pdf" && format != "csv" {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_FORMAT", "Format must be either 'pdf' or 'csv'", nil), requestID)
+		return
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if fromStr := ctx.Query("from"); fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, now.Location())
+		if err != nil {
+			utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_DATE", "'from' must be a date in YYYY-MM-DD format", err), requestID)
+			return
+		}
+		from = parsed
+	}
+
+	if toStr := ctx.Query("to"); toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, now.Location())
+		if err != nil {
+			utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_DATE", "'to' must be a date in YYYY-MM-DD format", err), requestID)
+			return
+		}
+		to = parsed
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	statement, err := wc.walletStatementService.GetStatement(ctx.Request.Context(), username, from, to)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to build wallet statement")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	var data []byte
+	contentType := "application/pdf"
+	if format == "csv" {
+		contentType = "text/csv"
+		data, err = wc.walletStatementService.GenerateStatementCSV(statement)
+	} else {
+		data, err = wc.walletStatementService.GenerateStatementPDF(statement)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("format", format).Msg("Failed to generate wallet statement")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	filename := fmt.Sprintf("wallet_statement_%s_%s.%s", statement.From, statement.To, format)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	ctx.Data(200, contentType, data)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/middleware/security"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type WatchlistController struct {
+	watchlistService services.WatchlistService
+}
+
+func NewWatchlistController(watchlistService services.WatchlistService) *WatchlistController {
+	return &WatchlistController{
+		watchlistService: watchlistService,
+	}
+}
+
+func (wc *WatchlistController) GetWatchlist(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	watchlist, err := wc.watchlistService.GetWatchlist(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get watchlist")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Watchlist retrieved successfully", requestID, watchlist)
+}
+
+func (wc *WatchlistController) AddMovie(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	var addRequest request.AddWatchlistMovieRequest
+	if err := ctx.ShouldBindJSON(&addRequest); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			utils.HandleErrorResponse(ctx, utils.NewValidationError(validationErrs), requestID)
+			return
+		}
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_REQUEST", "Invalid request data", err), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	movie, err := wc.watchlistService.AddMovie(ctx.Request.Context(), username, addRequest.MovieId)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("movieId", addRequest.MovieId).Msg("Failed to add movie to watchlist")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendCreatedResponse(ctx, "Movie added to watchlist successfully", requestID, movie)
+}
+
+func (wc *WatchlistController) RemoveMovie(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	movieID := ctx.Param("movie_id")
+	if movieID == "" {
+		utils.HandleErrorResponse(ctx, utils.NewBadRequestError("INVALID_MOVIE_ID", "Movie ID is required", nil), requestID)
+		return
+	}
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	if err := wc.watchlistService.RemoveMovie(ctx.Request.Context(), username, movieID); err != nil {
+		log.Error().Err(err).Str("username", username).Str("movieId", movieID).Msg("Failed to remove movie from watchlist")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Movie removed from watchlist successfully", requestID, nil)
+}
+
+func (wc *WatchlistController) GetFeed(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	feed, err := wc.watchlistService.GetFeed(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get watchlist feed")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Watchlist feed retrieved successfully", requestID, feed)
+}
+
+func (wc *WatchlistController) GetAlerts(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	alerts, err := wc.watchlistService.GetAlerts(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get watchlist alerts")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Watchlist alerts retrieved successfully", requestID, alerts)
+}
+
+func (wc *WatchlistController) MarkAlertsRead(ctx *gin.Context) {
+	requestID := utils.GetRequestID(ctx)
+
+	claims, err := security.GetTokenClaims(ctx)
+	if err != nil {
+		utils.HandleErrorResponse(ctx, utils.NewUnauthorizedError("UNAUTHORIZED", "Unable to verify user credentials", err), requestID)
+		return
+	}
+
+	username, _ := claims["username"].(string)
+
+	result, err := wc.watchlistService.MarkAlertsRead(ctx.Request.Context(), username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to mark watchlist alerts read")
+		utils.HandleErrorResponse(ctx, err, requestID)
+		return
+	}
+
+	utils.SendOKResponse(ctx, "Watchlist alerts marked as read", requestID, result)
+}
@@ -5,28 +5,38 @@ import (
 
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/services"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 	"github.com/rs/zerolog/log"
 )
 
-func SeedDB(userRepo repositories.UserRepository, staffRepo repositories.StaffRepository) {
+// SeedDB creates the seed admin and staff accounts if they do not exist yet. Their passwords
+// go through the password policy like any other, so a stricter policy skips them with an error.
+func SeedDB(userRepo repositories.UserRepository, staffRepo repositories.StaffRepository, passwordPolicyService services.PasswordPolicyService) {
 	ctx := context.Background()
 	log.Info().Msg("Starting database seeding...")
 
-	seedUser(ctx, userRepo, staffRepo, "seed-user-1", "foobar", "admin", "Admin One", 101)
-	seedUser(ctx, userRepo, staffRepo, "seed-user-2", "foobar", "admin", "Admin Two", 102)
+	seedUser(ctx, userRepo, staffRepo, passwordPolicyService, "seed-user-1", "SeedAdmin#2025", "admin", "Admin One", 101)
+	seedUser(ctx, userRepo, staffRepo, passwordPolicyService, "seed-user-2", "SeedAdmin#2025", "admin", "Admin Two", 102)
 
-	seedUser(ctx, userRepo, staffRepo, "staff-1", "foobar", "staff", "Staff One", 501)
+	seedUser(ctx, userRepo, staffRepo, passwordPolicyService, "staff-1", "SeedStaff#2025", "staff", "Staff One", 501)
 
 	log.Info().Msg("Database seeding completed successfully")
 }
 
 func seedUser(ctx context.Context, userRepo repositories.UserRepository, staffRepo repositories.StaffRepository,
-	username, password, role, staffName string, counterNumber int) {
+	passwordPolicyService services.PasswordPolicyService, username, password, role, staffName string, counterNumber int) {
 
 	user, _ := userRepo.FindByUsername(ctx, username)
 	staff, _ := staffRepo.FindByUsername(ctx, username)
-	passwordHistory, _ := userRepo.FindByUsernameinPasswordHistory(ctx, username)
+	passwordHistory, _ := userRepo.GetPasswordHistory(ctx, username, 1)
+
+	if user == nil {
+		if err := passwordPolicyService.ValidateNewPassword(ctx, username, role, "password", password); err != nil {
+			log.Error().Err(err).Str("username", username).Str("role", role).Msg("Seed password does not meet the password policy, skipping user")
+			return
+		}
+	}
 
 	hashedPassword, err := utils.HashPassword(password)
 	if err != nil {
@@ -56,9 +66,9 @@ func seedUser(ctx context.Context, userRepo repositories.UserRepository, staffRe
 		}
 	}
 
-	if passwordHistory == nil {
+	if len(passwordHistory) == 0 {
 		log.Info().Str("username", username).Msg("Creating password history")
-		newPasswordHistory := models.NewPasswordHistory(username, hashedPassword, "", "")
+		newPasswordHistory := models.NewPasswordHistory(username, hashedPassword)
 		err = userRepo.SavePasswordHistory(ctx, &newPasswordHistory)
 		if err != nil {
 			log.Error().Err(err).Str("username", username).Msg("Failed to create password history during seeding")
@@ -5,3 +5,9 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// MFALoginRequest completes a login that returned an MFA challenge. Code is either the current
+// authenticator code or an unused recovery code.
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
@@ -17,10 +17,60 @@ type InitializeBookingRequest struct {
 
 type ProcessPaymentRequest struct {
 	BookingID      int    `json:"booking_id" binding:"required,numeric"`
-	PaymentMethod  string `json:"payment_method" binding:"required,oneof=Card Wallet"`
-	CardNumber     string `json:"card_number" binding:"required,len=16,numeric"`
-	CVV            string `json:"cvv" binding:"required,len=3,numeric"`
-	ExpiryMonth    string `json:"expiry_month" binding:"required,min=1,max=2,numeric"`
-	ExpiryYear     string `json:"expiry_year" binding:"required,len=2,numeric"`
-	CardholderName string `json:"cardholder_name" binding:"required,customName"`
+	PaymentMethod  string `json:"payment_method" binding:"required,oneof=Card Wallet Points"`
+	SavedCardID    *int64 `json:"saved_card_id" binding:"omitempty,min=1"`
+	CardNumber     string `json:"card_number" binding:"omitempty,len=16,numeric"`
+	CVV            string `json:"cvv" binding:"omitempty,len=3,numeric"`
+	ExpiryMonth    string `json:"expiry_month" binding:"omitempty,min=1,max=2,numeric"`
+	ExpiryYear     string `json:"expiry_year" binding:"omitempty,len=2,numeric"`
+	CardholderName string `json:"cardholder_name" binding:"omitempty,customName"`
+}
+
+// BookingExportRequest filters the booking CSV/XLSX export. Status and Columns take
+// comma-separated lists.
+type BookingExportRequest struct {
+	Month       *int   `form:"month" binding:"omitempty,min=1,max=12"`
+	Year        *int   `form:"year"`
+	From        string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To          string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	MovieID     string `form:"movie_id" binding:"omitempty,max=30"`
+	SlotID      *int   `form:"slot_id" binding:"omitempty,min=1"`
+	PaymentType string `form:"payment_type" binding:"omitempty,oneof=Cash Card Wallet Points"`
+	Status      string `form:"status"`
+	Columns     string `form:"columns"`
+	Format      string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	Limit       *int   `form:"limit" binding:"omitempty,min=1"`
+	Offset      *int   `form:"offset" binding:"omitempty,min=0"`
+}
+
+// CustomerBookingsRequest pages through a customer's booking history. Status takes a
+// comma-separated list; from and to bound the show date.
+type CustomerBookingsRequest struct {
+	Status string `form:"status"`
+	From   string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To     string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	When   string `form:"when" binding:"omitempty,oneof=upcoming past"`
+	Cursor string `form:"cursor"`
+	Limit  *int   `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// BookingSearchRequest filters the admin and staff booking search. Status takes a
+// comma-separated list; from and to bound the show date.
+type BookingSearchRequest struct {
+	BookingID   *int   `form:"booking_id" binding:"omitempty,min=1"`
+	Phone       string `form:"phone" binding:"omitempty,max=15"`
+	Username    string `form:"username" binding:"omitempty,max=30"`
+	Name        string `form:"name" binding:"omitempty,max=50"`
+	ShowID      *int   `form:"show_id" binding:"omitempty,min=1"`
+	Status      string `form:"status"`
+	PaymentType string `form:"payment_type" binding:"omitempty,oneof=Cash Card Wallet Points"`
+	From        string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To          string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Cursor      string `form:"cursor"`
+	Limit       *int   `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type BookingRefundRequest struct {
+	BookingID int    `json:"booking_id" binding:"required,min=1"`
+	Reason    string `json:"reason" binding:"required,min=5,max=255"`
 }
@@ -7,3 +7,18 @@ type BulkCheckInRequest struct {
 type SingleCheckInRequest struct {
 	BookingID int `json:"booking_id" binding:"required"`
 }
+
+// CheckInScope picks the shows a check-in listing or summary covers. Window is in minutes and
+// keeps shows that have not ended and start within that many minutes from now. Without
+// show_id, date or window, today's shows are covered.
+type CheckInScope struct {
+	ShowID *int   `form:"show_id" binding:"omitempty,min=1"`
+	Date   string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+	Window *int   `form:"window" binding:"omitempty,min=1,max=1440"`
+}
+
+type CheckInBookingsRequest struct {
+	CheckInScope
+	Cursor string `form:"cursor"`
+	Limit  *int   `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type RequestVerificationCodeRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email phone"`
+}
+
+type ConfirmVerificationCodeRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email phone"`
+	Code    string `json:"code" binding:"required,len=6,numeric"`
+}
@@ -0,0 +1,11 @@
+package request
+
+// CustomerDataExportRequest selects the export format: a single JSON document (the default)
+// or a ZIP archive holding the JSON document and the profile image.
+type CustomerDataExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json zip"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
@@ -11,4 +11,9 @@ type UpdateProfileImageRequest struct {
     SecurityAnswer string `json:"security_answer" binding:"required,securityAnswer"`
     ProfileImg     string `json:"profile_img" binding:"required"`
     ProfileImgSHA  string `json:"profile_img_sha" binding:"required"`
-}
\ No newline at end of file
+}
+
+// ProfileImageRequest picks which stored size of the profile image to link to.
+type ProfileImageRequest struct {
+    Variant string `form:"variant" binding:"omitempty,oneof=thumbnail medium"`
+}
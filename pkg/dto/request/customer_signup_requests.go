@@ -3,7 +3,7 @@ package request
 type SignupRequest struct {
 	Name               string `json:"name" binding:"required,customName"`
 	Username           string `json:"username" binding:"required,customUsername"`
-	Password           string `json:"password" binding:"required,customPassword"`
+	Password           string `json:"password" binding:"required"`
 	PhoneNumber        string `json:"number" binding:"required,customPhone"`
 	Email              string `json:"email" binding:"required,email"`
 	ProfileImg         string `json:"profile_img"`
@@ -0,0 +1,6 @@
+package request
+
+type LoyaltyReversalRequest struct {
+	BookingID int    `json:"booking_id" binding:"required,min=1"`
+	Reason    string `json:"reason" binding:"required,min=5,max=255"`
+}
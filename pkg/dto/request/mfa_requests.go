@@ -0,0 +1,15 @@
+package request
+
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableMFARequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type UpdateMFAPolicyRequest struct {
+	Role     string `json:"role" binding:"required,oneof=admin staff"`
+	Required *bool  `json:"required" binding:"required"`
+}
@@ -0,0 +1,29 @@
+package request
+
+type SubmitMovieReviewRequest struct {
+	MovieId string `json:"movie_id" binding:"required,max=30"`
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Review  string `json:"review" binding:"required,max=2000"`
+}
+
+type UpdateMovieReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Review string `json:"review" binding:"required,max=2000"`
+}
+
+type MovieReviewListRequest struct {
+	Limit  *int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset *int `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AdminMovieReviewListRequest struct {
+	MovieID  string `form:"movie_id" binding:"omitempty,max=30"`
+	Username string `form:"username" binding:"omitempty,max=30"`
+	Status   string `form:"status" binding:"omitempty,oneof=visible hidden"`
+	Limit    *int   `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset   *int   `form:"offset" binding:"omitempty,min=0"`
+}
+
+type HideMovieReviewRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
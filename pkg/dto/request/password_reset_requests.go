@@ -3,10 +3,10 @@ package request
 type ForgotPasswordRequest struct {
 	Email       string `json:"email" binding:"required,email"`
 	ResetToken  string `json:"reset_token" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,customPassword"`
+	NewPassword string `json:"new_password" binding:"required"`
 }
 
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password" binding:"required"`
-	NewPassword     string `json:"new_password" binding:"required,customPassword"`
+	NewPassword     string `json:"new_password" binding:"required"`
 }
@@ -10,3 +10,17 @@ type PaymentRequest struct {
 	Amount     decimal.Decimal `json:"amount"`
 	Timestamp  string          `json:"timestamp"`
 }
+
+type TokenPaymentRequest struct {
+	Token     string          `json:"token"`
+	Amount    decimal.Decimal `json:"amount"`
+	Timestamp string          `json:"timestamp"`
+}
+
+type TokenizeCardRequest struct {
+	CardNumber string `json:"card_number"`
+	CVV        string `json:"cvv"`
+	Expiry     string `json:"expiry"`
+	Name       string `json:"name"`
+	Timestamp  string `json:"timestamp"`
+}
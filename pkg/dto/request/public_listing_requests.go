@@ -0,0 +1,12 @@
+package request
+
+type PublicShowListRequest struct {
+	From    string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To      string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	MovieID string `form:"movie_id" binding:"omitempty,max=30"`
+	Genre   string `form:"genre" binding:"omitempty,max=50"`
+}
+
+type PublicMovieListRequest struct {
+	Genre string `form:"genre" binding:"omitempty,max=50"`
+}
@@ -0,0 +1,15 @@
+package request
+
+type CreateReportScheduleRequest struct {
+	Name           string   `json:"name" binding:"required,min=3,max=100"`
+	ReportType     string   `json:"report_type" binding:"required,oneof=REVENUE BOOKINGS"`
+	Format         string   `json:"format" binding:"required,oneof=CSV PDF"`
+	Period         string   `json:"period" binding:"required,oneof=DAY WEEK MONTH"`
+	CronExpression string   `json:"cron_expression" binding:"required,max=100"`
+	Recipients     []string `json:"recipients" binding:"max=10,dive,email"`
+}
+
+type ReportRunsRequest struct {
+	ScheduleID *int64 `form:"schedule_id" binding:"omitempty,min=1"`
+	Limit      *int   `form:"limit" binding:"omitempty,min=1,max=200"`
+}
@@ -0,0 +1,9 @@
+package request
+
+type SaveCardRequest struct {
+	CardNumber     string `json:"card_number" binding:"required,len=16,numeric"`
+	CVV            string `json:"cvv" binding:"required,len=3,numeric"`
+	ExpiryMonth    string `json:"expiry_month" binding:"required,min=1,max=2,numeric"`
+	ExpiryYear     string `json:"expiry_year" binding:"required,len=2,numeric"`
+	CardholderName string `json:"cardholder_name" binding:"required,customName"`
+}
@@ -12,3 +12,19 @@ type ShowRequest struct {
 	SlotId  int             `json:"slotId"`
 	Cost    decimal.Decimal `json:"cost"`
 }
+
+// ShowSearchRequest filters the show search. TimeOfDay buckets slots by their start time, and
+// the price range matches either the standard or the Deluxe seat price.
+type ShowSearchRequest struct {
+	From      string   `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To        string   `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Genre     string   `form:"genre" binding:"omitempty,max=50"`
+	SlotID    *int     `form:"slot_id" binding:"omitempty,min=1"`
+	TimeOfDay string   `form:"time_of_day" binding:"omitempty,oneof=morning afternoon evening night"`
+	MinPrice  *float64 `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice  *float64 `form:"max_price" binding:"omitempty,min=0"`
+	MinSeats  *int     `form:"min_seats" binding:"omitempty,min=1,max=100"`
+	Sort      string   `form:"sort" binding:"omitempty,oneof=time price_asc price_desc seats rating"`
+	Limit     *int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset    *int     `form:"offset" binding:"omitempty,min=0"`
+}
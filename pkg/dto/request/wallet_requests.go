@@ -1,12 +1,47 @@
 package request
 
-import "github.com/govalues/decimal"
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
 
 type AddWalletFundsRequest struct {
 	Amount         decimal.Decimal `json:"amount" binding:"required"`
-	CardNumber     string          `json:"card_number" binding:"required"`
-	CVV            string          `json:"cvv" binding:"required"`
-	ExpiryMonth    string          `json:"expiry_month" binding:"required"`
-	ExpiryYear     string          `json:"expiry_year" binding:"required"`
-	CardholderName string          `json:"cardholder_name" binding:"required"`
+	SavedCardID    *int64          `json:"saved_card_id" binding:"omitempty,min=1"`
+	CardNumber     string          `json:"card_number" binding:"required_without=SavedCardID"`
+	CVV            string          `json:"cvv" binding:"required_without=SavedCardID"`
+	ExpiryMonth    string          `json:"expiry_month" binding:"required_without=SavedCardID"`
+	ExpiryYear     string          `json:"expiry_year" binding:"required_without=SavedCardID"`
+	CardholderName string          `json:"cardholder_name" binding:"required_without=SavedCardID"`
+}
+
+type WalletAdjustmentRequest struct {
+	Username  string          `json:"username" binding:"required"`
+	Direction string          `json:"direction" binding:"required,oneof=CREDIT DEBIT"`
+	Amount    decimal.Decimal `json:"amount" binding:"required"`
+	Reason    string          `json:"reason" binding:"required,min=5,max=255"`
+}
+
+type WalletTransferRequest struct {
+	RecipientUsername string          `json:"recipient_username" binding:"required"`
+	Amount            decimal.Decimal `json:"amount" binding:"required"`
+	Note              string          `json:"note" binding:"omitempty,max=140"`
+}
+
+type WalletCreditRequest struct {
+	Username  string          `json:"username" binding:"required"`
+	Amount    decimal.Decimal `json:"amount" binding:"required"`
+	Reason    string          `json:"reason" binding:"required,min=5,max=255"`
+	ExpiresAt *time.Time      `json:"expires_at"`
+}
+
+// WalletTransactionsRequest pages through a customer's wallet transactions. Type takes a
+// comma-separated list of transaction types; from and to bound the transaction date.
+type WalletTransactionsRequest struct {
+	Type   string `form:"type"`
+	From   string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To     string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Cursor string `form:"cursor"`
+	Limit  *int   `form:"limit" binding:"omitempty,min=1,max=100"`
 }
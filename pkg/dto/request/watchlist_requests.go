@@ -0,0 +1,5 @@
+package request
+
+type AddWatchlistMovieRequest struct {
+	MovieId string `json:"movie_id" binding:"required,max=30"`
+}
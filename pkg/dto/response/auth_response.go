@@ -1,8 +1,17 @@
 package response
 
+// LoginResponse carries a session token, or for users with MFA an mfa_token instead: with
+// mfa_required it is exchanged at /login/mfa, with mfa_enrollment_required it only grants
+// access to MFA enrollment. When the password has expired, password_change_token only grants
+// access to /change-password.
 type LoginResponse struct {
-	User  UserInfo `json:"user"`
-	Token string   `json:"token"`
+	User                   UserInfo `json:"user"`
+	Token                  string   `json:"token"`
+	MFARequired            bool     `json:"mfa_required,omitempty"`
+	MFAEnrollmentRequired  bool     `json:"mfa_enrollment_required,omitempty"`
+	MFAToken               string   `json:"mfa_token,omitempty"`
+	PasswordChangeRequired bool     `json:"password_change_required,omitempty"`
+	PasswordChangeToken    string   `json:"password_change_token,omitempty"`
 }
 
 type UserInfo struct {
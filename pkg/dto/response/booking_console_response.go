@@ -0,0 +1,61 @@
+package response
+
+import "time"
+
+type BookingSearchResult struct {
+	BookingID        int       `json:"booking_id"`
+	ShowID           int       `json:"show_id"`
+	MovieID          string    `json:"movie_id"`
+	MovieName        string    `json:"movie_name"`
+	ShowDate         string    `json:"show_date"`
+	ShowTime         string    `json:"show_time"`
+	SlotName         string    `json:"slot_name"`
+	CustomerUsername *string   `json:"customer_username"`
+	CustomerName     string    `json:"customer_name"`
+	PhoneNumber      string    `json:"phone_number"`
+	SeatNumbers      []string  `json:"seat_numbers"`
+	AmountPaid       float64   `json:"amount_paid"`
+	PaymentType      string    `json:"payment_type"`
+	BookingTime      time.Time `json:"booking_time"`
+	Status           string    `json:"status"`
+}
+
+// BookingSearchResponse is one page of search results. NextCursor is null on the last page.
+type BookingSearchResponse struct {
+	Bookings   []BookingSearchResult `json:"bookings"`
+	NextCursor *string               `json:"next_cursor"`
+}
+
+type BookingPaymentInfo struct {
+	TransactionID string    `json:"transaction_id"`
+	PaymentMethod string    `json:"payment_method"`
+	Amount        float64   `json:"amount"`
+	Status        string    `json:"status"`
+	ProcessedAt   time.Time `json:"processed_at"`
+}
+
+// BookingCheckInState describes where a booking stands at the door. State is one of
+// CHECKED_IN, OPEN, NOT_YET_OPEN, CLOSED, NOT_PAID or REFUNDED.
+type BookingCheckInState struct {
+	State       string     `json:"state"`
+	OpensAt     *time.Time `json:"opens_at,omitempty"`
+	ClosesAt    *time.Time `json:"closes_at,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	CheckedInBy *string    `json:"checked_in_by,omitempty"`
+}
+
+type BookingAuditEventResponse struct {
+	EventType string    `json:"event_type"`
+	Actor     *string   `json:"actor,omitempty"`
+	Details   *string   `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BookingDetailResponse struct {
+	Booking         BookingSearchResult         `json:"booking"`
+	MoviePoster     string                      `json:"movie_poster"`
+	Payments        []BookingPaymentInfo        `json:"payments"`
+	WalletMovements []WalletTransactionResponse `json:"wallet_movements"`
+	CheckIn         BookingCheckInState         `json:"check_in"`
+	AuditTrail      []BookingAuditEventResponse `json:"audit_trail"`
+}
@@ -26,4 +26,17 @@ type BookingResponse struct {
 	BookingTime   time.Time `json:"booking_time"`
 	Status        string    `json:"status"`
 	TransactionID string    `json:"transaction_id,omitempty"`
+	PointsEarned  int       `json:"points_earned,omitempty"`
+	PointsUsed    int       `json:"points_used,omitempty"`
+}
+
+// BookingRefundResponse describes a refund. WalletRefunded is the amount credited back to
+// the customer's wallet; card and cash payments are returned outside Skyfox.
+type BookingRefundResponse struct {
+	BookingID        int                          `json:"booking_id"`
+	Status           string                       `json:"status"`
+	PaymentType      string                       `json:"payment_type"`
+	AmountPaid       float64                      `json:"amount_paid"`
+	WalletRefunded   float64                      `json:"wallet_refunded"`
+	LoyaltyReversals []LoyaltyTransactionResponse `json:"loyalty_reversals"`
 }
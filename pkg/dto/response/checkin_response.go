@@ -1,7 +1,32 @@
 package response
 
+import "time"
+
 type BulkCheckInResponse struct {
 	CheckedIn   []int `json:"checked_in"`
 	AlreadyDone []int `json:"already_done"`
 	Invalid     []int `json:"invalid"`
 }
+
+// ShowCheckInSummary counts paid bookings and seats for a show. CheckInState is OPEN,
+// NOT_YET_OPEN or CLOSED.
+type ShowCheckInSummary struct {
+	ShowID            int    `json:"show_id"`
+	MovieID           string `json:"movie_id"`
+	MovieName         string `json:"movie_name"`
+	ShowDate          string `json:"show_date"`
+	ShowTime          string `json:"show_time"`
+	SlotName          string `json:"slot_name"`
+	CheckInState      string `json:"check_in_state"`
+	ExpectedBookings  int    `json:"expected_bookings"`
+	ExpectedSeats     int    `json:"expected_seats"`
+	AdmittedBookings  int    `json:"admitted_bookings"`
+	AdmittedSeats     int    `json:"admitted_seats"`
+	RemainingBookings int    `json:"remaining_bookings"`
+	RemainingSeats    int    `json:"remaining_seats"`
+}
+
+type CheckInSummaryResponse struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Shows       []ShowCheckInSummary `json:"shows"`
+}
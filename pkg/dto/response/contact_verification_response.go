@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// VerificationCodeResponse describes a code that was just sent. Destination is masked so the
+// response does not reveal more of the contact than the customer already knows.
+type VerificationCodeResponse struct {
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ContactVerificationResponse struct {
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	VerifiedAt  time.Time `json:"verified_at"`
+}
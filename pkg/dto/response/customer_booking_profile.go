@@ -7,11 +7,21 @@ import (
 type CustomerBookingInfo struct {
 	BookingID   int       `json:"booking_id"`
 	ShowID      int       `json:"show_id"`
+	MovieID     string    `json:"movie_id"`
+	MovieName   string    `json:"movie_name"`
+	MoviePoster string    `json:"movie_poster"`
 	ShowDate    string    `json:"show_date"`
 	ShowTime    string    `json:"show_time"`
+	SlotName    string    `json:"slot_name"`
 	SeatNumbers []string  `json:"seat_numbers"`
 	AmountPaid  float64   `json:"amount_paid"`
 	PaymentType string    `json:"payment_type"`
 	BookingTime time.Time `json:"booking_time"`
 	Status      string    `json:"status"`
 }
+
+// CustomerBookingsResponse is one page of booking history. NextCursor is null on the last page.
+type CustomerBookingsResponse struct {
+	Bookings   []CustomerBookingInfo `json:"bookings"`
+	NextCursor *string               `json:"next_cursor"`
+}
@@ -0,0 +1,39 @@
+package response
+
+// CustomerDataExport is everything held about a customer. In a ZIP export the profile image
+// is stored as a separate file named by ProfileImage.FileName; in a JSON export it is
+// embedded as base64 in ProfileImage.Data.
+type CustomerDataExport struct {
+	GeneratedAt  string                    `json:"generated_at"`
+	Profile      CustomerDataExportProfile `json:"profile"`
+	Bookings     []CustomerBookingInfo     `json:"bookings"`
+	Wallet       CustomerDataExportWallet  `json:"wallet"`
+	ProfileImage *CustomerDataExportImage  `json:"profile_image"`
+	Watchlist    []WatchlistMovieResponse  `json:"watchlist"`
+	Reviews      []MovieReviewResponse     `json:"reviews"`
+}
+
+type CustomerDataExportProfile struct {
+	Username           string `json:"username"`
+	Name               string `json:"name"`
+	Email              string `json:"email"`
+	PhoneNumber        string `json:"phone_number"`
+	SecurityQuestionID int    `json:"security_question_id"`
+	CreatedAt          string `json:"created_at"`
+}
+
+type CustomerDataExportWallet struct {
+	Balance      float64                     `json:"balance"`
+	Transactions []WalletTransactionResponse `json:"transactions"`
+}
+
+type CustomerDataExportImage struct {
+	ContentType string `json:"content_type"`
+	FileName    string `json:"file_name,omitempty"`
+	Data        string `json:"data,omitempty"`
+}
+
+type DeleteAccountResponse struct {
+	Username           string `json:"username"`
+	AnonymizedBookings int    `json:"anonymized_bookings"`
+}
@@ -2,5 +2,6 @@ package response
 
 type ProfileImageResponse struct {
 	PresignedURL string `json:"presigned_url"`
+	Variant      string `json:"variant"`
 	ExpiresAt    string `json:"expires_at"`
 }
@@ -1,17 +1,27 @@
 package response
 
 type CustomerProfileResponse struct {
-	Username               string `json:"username"`
-	Name                   string `json:"name"`
-	Email                  string `json:"email"`
-	PhoneNumber            string `json:"phone_number"`
-	SecurityQuestionExists bool   `json:"security_question_exists"`
-	CreatedAt              string `json:"created_at"`
+	Username               string                   `json:"username"`
+	Name                   string                   `json:"name"`
+	Email                  string                   `json:"email"`
+	PhoneNumber            string                   `json:"phone_number"`
+	EmailVerified          bool                     `json:"email_verified"`
+	PhoneNumberVerified    bool                     `json:"phone_number_verified"`
+	PendingEmail           *string                  `json:"pending_email,omitempty"`
+	PendingPhoneNumber     *string                  `json:"pending_phone_number,omitempty"`
+	SecurityQuestionExists bool                     `json:"security_question_exists"`
+	CreatedAt              string                   `json:"created_at"`
+	Watchlist              []WatchlistMovieResponse `json:"watchlist"`
 }
 
+// UpdateCustomerProfileResponse returns the profile after the update. A changed email or phone
+// number is not applied until it is verified, so it appears in the pending fields while Email
+// and PhoneNumber keep the current values.
 type UpdateCustomerProfileResponse struct {
-	Username    string `json:"username"`
-	Name        string `json:"name"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
+	Username           string  `json:"username"`
+	Name               string  `json:"name"`
+	Email              string  `json:"email"`
+	PhoneNumber        string  `json:"phone_number"`
+	PendingEmail       *string `json:"pending_email,omitempty"`
+	PendingPhoneNumber *string `json:"pending_phone_number,omitempty"`
 }
@@ -0,0 +1,25 @@
+package response
+
+type LoyaltyTransactionResponse struct {
+	ID              int64  `json:"id,omitempty"`
+	TransactionType string `json:"transaction_type"`
+	Points          int    `json:"points"`
+	BookingID       *int64 `json:"booking_id,omitempty"`
+	Description     string `json:"description"`
+	Timestamp       string `json:"timestamp,omitempty"`
+}
+
+type LoyaltyResponse struct {
+	PointsBalance    int                          `json:"points_balance"`
+	LifetimePoints   int                          `json:"lifetime_points"`
+	Tier             string                       `json:"tier"`
+	NextTier         string                       `json:"next_tier,omitempty"`
+	PointsToNextTier int                          `json:"points_to_next_tier,omitempty"`
+	RedeemableValue  float64                      `json:"redeemable_value"`
+	History          []LoyaltyTransactionResponse `json:"history"`
+}
+
+type LoyaltyReversalResponse struct {
+	BookingID int                          `json:"booking_id"`
+	Reversals []LoyaltyTransactionResponse `json:"reversals"`
+}
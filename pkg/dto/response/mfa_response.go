@@ -0,0 +1,36 @@
+package response
+
+import "time"
+
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RequiredForRole        bool       `json:"required_for_role"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// MFAEnrollmentResponse holds what an authenticator app needs. QRCode is a base64 PNG of
+// ProvisioningURI; Secret is for entering the key by hand.
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	QRCode          string `json:"qr_code"`
+}
+
+// MFAEnrollmentConfirmResponse is returned once. The recovery codes are not stored in a form
+// that can be shown again. Token is a full session token.
+type MFAEnrollmentConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Token         string   `json:"token"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFARolePolicyResponse struct {
+	Role      string    `json:"role"`
+	Required  bool      `json:"required"`
+	UpdatedBy *string   `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
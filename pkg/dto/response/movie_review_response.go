@@ -0,0 +1,28 @@
+package response
+
+// MovieReviewResponse only carries hidden_at, hidden_by and hidden_reason on the admin
+// endpoints, and only for hidden reviews. Public listings never include hidden reviews.
+type MovieReviewResponse struct {
+	ID           int64   `json:"id"`
+	MovieId      string  `json:"movie_id"`
+	Username     string  `json:"username"`
+	Rating       int     `json:"rating"`
+	Review       string  `json:"review"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+	Hidden       bool    `json:"hidden,omitempty"`
+	HiddenAt     *string `json:"hidden_at,omitempty"`
+	HiddenBy     *string `json:"hidden_by,omitempty"`
+	HiddenReason *string `json:"hidden_reason,omitempty"`
+}
+
+type MovieReviewsResponse struct {
+	MovieId      string                `json:"movie_id"`
+	SkyfoxRating float64               `json:"skyfox_rating"`
+	ReviewCount  int                   `json:"review_count"`
+	Reviews      []MovieReviewResponse `json:"reviews"`
+}
+
+type MovieReviewListResponse struct {
+	Reviews []MovieReviewResponse `json:"reviews"`
+}
@@ -0,0 +1,28 @@
+package response
+
+type SeatTypeUtilization struct {
+	SeatsSold       int     `json:"seats_sold"`
+	Capacity        int     `json:"capacity"`
+	UtilizationRate float64 `json:"utilization_rate"`
+}
+
+type OccupancyStats struct {
+	Key                  string              `json:"key,omitempty"`
+	Label                string              `json:"label"`
+	Shows                int                 `json:"shows"`
+	SeatsSold            int                 `json:"seats_sold"`
+	Capacity             int                 `json:"capacity"`
+	OccupancyRate        float64             `json:"occupancy_rate"`
+	Deluxe               SeatTypeUtilization `json:"deluxe"`
+	Standard             SeatTypeUtilization `json:"standard"`
+	AverageLeadTimeHours float64             `json:"average_lead_time_hours"`
+}
+
+type OccupancyDashboardResponse struct {
+	Summary   OccupancyStats   `json:"summary"`
+	Series    []OccupancyStats `json:"series"`
+	ByShow    []OccupancyStats `json:"by_show"`
+	ByMovie   []OccupancyStats `json:"by_movie"`
+	BySlot    []OccupancyStats `json:"by_slot"`
+	ByWeekday []OccupancyStats `json:"by_weekday"`
+}
@@ -6,3 +6,12 @@ type PaymentResponse struct {
 	Status        string `json:"status"`
 	TransactionID string `json:"transaction_id"`
 }
+
+type CardTokenResponse struct {
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	Status    string `json:"status"`
+	Token     string `json:"token"`
+	Last4     string `json:"last4"`
+	Brand     string `json:"brand"`
+}
@@ -0,0 +1,48 @@
+package response
+
+import "github.com/iamsuteerth/skyfox-backend/pkg/models"
+
+// Public listings carry no customer or booking data beyond seat counts.
+
+type PublicMovieResponse struct {
+	MovieId      string  `json:"movie_id"`
+	Name         string  `json:"name"`
+	Duration     string  `json:"duration"`
+	Plot         string  `json:"plot"`
+	Genre        string  `json:"genre"`
+	Poster       string  `json:"poster"`
+	ImdbRating   string  `json:"imdb_rating"`
+	SkyfoxRating float64 `json:"skyfox_rating"`
+	ReviewCount  int     `json:"review_count"`
+}
+
+type PublicShowResponse struct {
+	ShowId         int                  `json:"show_id"`
+	MovieId        string               `json:"movie_id"`
+	Movie          *PublicMovieResponse `json:"movie,omitempty"`
+	Date           string               `json:"date"`
+	Slot           models.Slot          `json:"slot"`
+	Cost           float64              `json:"cost"`
+	AvailableSeats int                  `json:"available_seats"`
+}
+
+type PublicShowListResponse struct {
+	From  string               `json:"from"`
+	To    string               `json:"to"`
+	Shows []PublicShowResponse `json:"shows"`
+}
+
+type PublicMovieSummaryResponse struct {
+	PublicMovieResponse
+	UpcomingShows int    `json:"upcoming_shows"`
+	NextShowDate  string `json:"next_show_date"`
+}
+
+type PublicMovieListResponse struct {
+	Movies []PublicMovieSummaryResponse `json:"movies"`
+}
+
+type PublicMovieDetailResponse struct {
+	Movie PublicMovieResponse  `json:"movie"`
+	Shows []PublicShowResponse `json:"shows"`
+}
@@ -0,0 +1,42 @@
+package response
+
+type ReportScheduleResponse struct {
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	ReportType     string   `json:"report_type"`
+	Format         string   `json:"format"`
+	Period         string   `json:"period"`
+	CronExpression string   `json:"cron_expression"`
+	Recipients     []string `json:"recipients"`
+	Enabled        bool     `json:"enabled"`
+	NextRunAt      string   `json:"next_run_at"`
+	LastRunAt      string   `json:"last_run_at,omitempty"`
+	CreatedBy      string   `json:"created_by"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+type ReportRunResponse struct {
+	ID             int64  `json:"id"`
+	ScheduleID     *int64 `json:"schedule_id,omitempty"`
+	ReportType     string `json:"report_type"`
+	Format         string `json:"format"`
+	PeriodStart    string `json:"period_start"`
+	PeriodEnd      string `json:"period_end"`
+	Status         string `json:"status"`
+	FileName       string `json:"file_name,omitempty"`
+	SizeBytes      int64  `json:"size_bytes,omitempty"`
+	DeliveryStatus string `json:"delivery_status,omitempty"`
+	Error          string `json:"error,omitempty"`
+	TriggeredBy    string `json:"triggered_by,omitempty"`
+	Attempt        int    `json:"attempt"`
+	NextRetryAt    string `json:"next_retry_at,omitempty"`
+	StartedAt      string `json:"started_at"`
+	CompletedAt    string `json:"completed_at,omitempty"`
+}
+
+type ReportDownloadResponse struct {
+	RunID       int64  `json:"run_id"`
+	FileName    string `json:"file_name"`
+	DownloadURL string `json:"download_url"`
+	ExpiresAt   string `json:"expires_at"`
+}
@@ -2,6 +2,7 @@ package response
 
 type RevenueGroupStats struct {
 	Label            string  `json:"label"`
+	MovieID          string  `json:"movie_id,omitempty"`
 	TotalRevenue     float64 `json:"total_revenue"`
 	MeanRevenue      float64 `json:"mean_revenue"`
 	MedianRevenue    float64 `json:"median_revenue"`
@@ -10,10 +11,11 @@ type RevenueGroupStats struct {
 }
 
 type RevenueDashboardResponse struct {
-	TotalRevenue     float64             `json:"total_revenue"`
-	MeanRevenue      float64             `json:"mean_revenue"`
-	MedianRevenue    float64             `json:"median_revenue"`
-	TotalBookings    int                 `json:"total_bookings"`
-	TotalSeatsBooked int                 `json:"total_seats_booked"`
-	Groups           []RevenueGroupStats `json:"groups"`
+	TotalRevenue     float64                  `json:"total_revenue"`
+	MeanRevenue      float64                  `json:"mean_revenue"`
+	MedianRevenue    float64                  `json:"median_revenue"`
+	TotalBookings    int                      `json:"total_bookings"`
+	TotalSeatsBooked int                      `json:"total_seats_booked"`
+	Groups           []RevenueGroupStats      `json:"groups"`
+	Forecast         *RevenueForecastResponse `json:"forecast,omitempty"`
 }
@@ -0,0 +1,31 @@
+package response
+
+type ForecastRange struct {
+	Low      float64 `json:"low"`
+	Expected float64 `json:"expected"`
+	High     float64 `json:"high"`
+}
+
+type ShowRevenueForecast struct {
+	Label            string        `json:"label"`
+	ShowID           int           `json:"show_id"`
+	MovieID          string        `json:"movie_id"`
+	MovieName        string        `json:"movie_name,omitempty"`
+	Date             string        `json:"date"`
+	Slot             string        `json:"slot"`
+	Cost             float64       `json:"cost"`
+	Capacity         int           `json:"capacity"`
+	SeatsSold        int           `json:"seats_sold"`
+	RevenueToDate    float64       `json:"revenue_to_date"`
+	ExpectedSeats    ForecastRange `json:"expected_seats"`
+	ExpectedBookings ForecastRange `json:"expected_bookings"`
+	ExpectedRevenue  ForecastRange `json:"expected_revenue"`
+	SampleSize       int           `json:"sample_size"`
+}
+
+type RevenueForecastResponse struct {
+	HorizonDays     int                   `json:"horizon_days"`
+	ConfidenceLevel float64               `json:"confidence_level"`
+	ExpectedRevenue ForecastRange         `json:"expected_revenue"`
+	Forecasts       []ShowRevenueForecast `json:"forecasts"`
+}
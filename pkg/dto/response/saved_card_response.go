@@ -0,0 +1,14 @@
+package response
+
+type SavedCardResponse struct {
+	ID          int64  `json:"id"`
+	Last4       string `json:"last4"`
+	Brand       string `json:"brand"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type SavedCardsResponse struct {
+	Cards []SavedCardResponse `json:"cards"`
+}
@@ -44,3 +44,17 @@ func NewShowConfirmationResponse(id int, movieId string, slot models.Slot, date
 		Cost:    cost,
 	}
 }
+
+// ShowSearchResult is a show with its movie, seat availability and the price of a Deluxe seat.
+type ShowSearchResult struct {
+	ShowResponse
+	DeluxeCost float64 `json:"deluxeCost"`
+}
+
+// ShowSearchResponse is one page of search results. Total counts the matches across all pages.
+type ShowSearchResponse struct {
+	Shows  []ShowSearchResult `json:"shows"`
+	Total  int                `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
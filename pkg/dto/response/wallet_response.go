@@ -7,14 +7,55 @@ type WalletResponse struct {
 }
 
 type WalletTransactionResponse struct {
-	ID              int64   `json:"id"`
-	Amount          float64 `json:"amount"`
-	TransactionType string  `json:"transaction_type"` // "ADD" or "DEDUCT" to match enum
-	BookingID       *int64  `json:"booking_id,omitempty"`
-	TransactionID   string  `json:"transaction_id"`
-	Timestamp       string  `json:"timestamp"`
+	ID                   int64   `json:"id"`
+	Amount               float64 `json:"amount"`
+	TransactionType      string  `json:"transaction_type"` // matches the wallet_transaction_type enum
+	BookingID            *int64  `json:"booking_id,omitempty"`
+	TransactionID        string  `json:"transaction_id"`
+	Timestamp            string  `json:"timestamp"`
+	CounterpartyUsername *string `json:"counterparty_username,omitempty"`
+	Note                 *string `json:"note,omitempty"`
+	MovieID              *string `json:"movie_id,omitempty"`
+	MovieName            *string `json:"movie_name,omitempty"`
+	MoviePoster          *string `json:"movie_poster,omitempty"`
 }
 
+// WalletTransactionsResponse is one page of transactions. NextCursor is null on the last page.
 type WalletTransactionsResponse struct {
 	Transactions []WalletTransactionResponse `json:"transactions"`
+	NextCursor   *string                     `json:"next_cursor"`
+}
+
+type WalletAdjustmentResponse struct {
+	Reference string  `json:"reference"`
+	Username  string  `json:"username"`
+	Direction string  `json:"direction"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+	Balance   float64 `json:"balance"`
+	CreatedBy string  `json:"created_by"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type WalletTransferResponse struct {
+	TransferID        int64   `json:"transfer_id"`
+	Reference         string  `json:"reference"`
+	RecipientUsername string  `json:"recipient_username"`
+	Amount            float64 `json:"amount"`
+	Note              *string `json:"note,omitempty"`
+	Status            string  `json:"status"`
+	ExpiresAt         string  `json:"expires_at"`
+	CompletedAt       string  `json:"completed_at,omitempty"`
+	Balance           float64 `json:"balance"`
+}
+
+type WalletCreditResponse struct {
+	Reference string  `json:"reference"`
+	Username  string  `json:"username"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+	ExpiresAt string  `json:"expires_at,omitempty"`
+	Balance   float64 `json:"balance"`
+	GrantedBy string  `json:"granted_by"`
+	CreatedAt string  `json:"created_at"`
 }
@@ -0,0 +1,26 @@
+package response
+
+type WalletStatementLine struct {
+	Timestamp            string  `json:"timestamp"`
+	TransactionType      string  `json:"transaction_type"`
+	TransactionID        string  `json:"transaction_id"`
+	Credit               float64 `json:"credit"`
+	Debit                float64 `json:"debit"`
+	BookingID            *int64  `json:"booking_id,omitempty"`
+	MovieName            string  `json:"movie_name,omitempty"`
+	CounterpartyUsername *string `json:"counterparty_username,omitempty"`
+	Note                 *string `json:"note,omitempty"`
+}
+
+type WalletStatement struct {
+	Username       string                `json:"username"`
+	CustomerName   string                `json:"customer_name"`
+	From           string                `json:"from"`
+	To             string                `json:"to"`
+	OpeningBalance float64               `json:"opening_balance"`
+	ClosingBalance float64               `json:"closing_balance"`
+	TotalCredits   float64               `json:"total_credits"`
+	TotalDebits    float64               `json:"total_debits"`
+	Lines          []WalletStatementLine `json:"lines"`
+	GeneratedAt    string                `json:"generated_at"`
+}
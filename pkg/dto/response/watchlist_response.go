@@ -0,0 +1,49 @@
+package response
+
+import "github.com/iamsuteerth/skyfox-backend/pkg/models"
+
+// WatchlistMovieResponse leaves Name and MoviePoster empty when the movie service is
+// unavailable, so the watchlist itself can still be shown.
+type WatchlistMovieResponse struct {
+	MovieId     string `json:"movie_id"`
+	Name        string `json:"name,omitempty"`
+	MoviePoster string `json:"movie_poster,omitempty"`
+	AddedAt     string `json:"added_at"`
+}
+
+type WatchlistResponse struct {
+	Movies []WatchlistMovieResponse `json:"movies"`
+}
+
+type WatchlistShowResponse struct {
+	ShowId    int         `json:"show_id"`
+	MovieId   string      `json:"movie_id"`
+	MovieName string      `json:"movie_name,omitempty"`
+	Date      string      `json:"date"`
+	Slot      models.Slot `json:"slot"`
+	Cost      float64     `json:"cost"`
+}
+
+type WatchlistFeedResponse struct {
+	Shows []WatchlistShowResponse `json:"shows"`
+}
+
+type WatchlistAlertResponse struct {
+	ID        int64       `json:"id"`
+	MovieId   string      `json:"movie_id"`
+	MovieName string      `json:"movie_name,omitempty"`
+	ShowId    int         `json:"show_id"`
+	ShowDate  string      `json:"show_date"`
+	Slot      models.Slot `json:"slot"`
+	CreatedAt string      `json:"created_at"`
+	Read      bool        `json:"read"`
+}
+
+type WatchlistAlertsResponse struct {
+	Alerts      []WatchlistAlertResponse `json:"alerts"`
+	UnreadCount int                      `json:"unread_count"`
+}
+
+type MarkWatchlistAlertsReadResponse struct {
+	MarkedRead int64 `json:"marked_read"`
+}
@@ -29,6 +29,13 @@ var (
 			Help: "Number of HTTP requests currently being processed",
 		},
 	)
+
+	SeatMapStreamsOpen = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "skyfox_seat_map_streams_open",
+			Help: "Number of open seat map event streams",
+		},
+	)
 )
 
 func InitMetrics() {
@@ -44,6 +44,8 @@ func getEndpointGroup(path string) string {
 		return "auth"
 	case path == "/change-password":
 		return "auth"
+	case path == "/login/mfa" || strings.HasPrefix(path, "/mfa") || path == "/admin/mfa-policy":
+		return "auth"
 	case strings.HasPrefix(path, "/security-questions"):
 		return "auth"
 		
@@ -54,13 +56,39 @@ func getEndpointGroup(path string) string {
 		return "customer_mgmt"
 	case strings.HasPrefix(path, "/customer/update-profile"):
 		return "customer_mgmt"
+	case path == "/customer/data-export" || path == "/customer/delete-account":
+		return "customer_mgmt"
+	case strings.HasPrefix(path, "/customer/verification"):
+		return "customer_mgmt"
 		
 	// Customer Wallet
 	case strings.HasPrefix(path, "/customer/wallet"):
 		return "wallet"
-		
+	case strings.HasPrefix(path, "/customer/saved-cards"):
+		return "wallet"
+	case strings.HasPrefix(path, "/admin/wallet"):
+		return "wallet"
+
+	// Scheduled Reports
+	case strings.HasPrefix(path, "/admin/reports"):
+		return "reports"
+
+	// Loyalty
+	case strings.HasPrefix(path, "/customer/loyalty") || strings.HasPrefix(path, "/admin/loyalty"):
+		return "loyalty"
+
+	// Watchlist
+	case strings.HasPrefix(path, "/customer/watchlist"):
+		return "watchlist"
+
+	// Movie Reviews
+	case strings.HasPrefix(path, "/movies/") && strings.HasSuffix(path, "/reviews"):
+		return "reviews"
+	case strings.HasPrefix(path, "/customer/reviews") || strings.HasPrefix(path, "/admin/reviews"):
+		return "reviews"
+
 	// Show & Movie Management
-	case path == "/shows" || path == "/show":
+	case path == "/shows" || path == "/show" || path == "/shows/search":
 		return "shows"
 	case path == "/show/movies":
 		return "shows"
@@ -72,6 +100,10 @@ func getEndpointGroup(path string) string {
 		return "booking"
 	case strings.HasPrefix(path, "/admin/create-customer-booking"):
 		return "booking"
+	case path == "/admin/booking/refund":
+		return "booking"
+	case strings.HasSuffix(path, "/seat-map/stream"):
+		return ""
 	case strings.HasPrefix(path, "/shows/") && strings.Contains(path, "seat-map"):
 		return "booking"
 	case strings.HasPrefix(path, "/booking/") && (strings.Contains(path, "/qr") || strings.Contains(path, "/pdf")):
@@ -82,15 +114,27 @@ func getEndpointGroup(path string) string {
 	// Check-in Operations
 	case strings.HasPrefix(path, "/check-in"):
 		return "checkin"
+	case strings.HasPrefix(path, "/console"):
+		return "checkin"
 		
 	// Admin Operations
 	case strings.HasPrefix(path, "/admin/profile") || strings.HasPrefix(path, "/staff/profile"):
 		return "admin"
-	case path == "/revenue":
+	case path == "/revenue" || path == "/revenue/forecast":
+		return "admin"
+	case path == "/occupancy":
 		return "admin"
 	case path == "/booking-csv":
 		return "admin"
 		
+	// Public Listings
+	case strings.HasPrefix(path, "/public/"):
+		return "public"
+
+	// Locally stored files
+	case strings.HasPrefix(path, "/files/"):
+		return "files"
+
 	// System endpoints
 	case path == "/health":
 		return ""
@@ -7,11 +7,31 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 	"github.com/rs/zerolog/log"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
+	return tokenAuthMiddleware("")
+}
+
+// MFAEnrollmentAuthMiddleware also accepts the short-lived token that /login issues to users
+// who must enroll in MFA before they get a full session.
+func MFAEnrollmentAuthMiddleware() gin.HandlerFunc {
+	return tokenAuthMiddleware(constants.TOKEN_PURPOSE_MFA_ENROLLMENT)
+}
+
+// PasswordChangeAuthMiddleware also accepts the short-lived token that /login issues to users
+// whose password has expired, so they can set a new one.
+func PasswordChangeAuthMiddleware() gin.HandlerFunc {
+	return tokenAuthMiddleware(constants.TOKEN_PURPOSE_PASSWORD_CHANGE)
+}
+
+// tokenAuthMiddleware validates the bearer token. Tokens with a purpose claim are only
+// accepted when it matches allowedPurpose, so a scoped token from /login can never be used
+// as a session.
+func tokenAuthMiddleware(allowedPurpose string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		requestID := utils.GetRequestID(ctx)
 
@@ -64,6 +84,14 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			if purpose, _ := claims["purpose"].(string); purpose != "" && purpose != allowedPurpose {
+				log.Debug().Str("purpose", purpose).Msg("Scoped token used outside its purpose")
+				utils.HandleErrorResponse(ctx,
+					utils.NewUnauthorizedError("INVALID_TOKEN", "Unauthorized", nil),
+					requestID)
+				ctx.Abort()
+				return
+			}
 			ctx.Set("claims", claims)
 			log.Debug().Interface("claims", claims).Msg("Token claims set in context")
 		} else {
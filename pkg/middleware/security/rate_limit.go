@@ -0,0 +1,121 @@
+package security
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// maxRateLimitBuckets caps the clients tracked per middleware. Once reached, clients without a
+// bucket share a single overflow bucket until idle buckets are swept.
+const maxRateLimitBuckets = 10000
+
+const overflowBucketKey = ""
+
+// RateLimitMiddleware gives each client IP a token bucket that refills at requestsPerMinute
+// and holds at most burst requests. Each call creates its own set of buckets, so route groups
+// limited separately never share a budget. Buckets live in memory, so every instance enforces
+// the limit on its own. The client IP only comes from X-Forwarded-For when the router trusts
+// the proxy that sent it, see gin.Engine.SetTrustedProxies.
+func RateLimitMiddleware(requestsPerMinute, burst int) gin.HandlerFunc {
+	if requestsPerMinute <= 0 {
+		log.Warn().Msg("Rate limit is not positive, rate limiting disabled for this route group")
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	limiter := &rateLimiter{
+		rate:    float64(requestsPerMinute) / time.Minute.Seconds(),
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+
+	return func(ctx *gin.Context) {
+		allowed, remaining, retryAfter := limiter.take(ctx.ClientIP(), time.Now())
+
+		ctx.Header("X-RateLimit-Limit", strconv.Itoa(requestsPerMinute))
+		ctx.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			requestID := utils.GetRequestID(ctx)
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			utils.HandleErrorResponse(ctx, &utils.AppError{
+				HTTPCode: http.StatusTooManyRequests,
+				Code:     "RATE_LIMITED",
+				Message:  "Too many requests, please try again later",
+			}, requestID)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // tokens per second
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func (l *rateLimiter) take(key string, now time.Time) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitBuckets {
+			key = overflowBucketKey
+			bucket, ok = l.buckets[key]
+		}
+		if !ok {
+			bucket = &tokenBucket{tokens: l.burst, last: now}
+			l.buckets[key] = bucket
+		}
+	}
+
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+		return false, 0, wait
+	}
+
+	bucket.tokens--
+	return true, int(bucket.tokens), 0
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket starts full anyway.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	BookingEventCreated         = "CREATED"
+	BookingEventPaymentReceived = "PAYMENT_RECEIVED"
+	BookingEventCancelled       = "CANCELLED"
+	BookingEventExpired         = "EXPIRED"
+	BookingEventCheckedIn       = "CHECKED_IN"
+	BookingEventRefunded        = "REFUNDED"
+	BookingEventLoyaltyReversed = "LOYALTY_REVERSED"
+)
+
+// BookingAuditEvent is one entry in a booking's audit trail. Actor is the username that
+// caused the event and is nil for system events such as expiry.
+type BookingAuditEvent struct {
+	ID        int64     `json:"id"`
+	BookingID int       `json:"booking_id"`
+	EventType string    `json:"event_type"`
+	Actor     *string   `json:"actor,omitempty"`
+	Details   *string   `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
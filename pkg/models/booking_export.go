@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
+
+// BookingExportFilter selects bookings for export. Period filters apply to booking_time;
+// Until is exclusive. A zero Limit exports every matching booking.
+type BookingExportFilter struct {
+	Statuses    []string
+	Since       *time.Time
+	Until       *time.Time
+	Month       *int
+	Year        *int
+	MovieID     *string
+	SlotID      *int
+	PaymentType *string
+	Limit       int
+	Offset      int
+}
+
+// BookingExportRow is one booking joined with its show, slot and customer.
+type BookingExportRow struct {
+	BookingID        int
+	ShowID           int
+	ShowDate         time.Time
+	MovieID          string
+	SlotName         string
+	CustomerName     string
+	PhoneNumber      string
+	CustomerUsername string
+	NoOfSeats        int
+	AmountPaid       decimal.Decimal
+	PaymentType      string
+	BookingTime      time.Time
+	Status           string
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
+
+// BookingSearchFilter selects one page of bookings for the admin and staff console, newest
+// booking first. Name matches part of the customer name, case-insensitively. Since and Until
+// bound the show date, Until exclusive. StartingWithin keeps shows that have not ended and
+// start within that many minutes from now.
+type BookingSearchFilter struct {
+	BookingID      *int
+	PhoneNumber    string
+	Username       string
+	Name           string
+	ShowID         *int
+	Statuses       []string
+	PaymentType    string
+	Since          *time.Time
+	Until          *time.Time
+	StartingWithin *int
+	After          *PageCursor
+	Limit          int
+}
+
+// BookingSearchRecord is a booking joined with its show, slot, customer and seats.
+type BookingSearchRecord struct {
+	BookingID        int
+	ShowID           int
+	MovieID          string
+	ShowDate         time.Time
+	SlotName         string
+	StartTime        string
+	EndTime          string
+	CustomerUsername *string
+	CustomerName     string
+	PhoneNumber      string
+	SeatNumbers      []string
+	AmountPaid       decimal.Decimal
+	PaymentType      string
+	BookingTime      time.Time
+	Status           string
+}
+
+// ShowCheckInFilter selects the shows summarised for check-in, using the same show scope as
+// BookingSearchFilter.
+type ShowCheckInFilter struct {
+	ShowID         *int
+	Since          *time.Time
+	Until          *time.Time
+	StartingWithin *int
+}
+
+// ShowCheckInSummary counts a show's paid bookings and seats, and how many have checked in.
+type ShowCheckInSummary struct {
+	ShowID           int
+	MovieID          string
+	ShowDate         time.Time
+	SlotName         string
+	StartTime        string
+	EndTime          string
+	ExpectedBookings int
+	ExpectedSeats    int
+	AdmittedBookings int
+	AdmittedSeats    int
+}
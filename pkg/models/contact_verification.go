@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	VerificationChannelEmail = "email"
+	VerificationChannelPhone = "phone"
+)
+
+// ContactVerificationCode is a one-time code sent to an email address or phone number. Only
+// the hash of the code is kept.
+type ContactVerificationCode struct {
+	ID          int64      `json:"id"`
+	Username    string     `json:"username"`
+	Channel     string     `json:"channel"`
+	Destination string     `json:"destination"`
+	CodeHash    string     `json:"-"`
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ConsumedAt  *time.Time `json:"consumed_at,omitempty"`
+}
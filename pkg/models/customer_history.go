@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
+
+// CustomerBookingFilter selects one page of a customer's bookings, newest booking first.
+// Since and Until bound the show date, Until exclusive. Upcoming, when set, keeps only shows
+// that have not started (true) or have started (false). After holds the keyset of the last
+// booking on the previous page.
+type CustomerBookingFilter struct {
+	Username string
+	Statuses []string
+	Since    *time.Time
+	Until    *time.Time
+	Upcoming *bool
+	After    *PageCursor
+	Limit    int
+}
+
+// PageCursor is the position of the last item on a page: its sort time and id.
+type PageCursor struct {
+	Time time.Time
+	ID   int64
+}
+
+// CustomerBookingRecord is a booking joined with its show, slot and seats.
+type CustomerBookingRecord struct {
+	BookingID   int
+	ShowID      int
+	MovieID     string
+	ShowDate    time.Time
+	SlotName    string
+	StartTime   string
+	SeatNumbers []string
+	AmountPaid  decimal.Decimal
+	PaymentType string
+	BookingTime time.Time
+	Status      string
+}
+
+// WalletTransactionFilter selects one page of a customer's wallet transactions, newest first.
+// Since and Until bound the transaction time, Until exclusive.
+type WalletTransactionFilter struct {
+	Username string
+	Types    []string
+	Since    *time.Time
+	Until    *time.Time
+	After    *PageCursor
+	Limit    int
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type LoyaltyAccount struct {
+	Username       string    `json:"username"`
+	PointsBalance  int       `json:"points_balance"`
+	LifetimePoints int       `json:"lifetime_points"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type LoyaltyTransaction struct {
+	ID              int64     `json:"id"`
+	Username        string    `json:"username"`
+	BookingID       *int64    `json:"booking_id,omitempty"`
+	TransactionType string    `json:"transaction_type"`
+	Points          int       `json:"points"`
+	Description     string    `json:"description"`
+	CreatedBy       *string   `json:"created_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
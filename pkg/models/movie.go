@@ -8,4 +8,8 @@ type Movie struct {
 	ImdbRating  string `json:"imdbRating"`
 	MoviePoster string `json:"moviePoster"`
 	Genre       string `json:"genre"`
+	// SkyfoxRating and ReviewCount come from Skyfox reviews, not the movie service, and are
+	// only filled in where a listing asks for them.
+	SkyfoxRating float64 `json:"skyfoxRating"`
+	ReviewCount  int     `json:"reviewCount"`
 }
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// MovieReview is a customer's rating and review of a movie they attended. Hidden reviews
+// are kept for moderation but left out of public listings and rating aggregates.
+type MovieReview struct {
+	ID           int64
+	Username     string
+	MovieId      string
+	Rating       int
+	Review       string
+	Hidden       bool
+	HiddenAt     *time.Time
+	HiddenBy     *string
+	HiddenReason *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type MovieReviewFilter struct {
+	MovieID  string
+	Username string
+	// Hidden restricts the results to hidden or visible reviews when set.
+	Hidden *bool
+	Limit  int
+	Offset int
+}
+
+// MovieRatingSummary aggregates the visible reviews of a movie.
+type MovieRatingSummary struct {
+	MovieId       string
+	AverageRating float64
+	ReviewCount   int
+}
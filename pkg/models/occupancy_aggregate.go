@@ -0,0 +1,25 @@
+package models
+
+// OccupancyDimension names what occupancy is grouped by. Bucket groups by show date
+// using the timeframe set on the query, and an empty dimension aggregates everything.
+type OccupancyDimension string
+
+const (
+	OccupancyOverall   OccupancyDimension = ""
+	OccupancyByShow    OccupancyDimension = "show"
+	OccupancyByMovie   OccupancyDimension = "movie"
+	OccupancyBySlot    OccupancyDimension = "slot"
+	OccupancyByWeekday OccupancyDimension = "weekday"
+	OccupancyByBucket  OccupancyDimension = "bucket"
+)
+
+type OccupancyAggregate struct {
+	Key                  string
+	Label                string
+	Shows                int
+	SeatsSold            int
+	Capacity             int
+	DeluxeSeatsSold      int
+	DeluxeCapacity       int
+	AverageLeadTimeHours float64
+}
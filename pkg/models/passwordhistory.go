@@ -1,19 +1,19 @@
 package models
 
+import "time"
+
+// PasswordHistory is one password a user has had, including the current one.
 type PasswordHistory struct {
-	ID                int    `json:"id"`
-	Username          string `json:"username"`
-	PreviousPassword1 string `json:"previous_password_1"`
-	PreviousPassword2 string `json:"previous_password_2"`
-	PreviousPassword3 string `json:"previous_password_3"`
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
-func NewPasswordHistory(username string, previous1, previous2, previous3 string) PasswordHistory {
+func NewPasswordHistory(username string, passwordHash string) PasswordHistory {
 	return PasswordHistory{
-		Username:          username,
-		PreviousPassword1: previous1,
-		PreviousPassword2: previous2,
-		PreviousPassword3: previous3,
+		Username:     username,
+		PasswordHash: passwordHash,
 	}
 }
 
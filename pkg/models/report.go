@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type ReportSchedule struct {
+	ID             int64      `json:"id"`
+	Name           string     `json:"name"`
+	ReportType     string     `json:"report_type"`
+	Format         string     `json:"format"`
+	Period         string     `json:"period"`
+	CronExpression string     `json:"cron_expression"`
+	Recipients     []string   `json:"recipients"`
+	Enabled        bool       `json:"enabled"`
+	NextRunAt      time.Time  `json:"next_run_at"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ReportRun struct {
+	ID             int64      `json:"id"`
+	ScheduleID     *int64     `json:"schedule_id,omitempty"`
+	ReportType     string     `json:"report_type"`
+	Format         string     `json:"format"`
+	PeriodStart    time.Time  `json:"period_start"`
+	PeriodEnd      time.Time  `json:"period_end"`
+	Status         string     `json:"status"`
+	ObjectKey      *string    `json:"object_key,omitempty"`
+	FileName       *string    `json:"file_name,omitempty"`
+	SizeBytes      *int64     `json:"size_bytes,omitempty"`
+	DeliveryStatus *string    `json:"delivery_status,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	TriggeredBy    *string    `json:"triggered_by,omitempty"`
+	Attempt        int        `json:"attempt"`
+	RetryAt        *time.Time `json:"retry_at,omitempty"`
+	StartedAt      time.Time  `json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
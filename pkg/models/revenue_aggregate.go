@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// RevenueFilter narrows the bookings included in revenue aggregates. Nil fields are not applied.
+type RevenueFilter struct {
+	Statuses []string
+	Since    *time.Time
+	Until    *time.Time
+	Month    *int
+	Year     *int
+	MovieID  *string
+	SlotID   *int
+	// MovieIDsIn restricts bookings to these movies when non-nil. An empty slice matches nothing.
+	MovieIDsIn []string
+}
+
+// RevenueGroupKey names the columns revenue can be grouped by. Bucket groups by
+// booking time using the timeframe set on the query (daily, weekly, monthly or yearly).
+type RevenueGroupKey string
+
+const (
+	RevenueGroupByMovie  RevenueGroupKey = "movie"
+	RevenueGroupBySlot   RevenueGroupKey = "slot"
+	RevenueGroupByBucket RevenueGroupKey = "bucket"
+)
+
+type RevenueAggregate struct {
+	MovieID       string
+	SlotName      string
+	Bucket        string
+	TotalRevenue  float64
+	MeanRevenue   float64
+	MedianRevenue float64
+	TotalBookings int
+	TotalSeats    int
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type SavedCard struct {
+	ID            int64     `json:"id"`
+	Username      string    `json:"username"`
+	ProviderToken string    `json:"-"`
+	Last4         string    `json:"last4"`
+	Brand         string    `json:"brand"`
+	ExpiryMonth   int       `json:"expiry_month"`
+	ExpiryYear    int       `json:"expiry_year"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	SeatStateHeld      = "HELD"
+	SeatStateReleased  = "RELEASED"
+	SeatStateBooked    = "BOOKED"
+	SeatStateCheckedIn = "CHECKED_IN"
+	// SeatStateResync tells subscribers that events may have been missed and the seat map
+	// should be fetched again.
+	SeatStateResync = "RESYNC"
+)
+
+// SeatEvent is a change to the state of some seats of a show.
+type SeatEvent struct {
+	ShowID      int       `json:"show_id"`
+	State       string    `json:"state"`
+	SeatNumbers []string  `json:"seat_numbers"`
+	At          time.Time `json:"at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ShowAvailability is a show with the number of seats held by pending, confirmed and
+// checked-in bookings.
+type ShowAvailability struct {
+	Show
+	BookedSeats int
+}
+
+type UpcomingShowFilter struct {
+	From  *time.Time
+	Until *time.Time
+	// MovieIDs restricts the shows to these movies when non-nil. An empty slice matches nothing.
+	MovieIDs []string
+	Limit    int
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ShowSalesMaxLeadDays caps lead-time buckets; earlier bookings fall into the last bucket.
+const ShowSalesMaxLeadDays = 30
+
+// ShowSales is the booking history of a single show. SeatsByLeadDays[d] counts seats
+// booked d whole days before the show started.
+type ShowSales struct {
+	ShowID          int
+	MovieID         string
+	SlotID          int
+	SlotName        string
+	Date            time.Time
+	StartsAt        time.Time
+	Cost            float64
+	Capacity        int
+	Bookings        int
+	SeatsSold       int
+	Revenue         float64
+	SeatsByLeadDays [ShowSalesMaxLeadDays + 1]int
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// ShowSearchFilter selects one page of shows between From and Until, both inclusive. StartFrom
+// and StartBefore bound the slot start time as HH:MM:SS; when StartFrom is later than
+// StartBefore the range wraps past midnight. A show matches the price range when its standard
+// or Deluxe price falls in it. MovieIDs restricts the shows to these movies when non-nil, and an
+// empty slice matches nothing. UpcomingOnly drops shows that have already started.
+type ShowSearchFilter struct {
+	From         time.Time
+	Until        time.Time
+	MovieIDs     []string
+	SlotID       *int
+	StartFrom    string
+	StartBefore  string
+	MinPrice     *float64
+	MaxPrice     *float64
+	MinSeats     *int
+	UpcomingOnly bool
+	Sort         string
+	Limit        int
+	Offset       int
+}
+
+const (
+	ShowSearchSortTime      = "time"
+	ShowSearchSortPriceAsc  = "price_asc"
+	ShowSearchSortPriceDesc = "price_desc"
+	ShowSearchSortSeats     = "seats"
+	ShowSearchSortRating    = "rating"
+)
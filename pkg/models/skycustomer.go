@@ -1,15 +1,27 @@
 package models
 
+import "time"
+
 type SkyCustomer struct {
-	ID                 int    `json:"id"`
-	Name               string `json:"name"`
-	Username           string `json:"username"`
-	Number             string `json:"number"`
-	Email              string `json:"email"`
-	ProfileImg         string `json:"profile_img"`
-	ProfileImgBytes    []byte `json:"profile_img_bytes"`
-	SecurityQuestionID int    `json:"security_question_id"`
-	SecurityAnswerHash string `json:"-"`
+	ID                 int        `json:"id"`
+	Name               string     `json:"name"`
+	Username           string     `json:"username"`
+	Number             string     `json:"number"`
+	Email              string     `json:"email"`
+	ProfileImg         string     `json:"profile_img"`
+	ProfileImgBytes    []byte     `json:"profile_img_bytes"`
+	SecurityQuestionID int        `json:"security_question_id"`
+	SecurityAnswerHash string     `json:"-"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at,omitempty"`
+	NumberVerifiedAt   *time.Time `json:"number_verified_at,omitempty"`
+	// PendingEmail and PendingNumber hold a changed contact until it is verified.
+	PendingEmail  *string `json:"pending_email,omitempty"`
+	PendingNumber *string `json:"pending_number,omitempty"`
+}
+
+// HasVerifiedContact reports whether the customer has verified their email or phone number.
+func (c *SkyCustomer) HasVerifiedContact() bool {
+	return c.EmailVerifiedAt != nil || c.NumberVerifiedAt != nil
 }
 
 func NewSkyCustomer(name, username, number, email string, profileImgBytes []byte, securityQuestionID int, securityAnswerHash string) SkyCustomer {
@@ -8,6 +8,8 @@ type User struct {
 	Password  string    `json:"password"`
 	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
+	// PasswordChangedAt is when the current password was set, used for password expiry.
+	PasswordChangedAt time.Time `json:"password_changed_at"`
 }
 
 func NewUser(username string, password string, role string) User {
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// UserMFA is a user's TOTP enrollment. EnabledAt is nil while enrollment waits for the first
+// code from the authenticator app.
+type UserMFA struct {
+	Username       string     `json:"username"`
+	Secret         string     `json:"-"`
+	EnabledAt      *time.Time `json:"enabled_at,omitempty"`
+	LastUsedStep   *int64     `json:"-"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (m *UserMFA) Enabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+type MFARecoveryCode struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	CodeHash string `json:"-"`
+}
+
+type MFARolePolicy struct {
+	Role      string    `json:"role"`
+	Required  bool      `json:"required"`
+	UpdatedBy *string   `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
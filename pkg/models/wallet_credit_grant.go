@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
+
+type WalletCreditGrant struct {
+	ID              int64           `json:"id"`
+	WalletID        int64           `json:"wallet_id"`
+	Reference       string          `json:"reference"`
+	Amount          decimal.Decimal `json:"amount"`
+	RemainingAmount decimal.Decimal `json:"remaining_amount"`
+	Reason          string          `json:"reason"`
+	GrantedBy       string          `json:"granted_by"`
+	ExpiresAt       *time.Time      `json:"expires_at,omitempty"`
+	ExpiredAt       *time.Time      `json:"expired_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
+
+type LedgerJournalEntry struct {
+	ID          int64           `json:"id"`
+	Reference   string          `json:"reference"`
+	EntryType   string          `json:"entry_type"`
+	Description string          `json:"description"`
+	BookingID   *int64          `json:"booking_id,omitempty"`
+	CreatedBy   *string         `json:"created_by,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Postings    []LedgerPosting `json:"postings"`
+}
+
+// LedgerPosting targets either a customer wallet (WalletID) or a system account (AccountCode).
+// TransactionType, CounterpartyUsername and Note only apply to wallet postings and control
+// the wallet_transaction row shown to the customer; TransactionType defaults to ADD or DEDUCT.
+type LedgerPosting struct {
+	ID                   int64           `json:"id"`
+	JournalEntryID       int64           `json:"journal_entry_id"`
+	WalletID             *int64          `json:"wallet_id,omitempty"`
+	AccountCode          string          `json:"account_code,omitempty"`
+	Direction            string          `json:"direction"`
+	Amount               decimal.Decimal `json:"amount"`
+	TransactionType      string          `json:"transaction_type,omitempty"`
+	CounterpartyUsername *string         `json:"counterparty_username,omitempty"`
+	Note                 *string         `json:"note,omitempty"`
+}
+
+type WalletReconciliation struct {
+	WalletID       int64           `json:"wallet_id"`
+	Username       string          `json:"username"`
+	CachedBalance  decimal.Decimal `json:"cached_balance"`
+	JournalBalance decimal.Decimal `json:"journal_balance"`
+}
@@ -7,12 +7,15 @@ import (
 )
 
 type WalletTransaction struct {
-	ID              int64           `json:"id"`
-	WalletID        int64           `json:"wallet_id"`
-	Username        string          `json:"username"`
-	BookingID       *int64          `json:"booking_id,omitempty"`
-	TransactionID   string          `json:"transaction_id"`
-	Amount          decimal.Decimal `json:"amount"`
-	Timestamp       time.Time       `json:"timestamp"`
-	TransactionType string          `json:"transaction_type"` // "ADD" or "DEDUCT"
+	ID                   int64           `json:"id"`
+	WalletID             int64           `json:"wallet_id"`
+	Username             string          `json:"username"`
+	BookingID            *int64          `json:"booking_id,omitempty"`
+	TransactionID        string          `json:"transaction_id"`
+	Amount               decimal.Decimal `json:"amount"`
+	Timestamp            time.Time       `json:"timestamp"`
+	TransactionType      string          `json:"transaction_type"` // "ADD", "DEDUCT", "TRANSFER_IN", "TRANSFER_OUT", "CREDIT", "CREDIT_EXPIRY" or "REFUND"
+	CounterpartyUsername *string         `json:"counterparty_username,omitempty"`
+	Note                 *string         `json:"note,omitempty"`
+	MovieID              *string         `json:"movie_id,omitempty"` // set only when listing transactions, for booking payments
 }
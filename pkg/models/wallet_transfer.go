@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/govalues/decimal"
+)
+
+type WalletTransfer struct {
+	ID                int64           `json:"id"`
+	Reference         string          `json:"reference"`
+	SenderUsername    string          `json:"sender_username"`
+	RecipientUsername string          `json:"recipient_username"`
+	Amount            decimal.Decimal `json:"amount"`
+	Note              *string         `json:"note,omitempty"`
+	Status            string          `json:"status"`
+	ExpiresAt         time.Time       `json:"expires_at"`
+	CreatedAt         time.Time       `json:"created_at"`
+	CompletedAt       *time.Time      `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type WatchlistItem struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	MovieId   string    `json:"movie_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WatchlistAlert tells a customer that a show was scheduled for a movie on their watchlist.
+type WatchlistAlert struct {
+	ID        int64      `json:"id"`
+	Username  string     `json:"username"`
+	MovieId   string     `json:"movie_id"`
+	ShowId    int        `json:"show_id"`
+	ShowDate  time.Time  `json:"show_date"`
+	Slot      Slot       `json:"slot"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	// DeliveryAttempts counts hand-offs to the notifier, including the current one.
+	DeliveryAttempts int `json:"-"`
+}
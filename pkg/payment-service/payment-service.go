@@ -29,6 +29,8 @@ type validationError struct {
 
 type PaymentService interface {
 	ProcessPayment(ctx context.Context, cardNumber, cvv, expiry, name string, amount decimal.Decimal) (string, error)
+	ProcessTokenPayment(ctx context.Context, token string, amount decimal.Decimal) (string, error)
+	TokenizeCard(ctx context.Context, cardNumber, cvv, expiry, name string) (*response.CardTokenResponse, error)
 }
 
 type paymentService struct {
@@ -42,8 +44,6 @@ func NewPaymentService(cfg config.PaymentServiceConfig) PaymentService {
 }
 
 func (s *paymentService) ProcessPayment(ctx context.Context, cardNumber, cvv, expiry, name string, amount decimal.Decimal) (string, error) {
-	url := fmt.Sprintf("%s/payment", s.config.BaseURL)
-
 	paymentReq := request.PaymentRequest{
 		CardNumber: cardNumber,
 		CVV:        cvv,
@@ -53,14 +53,82 @@ func (s *paymentService) ProcessPayment(ctx context.Context, cardNumber, cvv, ex
 		Timestamp:  time.Now().Format(time.RFC3339),
 	}
 
-	payloadBytes, err := json.Marshal(paymentReq)
+	return s.charge(ctx, "/payment", paymentReq)
+}
+
+func (s *paymentService) ProcessTokenPayment(ctx context.Context, token string, amount decimal.Decimal) (string, error) {
+	paymentReq := request.TokenPaymentRequest{
+		Token:     token,
+		Amount:    amount,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	return s.charge(ctx, "/payment/token", paymentReq)
+}
+
+func (s *paymentService) TokenizeCard(ctx context.Context, cardNumber, cvv, expiry, name string) (*response.CardTokenResponse, error) {
+	tokenizeReq := request.TokenizeCardRequest{
+		CardNumber: cardNumber,
+		CVV:        cvv,
+		Expiry:     expiry,
+		Name:       name,
+		Timestamp:  time.Now().Format(time.RFC3339),
+	}
+
+	statusCode, body, err := s.post(ctx, "/tokenize", tokenizeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, providerError(statusCode, body, "Card tokenization")
+	}
+
+	var tokenResp response.CardTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, utils.NewInternalServerError("JSON_PARSE_ERROR", "Failed to parse tokenization response", err)
+	}
+
+	if tokenResp.Status != "SUCCESS" || tokenResp.Token == "" {
+		return nil, utils.NewInternalServerError("TOKENIZATION_FAILED", "Card tokenization was not successful", nil)
+	}
+
+	return &tokenResp, nil
+}
+
+func (s *paymentService) charge(ctx context.Context, path string, payload interface{}) (string, error) {
+	statusCode, body, err := s.post(ctx, path, payload)
 	if err != nil {
-		return "", utils.NewInternalServerError("REQUEST_PREPARATION_FAILED", "Failed to prepare payment request", err)
+		return "", err
+	}
+
+	if statusCode != http.StatusOK {
+		return "", providerError(statusCode, body, "Payment")
+	}
+
+	var paymentResp response.PaymentResponse
+	if err := json.Unmarshal(body, &paymentResp); err != nil {
+		return "", utils.NewInternalServerError("JSON_PARSE_ERROR", "Failed to parse payment response", err)
+	}
+
+	if paymentResp.Status != "SUCCESS" {
+		return "", utils.NewInternalServerError("PAYMENT_FAILED", "Payment was not successful", nil)
+	}
+
+	return paymentResp.TransactionID, nil
+}
+
+func (s *paymentService) post(ctx context.Context, path string, payload interface{}) (int, []byte, error) {
+	url := fmt.Sprintf("%s%s", s.config.BaseURL, path)
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, utils.NewInternalServerError("REQUEST_PREPARATION_FAILED", "Failed to prepare payment request", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
-		return "", utils.NewInternalServerError("REQUEST_CREATION_FAILED", "Failed to create payment request", err)
+		return 0, nil, utils.NewInternalServerError("REQUEST_CREATION_FAILED", "Failed to create payment request", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -71,35 +139,27 @@ func (s *paymentService) ProcessPayment(ctx context.Context, cardNumber, cvv, ex
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", utils.NewInternalServerError("PAYMENT_SERVICE_ERROR", "Failed to connect to payment service", err)
+		return 0, nil, utils.NewInternalServerError("PAYMENT_SERVICE_ERROR", "Failed to connect to payment service", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", utils.NewInternalServerError("RESPONSE_READ_ERROR", "Failed to read payment response", err)
+		return 0, nil, utils.NewInternalServerError("RESPONSE_READ_ERROR", "Failed to read payment response", err)
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var paymentResp response.PaymentResponse
-		if err := json.Unmarshal(body, &paymentResp); err != nil {
-			return "", utils.NewInternalServerError("JSON_PARSE_ERROR", "Failed to parse payment response", err)
-		}
-
-		if paymentResp.Status != "SUCCESS" {
-			return "", utils.NewInternalServerError("PAYMENT_FAILED", "Payment was not successful", nil)
-		}
-
-		return paymentResp.TransactionID, nil
+	return resp.StatusCode, body, nil
+}
 
+func providerError(statusCode int, body []byte, operation string) error {
+	switch statusCode {
 	case http.StatusUnprocessableEntity:
 		var validationResp validationErrorResponse
 		if err := json.Unmarshal(body, &validationResp); err != nil {
-			return "", utils.NewInternalServerError("JSON_PARSE_ERROR", "Failed to parse validation errors", err)
+			return utils.NewInternalServerError("JSON_PARSE_ERROR", "Failed to parse validation errors", err)
 		}
 
-		errorMsg := "Payment validation failed: "
+		errorMsg := operation + " validation failed: "
 		for i, valErr := range validationResp.Errors {
 			if i > 0 {
 				errorMsg += ", "
@@ -107,16 +167,16 @@ func (s *paymentService) ProcessPayment(ctx context.Context, cardNumber, cvv, ex
 			errorMsg += fmt.Sprintf("%s (%s)", valErr.Message, valErr.Field)
 		}
 
-		return "", utils.NewBadRequestError("PAYMENT_VALIDATION_FAILED", errorMsg, nil)
+		return utils.NewBadRequestError("PAYMENT_VALIDATION_FAILED", errorMsg, nil)
 
 	case http.StatusForbidden:
-		return "", utils.NewInternalServerError("PAYMENT_AUTH_FAILED", "Payment service authentication failed", nil)
+		return utils.NewInternalServerError("PAYMENT_AUTH_FAILED", "Payment service authentication failed", nil)
 
 	default:
-		return "", utils.NewInternalServerError(
+		return utils.NewInternalServerError(
 			"PAYMENT_SERVICE_ERROR",
-			fmt.Sprintf("Payment service returned unexpected status code %d", resp.StatusCode),
-			fmt.Errorf("unexpected status: %d, body: %s", resp.StatusCode, string(body)),
+			fmt.Sprintf("Payment service returned unexpected status code %d", statusCode),
+			fmt.Errorf("unexpected status: %d, body: %s", statusCode, string(body)),
 		)
 	}
 }
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type BookingAuditRepository interface {
+	RecordEvent(ctx context.Context, event *models.BookingAuditEvent) error
+	GetEventsByBookingId(ctx context.Context, bookingId int) ([]models.BookingAuditEvent, error)
+}
+
+type bookingAuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBookingAuditRepository(db *pgxpool.Pool) BookingAuditRepository {
+	return &bookingAuditRepository{db: db}
+}
+
+func (r *bookingAuditRepository) RecordEvent(ctx context.Context, event *models.BookingAuditEvent) error {
+	query := `
+		INSERT INTO booking_audit_event (booking_id, event_type, actor, details)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, event.BookingID, event.EventType, event.Actor, event.Details).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		log.Error().Err(err).Int("bookingId", event.BookingID).Str("eventType", event.EventType).Msg("Failed to record booking audit event")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to record booking audit event", err)
+	}
+
+	return nil
+}
+
+func (r *bookingAuditRepository) GetEventsByBookingId(ctx context.Context, bookingId int) ([]models.BookingAuditEvent, error) {
+	query := `
+		SELECT id, booking_id, event_type, actor, details, created_at
+		FROM booking_audit_event
+		WHERE booking_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, bookingId)
+	if err != nil {
+		log.Error().Err(err).Int("bookingId", bookingId).Msg("Failed to query booking audit events")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve booking audit trail", err)
+	}
+	defer rows.Close()
+
+	events := []models.BookingAuditEvent{}
+	for rows.Next() {
+		var e models.BookingAuditEvent
+		if err := rows.Scan(&e.ID, &e.BookingID, &e.EventType, &e.Actor, &e.Details, &e.CreatedAt); err != nil {
+			log.Error().Err(err).Msg("Error scanning booking audit event row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read booking audit trail", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over booking audit event rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process booking audit trail", err)
+	}
+
+	return events, nil
+}
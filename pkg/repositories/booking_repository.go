@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
@@ -19,14 +21,16 @@ type BookingRepository interface {
 	UpdateBookingStatus(ctx context.Context, bookingID int, status string) error
 	UpdateBookingPaymentType(ctx context.Context, bookingID int, paymentType string) error
 	DeleteBookingsByIds(ctx context.Context, bookingIds []int) error
-	FindByCustomerUsername(ctx context.Context, username string) ([]*models.Booking, error)
-	FindLatestByCustomerUsername(ctx context.Context, username string) (*models.Booking, error)
-	FindConfirmedBookings(ctx context.Context) ([]*models.Booking, error)
+	FindCustomerBookings(ctx context.Context, filter models.CustomerBookingFilter) ([]models.CustomerBookingRecord, error)
+	SearchBookings(ctx context.Context, filter models.BookingSearchFilter) ([]models.BookingSearchRecord, error)
+	GetShowCheckInSummaries(ctx context.Context, filter models.ShowCheckInFilter) ([]models.ShowCheckInSummary, error)
 	MarkBookingsCheckedIn(ctx context.Context, bookingIDs []int) (int, error)
 	MarkBookingCheckedIn(ctx context.Context, bookingID int) (bool, error)
+	MarkBookingRefunded(ctx context.Context, bookingID int) (bool, error)
 	FindBookingsByIds(ctx context.Context, bookingIDs []int) ([]*models.Booking, error)
 	FindBookingsByStatus(ctx context.Context, statuses []string) ([]*models.Booking, error)
-	FindBookingsByStatusAndDate(ctx context.Context, statuses []string, month *int, year *int) ([]*models.Booking, error)
+	FindBookingsByStatusBetween(ctx context.Context, statuses []string, from, to time.Time) ([]*models.Booking, error)
+	StreamBookingsForExport(ctx context.Context, filter models.BookingExportFilter, fn func(row *models.BookingExportRow) error) error
 }
 
 type bookingRepository struct {
@@ -198,28 +202,75 @@ func (repo *bookingRepository) DeleteBookingsByIds(ctx context.Context, bookingI
 	return nil
 }
 
-func (repo *bookingRepository) FindByCustomerUsername(ctx context.Context, username string) ([]*models.Booking, error) {
-	const query = `
-        SELECT id, show_id, customer_id, customer_username, amount_paid, payment_type, status, booking_time
-        FROM booking
-        WHERE customer_username = $1
-        ORDER BY booking_time DESC
-    `
-	rows, err := repo.db.Query(ctx, query, username)
+// FindCustomerBookings returns one page of a customer's bookings with their show, slot and
+// seats in a single query, ordered by booking time and id, newest first.
+func (repo *bookingRepository) FindCustomerBookings(ctx context.Context, filter models.CustomerBookingFilter) ([]models.CustomerBookingRecord, error) {
+	query := `
+		SELECT
+			b.id, b.show_id, s.movie_id, s.date, sl.name, sl.start_time,
+			COALESCE((
+				SELECT array_agg(bsm.seat_number ORDER BY bsm.seat_number)
+				FROM booking_seat_mapping bsm
+				WHERE bsm.booking_id = b.id
+			), '{}'),
+			b.amount_paid, b.payment_type, b.status, b.booking_time
+		FROM booking b
+		JOIN show s ON s.id = b.show_id
+		JOIN slot sl ON sl.id = s.slot_id
+		WHERE b.customer_username = $1
+	`
+
+	args := []interface{}{filter.Username}
+	addCondition := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		query += " AND " + fmt.Sprintf(condition, placeholders...)
+	}
+
+	if len(filter.Statuses) > 0 {
+		addCondition("b.status = ANY($%d)", filter.Statuses)
+	}
+	if filter.Since != nil {
+		addCondition("s.date >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("s.date < $%d", *filter.Until)
+	}
+	if filter.Upcoming != nil {
+		if *filter.Upcoming {
+			query += " AND (s.date + sl.start_time) > LOCALTIMESTAMP"
+		} else {
+			query += " AND (s.date + sl.start_time) <= LOCALTIMESTAMP"
+		}
+	}
+	if filter.After != nil {
+		addCondition("(b.booking_time, b.id) < ($%d, $%d)", filter.After.Time, filter.After.ID)
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY b.booking_time DESC, b.id DESC LIMIT $%d", len(args))
+
+	rows, err := repo.db.Query(ctx, query, args...)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to query bookings for given username")
+		log.Error().Err(err).Str("username", filter.Username).Msg("Failed to query bookings for given username")
 		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve bookings", err)
 	}
 	defer rows.Close()
 
-	var bookings []*models.Booking
+	bookings := []models.CustomerBookingRecord{}
 	for rows.Next() {
-		var booking models.Booking
+		var booking models.CustomerBookingRecord
 		err := rows.Scan(
-			&booking.Id,
-			&booking.ShowId,
-			&booking.CustomerId,
-			&booking.CustomerUsername,
+			&booking.BookingID,
+			&booking.ShowID,
+			&booking.MovieID,
+			&booking.ShowDate,
+			&booking.SlotName,
+			&booking.StartTime,
+			&booking.SeatNumbers,
 			&booking.AmountPaid,
 			&booking.PaymentType,
 			&booking.Status,
@@ -229,7 +280,7 @@ func (repo *bookingRepository) FindByCustomerUsername(ctx context.Context, usern
 			log.Error().Err(err).Msg("Error scanning booking row")
 			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan booking data", err)
 		}
-		bookings = append(bookings, &booking)
+		bookings = append(bookings, booking)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -240,33 +291,119 @@ func (repo *bookingRepository) FindByCustomerUsername(ctx context.Context, usern
 	return bookings, nil
 }
 
-func (repo *bookingRepository) FindLatestByCustomerUsername(ctx context.Context, username string) (*models.Booking, error) {
-	const query = `
-        SELECT id, show_id, customer_id, customer_username, amount_paid, payment_type, status, booking_time
-        FROM booking
-        WHERE customer_username = $1
-        ORDER BY booking_time DESC
-        LIMIT 1
-    `
-	var booking models.Booking
-	err := repo.db.QueryRow(ctx, query, username).Scan(
-		&booking.Id,
-		&booking.ShowId,
-		&booking.CustomerId,
-		&booking.CustomerUsername,
-		&booking.AmountPaid,
-		&booking.PaymentType,
-		&booking.Status,
-		&booking.BookingTime,
-	)
-	if err == pgx.ErrNoRows {
-		return nil, nil
+// showStartingWithinCondition keeps shows that have not ended and start within the given
+// number of minutes. Slots ending before 04:00 end on the next day, as in check-in.
+const showStartingWithinCondition = `(s.date + sl.start_time) <= LOCALTIMESTAMP + make_interval(mins => $%d)
+	AND (s.date + sl.end_time + CASE WHEN sl.end_time < TIME '04:00' THEN INTERVAL '1 day' ELSE INTERVAL '0 days' END) > LOCALTIMESTAMP`
+
+// SearchBookings returns one page of bookings matching the console filters, with show, slot,
+// customer and seats resolved in a single query, ordered by booking time and id, newest first.
+func (repo *bookingRepository) SearchBookings(ctx context.Context, filter models.BookingSearchFilter) ([]models.BookingSearchRecord, error) {
+	query := `
+		SELECT
+			b.id, b.show_id, s.movie_id, s.date, sl.name, sl.start_time, sl.end_time, b.customer_username,
+			COALESCE(abc.name, c.name, ''), COALESCE(abc.number, c.number, ''),
+			COALESCE((
+				SELECT array_agg(bsm.seat_number ORDER BY bsm.seat_number)
+				FROM booking_seat_mapping bsm
+				WHERE bsm.booking_id = b.id
+			), '{}'),
+			b.amount_paid, b.payment_type, b.status, b.booking_time
+		FROM booking b
+		JOIN show s ON s.id = b.show_id
+		JOIN slot sl ON sl.id = s.slot_id
+		LEFT JOIN admin_booked_customer abc ON abc.id = b.customer_id
+		LEFT JOIN customertable c ON c.username = b.customer_username
+		WHERE TRUE
+	`
+
+	args := []interface{}{}
+	addCondition := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		query += " AND " + fmt.Sprintf(condition, placeholders...)
+	}
+
+	if filter.BookingID != nil {
+		addCondition("b.id = $%d", *filter.BookingID)
+	}
+	if filter.PhoneNumber != "" {
+		addCondition("COALESCE(abc.number, c.number) = $%d", filter.PhoneNumber)
+	}
+	if filter.Username != "" {
+		addCondition("b.customer_username = $%d", filter.Username)
+	}
+	if filter.Name != "" {
+		addCondition(`COALESCE(abc.name, c.name) ILIKE '%%' || $%d || '%%' ESCAPE '\'`, escapeLikePattern(filter.Name))
+	}
+	if filter.ShowID != nil {
+		addCondition("b.show_id = $%d", *filter.ShowID)
 	}
+	if len(filter.Statuses) > 0 {
+		addCondition("b.status = ANY($%d)", filter.Statuses)
+	}
+	if filter.PaymentType != "" {
+		addCondition("b.payment_type = $%d", filter.PaymentType)
+	}
+	if filter.Since != nil {
+		addCondition("s.date >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("s.date < $%d", *filter.Until)
+	}
+	if filter.StartingWithin != nil {
+		addCondition(showStartingWithinCondition, *filter.StartingWithin)
+	}
+	if filter.After != nil {
+		addCondition("(b.booking_time, b.id) < ($%d, $%d)", filter.After.Time, filter.After.ID)
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY b.booking_time DESC, b.id DESC LIMIT $%d", len(args))
+
+	rows, err := repo.db.Query(ctx, query, args...)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to query latest booking for given username")
-		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve latest booking", err)
+		log.Error().Err(err).Msg("Failed to search bookings")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to search bookings", err)
 	}
-	return &booking, nil
+	defer rows.Close()
+
+	bookings := []models.BookingSearchRecord{}
+	for rows.Next() {
+		var booking models.BookingSearchRecord
+		err := rows.Scan(
+			&booking.BookingID,
+			&booking.ShowID,
+			&booking.MovieID,
+			&booking.ShowDate,
+			&booking.SlotName,
+			&booking.StartTime,
+			&booking.EndTime,
+			&booking.CustomerUsername,
+			&booking.CustomerName,
+			&booking.PhoneNumber,
+			&booking.SeatNumbers,
+			&booking.AmountPaid,
+			&booking.PaymentType,
+			&booking.Status,
+			&booking.BookingTime,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning booking search row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan booking data", err)
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over booking search rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over bookings", err)
+	}
+
+	return bookings, nil
 }
 
 func (repo *bookingRepository) FindBookingsByStatus(ctx context.Context, statuses []string) ([]*models.Booking, error) {
@@ -320,49 +457,78 @@ func (repo *bookingRepository) FindBookingsByStatus(ctx context.Context, statuse
 	return bookings, nil
 }
 
-func (repo *bookingRepository) FindConfirmedBookings(ctx context.Context) ([]*models.Booking, error) {
-	const query = `
-		SELECT 
-			id, date, show_id, customer_id, customer_username, no_of_seats, amount_paid, status, booking_time, payment_type
-		FROM booking
-		WHERE status = 'Confirmed'
-		ORDER BY booking_time DESC
+// GetShowCheckInSummaries counts paid bookings and seats per show in scope, and how many of
+// them have checked in, ordered by show start.
+func (repo *bookingRepository) GetShowCheckInSummaries(ctx context.Context, filter models.ShowCheckInFilter) ([]models.ShowCheckInSummary, error) {
+	query := `
+		SELECT
+			s.id, s.movie_id, s.date, sl.name, sl.start_time, sl.end_time,
+			COUNT(b.id),
+			COALESCE(SUM(b.no_of_seats), 0),
+			COUNT(b.id) FILTER (WHERE b.status = 'CheckedIn'),
+			COALESCE(SUM(b.no_of_seats) FILTER (WHERE b.status = 'CheckedIn'), 0)
+		FROM show s
+		JOIN slot sl ON sl.id = s.slot_id
+		LEFT JOIN booking b ON b.show_id = s.id AND b.status IN ('Confirmed', 'CheckedIn')
+		WHERE TRUE
 	`
-	rows, err := repo.db.Query(ctx, query)
+
+	args := []interface{}{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += " AND " + fmt.Sprintf(condition, len(args))
+	}
+
+	if filter.ShowID != nil {
+		addCondition("s.id = $%d", *filter.ShowID)
+	}
+	if filter.Since != nil {
+		addCondition("s.date >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("s.date < $%d", *filter.Until)
+	}
+	if filter.StartingWithin != nil {
+		addCondition(showStartingWithinCondition, *filter.StartingWithin)
+	}
+
+	query += " GROUP BY s.id, sl.id ORDER BY s.date, sl.start_time, s.id"
+
+	rows, err := repo.db.Query(ctx, query, args...)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch confirmed bookings")
-		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve confirmed bookings", err)
+		log.Error().Err(err).Msg("Failed to query check-in summaries")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve check-in summary", err)
 	}
 	defer rows.Close()
 
-	var bookings []*models.Booking
+	summaries := []models.ShowCheckInSummary{}
 	for rows.Next() {
-		var booking models.Booking
+		var summary models.ShowCheckInSummary
 		err := rows.Scan(
-			&booking.Id,
-			&booking.Date,
-			&booking.ShowId,
-			&booking.CustomerId,
-			&booking.CustomerUsername,
-			&booking.NoOfSeats,
-			&booking.AmountPaid,
-			&booking.Status,
-			&booking.BookingTime,
-			&booking.PaymentType,
+			&summary.ShowID,
+			&summary.MovieID,
+			&summary.ShowDate,
+			&summary.SlotName,
+			&summary.StartTime,
+			&summary.EndTime,
+			&summary.ExpectedBookings,
+			&summary.ExpectedSeats,
+			&summary.AdmittedBookings,
+			&summary.AdmittedSeats,
 		)
 		if err != nil {
-			log.Error().Err(err).Msg("Error scanning confirmed booking row")
-			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan confirmed booking data", err)
+			log.Error().Err(err).Msg("Error scanning check-in summary row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan check-in summary", err)
 		}
-		bookings = append(bookings, &booking)
+		summaries = append(summaries, summary)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Error().Err(err).Msg("Error iterating over confirmed bookings")
-		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over confirmed bookings", err)
+		log.Error().Err(err).Msg("Error iterating over check-in summary rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over check-in summary", err)
 	}
 
-	return bookings, nil
+	return summaries, nil
 }
 
 func (repo *bookingRepository) MarkBookingsCheckedIn(ctx context.Context, bookingIDs []int) (int, error) {
@@ -396,6 +562,20 @@ func (repo *bookingRepository) MarkBookingCheckedIn(ctx context.Context, booking
 	return cmdTag.RowsAffected() == 1, nil
 }
 
+func (repo *bookingRepository) MarkBookingRefunded(ctx context.Context, bookingID int) (bool, error) {
+	query := `
+		UPDATE booking
+		SET status = 'Refunded'
+		WHERE id = $1 AND status = 'Confirmed'
+	`
+	cmdTag, err := repo.db.Exec(ctx, query, bookingID)
+	if err != nil {
+		log.Error().Err(err).Int("bookingID", bookingID).Msg("Failed to update booking to Refunded")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to update booking status", err)
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
+
 func (repo *bookingRepository) FindBookingsByIds(ctx context.Context, bookingIDs []int) ([]*models.Booking, error) {
 	if len(bookingIDs) == 0 {
 		return []*models.Booking{}, nil
@@ -440,44 +620,25 @@ func (repo *bookingRepository) FindBookingsByIds(ctx context.Context, bookingIDs
 	return bookings, nil
 }
 
-func (repo *bookingRepository) FindBookingsByStatusAndDate(ctx context.Context, statuses []string, month *int, year *int) ([]*models.Booking, error) {
-	if len(statuses) == 0 {
-		return []*models.Booking{}, nil
-	}
-
+// FindBookingsByStatusBetween returns bookings made in [from, to), newest first.
+func (repo *bookingRepository) FindBookingsByStatusBetween(ctx context.Context, statuses []string, from, to time.Time) ([]*models.Booking, error) {
 	query := `
-        SELECT 
-            id, date, show_id, customer_id, customer_username, 
-            no_of_seats, amount_paid, status, booking_time, payment_type
-        FROM booking
-        WHERE status = ANY($1)
-    `
-
-	args := []interface{}{statuses}
-	argCount := 2
-
-	if month != nil {
-		query += fmt.Sprintf(" AND EXTRACT(MONTH FROM booking_time) = $%d", argCount)
-		args = append(args, *month)
-		argCount++
-	}
-
-	if year != nil {
-		query += fmt.Sprintf(" AND EXTRACT(YEAR FROM booking_time) = $%d", argCount)
-		args = append(args, *year)
-	}
-
-	query += " ORDER BY booking_time DESC"
-
-	rows, err := repo.db.Query(ctx, query, args...)
+		SELECT
+			id, date, show_id, customer_id, customer_username,
+			no_of_seats, amount_paid, status, booking_time, payment_type
+		FROM booking
+		WHERE status = ANY($1) AND booking_time >= $2 AND booking_time < $3
+		ORDER BY booking_time DESC
+	`
 
+	rows, err := repo.db.Query(ctx, query, statuses, from, to)
 	if err != nil {
-		log.Error().Err(err).Strs("statuses", statuses).Msg("Failed to query bookings by status")
+		log.Error().Err(err).Strs("statuses", statuses).Msg("Failed to query bookings by status and period")
 		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve bookings", err)
 	}
 	defer rows.Close()
 
-	var bookings []*models.Booking
+	bookings := []*models.Booking{}
 	for rows.Next() {
 		var booking models.Booking
 		err := rows.Scan(
@@ -506,3 +667,109 @@ func (repo *bookingRepository) FindBookingsByStatusAndDate(ctx context.Context,
 
 	return bookings, nil
 }
+
+// StreamBookingsForExport runs a single joined query and hands each row to fn as it is read
+// from the connection, so large exports are never held in memory. Returning an error from fn
+// stops the export.
+func (repo *bookingRepository) StreamBookingsForExport(ctx context.Context, filter models.BookingExportFilter, fn func(row *models.BookingExportRow) error) error {
+	query := `
+		SELECT
+			b.id, COALESCE(b.show_id, 0), b.date, COALESCE(s.movie_id, ''), COALESCE(sl.name, ''),
+			COALESCE(abc.name, c.name, ''), COALESCE(abc.number, c.number, ''), COALESCE(b.customer_username, ''),
+			b.no_of_seats, b.amount_paid, b.payment_type, b.booking_time, b.status::text
+		FROM booking b
+		LEFT JOIN show s ON s.id = b.show_id
+		LEFT JOIN slot sl ON sl.id = s.slot_id
+		LEFT JOIN admin_booked_customer abc ON abc.id = b.customer_id
+		LEFT JOIN customertable c ON c.username = b.customer_username
+		WHERE b.status = ANY($1)
+	`
+
+	args := []interface{}{filter.Statuses}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.Since != nil {
+		addCondition("b.booking_time >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("b.booking_time < $%d", *filter.Until)
+	}
+	if filter.Month != nil {
+		addCondition("EXTRACT(MONTH FROM b.booking_time) = $%d", *filter.Month)
+	}
+	if filter.Year != nil {
+		addCondition("EXTRACT(YEAR FROM b.booking_time) = $%d", *filter.Year)
+	}
+	if filter.MovieID != nil {
+		addCondition("s.movie_id = $%d", *filter.MovieID)
+	}
+	if filter.SlotID != nil {
+		addCondition("s.slot_id = $%d", *filter.SlotID)
+	}
+	if filter.PaymentType != nil {
+		addCondition("b.payment_type = $%d", *filter.PaymentType)
+	}
+
+	query += " ORDER BY b.booking_time DESC, b.id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := repo.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Strs("statuses", filter.Statuses).Msg("Failed to query bookings for export")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve bookings", err)
+	}
+	defer rows.Close()
+
+	var row models.BookingExportRow
+	for rows.Next() {
+		err := rows.Scan(
+			&row.BookingID,
+			&row.ShowID,
+			&row.ShowDate,
+			&row.MovieID,
+			&row.SlotName,
+			&row.CustomerName,
+			&row.PhoneNumber,
+			&row.CustomerUsername,
+			&row.NoOfSeats,
+			&row.AmountPaid,
+			&row.PaymentType,
+			&row.BookingTime,
+			&row.Status,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning booking export row")
+			return utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan booking data", err)
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over booking export rows")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over bookings", err)
+	}
+
+	return nil
+}
+
+// escapeLikePattern escapes the LIKE wildcards in user input so it only matches literally.
+// Queries using it must declare ESCAPE '\'.
+func escapeLikePattern(value string) string {
+	return likePatternEscaper.Replace(value)
+}
+
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
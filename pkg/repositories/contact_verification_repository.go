@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type ContactVerificationRepository interface {
+	CreateCode(ctx context.Context, code *models.ContactVerificationCode) error
+	FindLatestCode(ctx context.Context, username, channel string) (*models.ContactVerificationCode, error)
+	// ReserveAttempt counts an attempt against an unused code and returns its hash, or nil if
+	// the code was used or has no attempts left. Concurrent confirms cannot exceed maxAttempts.
+	ReserveAttempt(ctx context.Context, codeID int64, maxAttempts int) (*string, error)
+	ConfirmCode(ctx context.Context, code *models.ContactVerificationCode) (time.Time, error)
+}
+
+type contactVerificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewContactVerificationRepository(db *pgxpool.Pool) ContactVerificationRepository {
+	return &contactVerificationRepository{db: db}
+}
+
+// CreateCode stores a new code and retires any earlier unused code for the same channel, so
+// only the most recently sent code can be confirmed.
+func (r *contactVerificationRepository) CreateCode(ctx context.Context, code *models.ContactVerificationCode) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin verification code transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE contact_verification_code
+		SET consumed_at = CURRENT_TIMESTAMP
+		WHERE username = $1 AND channel = $2 AND consumed_at IS NULL
+	`, code.Username, code.Channel)
+	if err != nil {
+		log.Error().Err(err).Str("username", code.Username).Msg("Failed to retire previous verification codes")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to store verification code", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO contact_verification_code (username, channel, destination, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, attempts, created_at
+	`, code.Username, code.Channel, code.Destination, code.CodeHash, code.ExpiresAt).Scan(&code.ID, &code.Attempts, &code.CreatedAt)
+	if err != nil {
+		log.Error().Err(err).Str("username", code.Username).Msg("Failed to store verification code")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to store verification code", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", code.Username).Msg("Failed to commit verification code")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to store verification code", err)
+	}
+
+	return nil
+}
+
+// FindLatestCode returns the most recently issued code for the channel, used or not, or nil
+// if none was ever sent.
+func (r *contactVerificationRepository) FindLatestCode(ctx context.Context, username, channel string) (*models.ContactVerificationCode, error) {
+	query := `
+		SELECT id, username, channel, destination, code_hash, attempts, created_at, expires_at, consumed_at
+		FROM contact_verification_code
+		WHERE username = $1 AND channel = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	var code models.ContactVerificationCode
+	err := r.db.QueryRow(ctx, query, username, channel).Scan(
+		&code.ID,
+		&code.Username,
+		&code.Channel,
+		&code.Destination,
+		&code.CodeHash,
+		&code.Attempts,
+		&code.CreatedAt,
+		&code.ExpiresAt,
+		&code.ConsumedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Str("username", username).Msg("Failed to fetch verification code")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching verification code", err)
+	}
+
+	return &code, nil
+}
+
+func (r *contactVerificationRepository) ReserveAttempt(ctx context.Context, codeID int64, maxAttempts int) (*string, error) {
+	query := `
+		UPDATE contact_verification_code
+		SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 AND consumed_at IS NULL
+		RETURNING code_hash
+	`
+
+	var codeHash string
+	err := r.db.QueryRow(ctx, query, codeID, maxAttempts).Scan(&codeHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Int64("codeId", codeID).Msg("Failed to record verification attempt")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to record verification attempt", err)
+	}
+	return &codeHash, nil
+}
+
+// ConfirmCode marks the code used and, in the same transaction, records the contact it was sent
+// to as verified. If that contact was pending it replaces the current one. It fails if the code
+// was already used or the contact has changed since the code was sent.
+func (r *contactVerificationRepository) ConfirmCode(ctx context.Context, code *models.ContactVerificationCode) (time.Time, error) {
+	var column, verifiedColumn, pendingColumn string
+	switch code.Channel {
+	case models.VerificationChannelEmail:
+		column, verifiedColumn, pendingColumn = "email", "email_verified_at", "pending_email"
+	case models.VerificationChannelPhone:
+		column, verifiedColumn, pendingColumn = "number", "number_verified_at", "pending_number"
+	default:
+		return time.Time{}, utils.NewBadRequestError("INVALID_CHANNEL", fmt.Sprintf("Unknown verification channel %q", code.Channel), nil)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin verification transaction")
+		return time.Time{}, utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var verifiedAt time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE contact_verification_code
+		SET consumed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND consumed_at IS NULL
+		RETURNING consumed_at
+	`, code.ID).Scan(&verifiedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return time.Time{}, utils.NewBadRequestError("VERIFICATION_CODE_EXPIRED", "The verification code has expired or was already used, please request a new one", nil)
+		}
+		log.Error().Err(err).Int64("codeId", code.ID).Msg("Failed to consume verification code")
+		return time.Time{}, utils.NewInternalServerError("DATABASE_ERROR", "Failed to confirm verification code", err)
+	}
+
+	// column, verifiedColumn and pendingColumn come from the switch above, never from input.
+	query := fmt.Sprintf(`
+		UPDATE customertable
+		SET %[1]s = $2, %[2]s = $3, %[3]s = NULL
+		WHERE username = $1 AND (%[1]s = $2 OR %[3]s = $2)
+	`, column, verifiedColumn, pendingColumn)
+	tag, err := tx.Exec(ctx, query, code.Username, code.Destination, verifiedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			if code.Channel == models.VerificationChannelPhone {
+				return time.Time{}, utils.NewBadRequestError("NUMBER_EXISTS", "Phone Number already exists", nil)
+			}
+			return time.Time{}, utils.NewBadRequestError("EMAIL_EXISTS", "Email already exists", nil)
+		}
+		log.Error().Err(err).Str("username", code.Username).Str("channel", code.Channel).Msg("Failed to mark contact verified")
+		return time.Time{}, utils.NewInternalServerError("DATABASE_ERROR", "Failed to confirm verification code", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return time.Time{}, utils.NewBadRequestError("CONTACT_CHANGED", "The contact this code was sent to is no longer on your account, please request a new code", nil)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", code.Username).Msg("Failed to commit contact verification")
+		return time.Time{}, utils.NewInternalServerError("DATABASE_ERROR", "Failed to confirm verification code", err)
+	}
+
+	return verifiedAt, nil
+}
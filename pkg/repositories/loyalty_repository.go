@@ -0,0 +1,318 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type LoyaltyRepository interface {
+	GetAccount(ctx context.Context, username string) (*models.LoyaltyAccount, error)
+	GetTransactions(ctx context.Context, username string, limit int) ([]models.LoyaltyTransaction, error)
+	EarnPoints(ctx context.Context, username string, bookingId int64, points int, description string) (bool, error)
+	RedeemPoints(ctx context.Context, username string, bookingId int64, points int, description string) error
+	// CancelRedemption returns the points redeemed for a booking whose payment could not be
+	// completed and removes the redemption, so the customer can pay for it again.
+	CancelRedemption(ctx context.Context, bookingId int64) error
+	ReverseBookingPoints(ctx context.Context, bookingId int64, reason, createdBy string) ([]models.LoyaltyTransaction, error)
+}
+
+type loyaltyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoyaltyRepository(db *pgxpool.Pool) LoyaltyRepository {
+	return &loyaltyRepository{db: db}
+}
+
+func (r *loyaltyRepository) GetAccount(ctx context.Context, username string) (*models.LoyaltyAccount, error) {
+	query := `
+		SELECT username, points_balance, lifetime_points, updated_at
+		FROM loyalty_account
+		WHERE username = $1
+	`
+
+	var account models.LoyaltyAccount
+	err := r.db.QueryRow(ctx, query, username).Scan(
+		&account.Username,
+		&account.PointsBalance,
+		&account.LifetimePoints,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Str("username", username).Msg("Failed to fetch loyalty account")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve loyalty account", err)
+	}
+
+	return &account, nil
+}
+
+func (r *loyaltyRepository) GetTransactions(ctx context.Context, username string, limit int) ([]models.LoyaltyTransaction, error) {
+	query := `
+		SELECT id, username, booking_id, transaction_type, points, description, created_by, created_at
+		FROM loyalty_transaction
+		WHERE username = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, username, limit)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to query loyalty transactions")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve loyalty history", err)
+	}
+	defer rows.Close()
+
+	var transactions []models.LoyaltyTransaction
+	for rows.Next() {
+		var t models.LoyaltyTransaction
+		if err := rows.Scan(&t.ID, &t.Username, &t.BookingID, &t.TransactionType, &t.Points, &t.Description, &t.CreatedBy, &t.CreatedAt); err != nil {
+			log.Error().Err(err).Msg("Error scanning loyalty transaction row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read loyalty history", err)
+		}
+		transactions = append(transactions, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over loyalty transaction rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process loyalty history", err)
+	}
+
+	return transactions, nil
+}
+
+// EarnPoints credits points for a booking. It returns false when the booking has
+// already earned points, so repeated calls for the same booking are harmless.
+func (r *loyaltyRepository) EarnPoints(ctx context.Context, username string, bookingId int64, points int, description string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin loyalty transaction")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `INSERT INTO loyalty_account (username) VALUES ($1) ON CONFLICT (username) DO NOTHING`, username); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to create loyalty account")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to create loyalty account", err)
+	}
+
+	inserted, err := insertLoyaltyTransaction(ctx, tx, username, &bookingId, "EARN", points, description, nil)
+	if err != nil {
+		return false, err
+	}
+	if !inserted {
+		return false, nil
+	}
+
+	query := `
+		UPDATE loyalty_account
+		SET points_balance = points_balance + $1, lifetime_points = lifetime_points + $1, updated_at = CURRENT_TIMESTAMP
+		WHERE username = $2
+	`
+	if _, err := tx.Exec(ctx, query, points, username); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to credit loyalty points")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to credit loyalty points", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Int64("bookingId", bookingId).Msg("Failed to commit loyalty accrual")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit loyalty points", err)
+	}
+
+	return true, nil
+}
+
+func (r *loyaltyRepository) RedeemPoints(ctx context.Context, username string, bookingId int64, points int, description string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin loyalty transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE loyalty_account
+		SET points_balance = points_balance - $1, updated_at = CURRENT_TIMESTAMP
+		WHERE username = $2 AND points_balance >= $1
+	`
+	result, err := tx.Exec(ctx, query, points, username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to redeem loyalty points")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to redeem loyalty points", err)
+	}
+	if result.RowsAffected() == 0 {
+		return utils.NewBadRequestError("INSUFFICIENT_POINTS", "Not enough loyalty points to pay for this booking", nil)
+	}
+
+	inserted, err := insertLoyaltyTransaction(ctx, tx, username, &bookingId, "REDEEM", points, description, nil)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		return utils.NewBadRequestError("DUPLICATE_TRANSACTION", "Points have already been redeemed for this booking", nil)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Int64("bookingId", bookingId).Msg("Failed to commit loyalty redemption")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit loyalty redemption", err)
+	}
+
+	return nil
+}
+
+func (r *loyaltyRepository) CancelRedemption(ctx context.Context, bookingId int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin loyalty transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var username string
+	var points int
+	err = tx.QueryRow(ctx, `
+		DELETE FROM loyalty_transaction
+		WHERE booking_id = $1 AND transaction_type = 'REDEEM'
+		RETURNING username, points
+	`, bookingId).Scan(&username, &points)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		log.Error().Err(err).Int64("bookingId", bookingId).Msg("Failed to remove loyalty redemption")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to cancel loyalty redemption", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE loyalty_account
+		SET points_balance = points_balance + $1, updated_at = CURRENT_TIMESTAMP
+		WHERE username = $2
+	`, points, username)
+	if err != nil {
+		log.Error().Err(err).Int64("bookingId", bookingId).Str("username", username).Msg("Failed to return redeemed loyalty points")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to cancel loyalty redemption", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Int64("bookingId", bookingId).Msg("Failed to commit loyalty redemption cancellation")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to cancel loyalty redemption", err)
+	}
+
+	return nil
+}
+
+// ReverseBookingPoints undoes the loyalty effects of a refunded booking: earned points are
+// taken back in full, leaving a negative balance if the customer already spent them, and
+// redeemed points are returned. It returns the reversal rows written, which is empty when
+// the booking was already reversed.
+func (r *loyaltyRepository) ReverseBookingPoints(ctx context.Context, bookingId int64, reason, createdBy string) ([]models.LoyaltyTransaction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin loyalty transaction")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT lt.username, lt.transaction_type, lt.points
+		FROM loyalty_transaction lt
+		JOIN loyalty_account la ON la.username = lt.username
+		WHERE lt.booking_id = $1 AND lt.transaction_type IN ('EARN', 'REDEEM')
+		FOR UPDATE OF la
+	`
+	rows, err := tx.Query(ctx, query, bookingId)
+	if err != nil {
+		log.Error().Err(err).Int64("bookingId", bookingId).Msg("Failed to query booking loyalty transactions")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve booking loyalty history", err)
+	}
+
+	var originals []models.LoyaltyTransaction
+	for rows.Next() {
+		var t models.LoyaltyTransaction
+		if err := rows.Scan(&t.Username, &t.TransactionType, &t.Points); err != nil {
+			rows.Close()
+			log.Error().Err(err).Msg("Error scanning loyalty transaction row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read booking loyalty history", err)
+		}
+		originals = append(originals, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over loyalty transaction rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process booking loyalty history", err)
+	}
+
+	var reversals []models.LoyaltyTransaction
+	for _, original := range originals {
+		reversal := models.LoyaltyTransaction{
+			Username:    original.Username,
+			BookingID:   &bookingId,
+			Points:      original.Points,
+			Description: reason,
+			CreatedBy:   &createdBy,
+		}
+
+		var update string
+		if original.TransactionType == "EARN" {
+			reversal.TransactionType = "EARN_REVERSAL"
+			update = `
+				UPDATE loyalty_account
+				SET points_balance = points_balance - $1,
+				    lifetime_points = lifetime_points - $1,
+				    updated_at = CURRENT_TIMESTAMP
+				WHERE username = $2
+			`
+		} else {
+			reversal.TransactionType = "REDEEM_REVERSAL"
+			update = `
+				UPDATE loyalty_account
+				SET points_balance = points_balance + $1, updated_at = CURRENT_TIMESTAMP
+				WHERE username = $2
+			`
+		}
+
+		inserted, err := insertLoyaltyTransaction(ctx, tx, reversal.Username, reversal.BookingID, reversal.TransactionType, reversal.Points, reason, &createdBy)
+		if err != nil {
+			return nil, err
+		}
+		if !inserted {
+			continue
+		}
+
+		if _, err := tx.Exec(ctx, update, reversal.Points, reversal.Username); err != nil {
+			log.Error().Err(err).Int64("bookingId", bookingId).Str("type", reversal.TransactionType).Msg("Failed to reverse loyalty points")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to reverse loyalty points", err)
+		}
+		reversals = append(reversals, reversal)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Int64("bookingId", bookingId).Msg("Failed to commit loyalty reversal")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit loyalty reversal", err)
+	}
+
+	return reversals, nil
+}
+
+// insertLoyaltyTransaction returns false when the booking already has a row of this type.
+func insertLoyaltyTransaction(ctx context.Context, tx pgx.Tx, username string, bookingId *int64, transactionType string, points int, description string, createdBy *string) (bool, error) {
+	query := `
+		INSERT INTO loyalty_transaction (username, booking_id, transaction_type, points, description, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (booking_id, transaction_type) WHERE booking_id IS NOT NULL DO NOTHING
+	`
+	result, err := tx.Exec(ctx, query, username, bookingId, transactionType, points, description, createdBy)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("type", transactionType).Msg("Failed to record loyalty transaction")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to record loyalty transaction", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
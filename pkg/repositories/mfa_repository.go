@@ -0,0 +1,338 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type MFARepository interface {
+	FindByUsername(ctx context.Context, username string) (*models.UserMFA, error)
+	SavePendingSecret(ctx context.Context, username, secret string) error
+	UpdateSecret(ctx context.Context, username, secret string) error
+	Enable(ctx context.Context, username string, step int64, recoveryCodeHashes []string) error
+	Delete(ctx context.Context, username string) error
+	RecordCodeUsed(ctx context.Context, username string, step int64) (bool, error)
+	ReserveAttempt(ctx context.Context, username string, maxAttempts int, lockout time.Duration) (bool, error)
+	ResetFailedAttempts(ctx context.Context, username string) error
+	GetUnusedRecoveryCodes(ctx context.Context, username string) ([]models.MFARecoveryCode, error)
+	UseRecoveryCode(ctx context.Context, codeID int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, username string, recoveryCodeHashes []string) error
+	IsRequiredForRole(ctx context.Context, role string) (bool, error)
+	GetRolePolicies(ctx context.Context) ([]models.MFARolePolicy, error)
+	UpdateRolePolicy(ctx context.Context, role string, required bool, updatedBy string) (*models.MFARolePolicy, error)
+}
+
+type mfaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMFARepository(db *pgxpool.Pool) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) FindByUsername(ctx context.Context, username string) (*models.UserMFA, error) {
+	query := `
+		SELECT username, secret, enabled_at, last_used_step, failed_attempts, locked_until, created_at
+		FROM user_mfa
+		WHERE username = $1
+	`
+
+	var mfa models.UserMFA
+	err := r.db.QueryRow(ctx, query, username).Scan(
+		&mfa.Username,
+		&mfa.Secret,
+		&mfa.EnabledAt,
+		&mfa.LastUsedStep,
+		&mfa.FailedAttempts,
+		&mfa.LockedUntil,
+		&mfa.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Str("username", username).Msg("Failed to fetch MFA enrollment")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching MFA enrollment", err)
+	}
+
+	return &mfa, nil
+}
+
+// SavePendingSecret starts or restarts an enrollment. It never replaces the secret of an
+// enrollment that is already enabled.
+func (r *mfaRepository) SavePendingSecret(ctx context.Context, username, secret string) error {
+	query := `
+		INSERT INTO user_mfa (username, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (username) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = NULL, failed_attempts = 0, locked_until = NULL, created_at = CURRENT_TIMESTAMP
+		WHERE user_mfa.enabled_at IS NULL
+	`
+
+	tag, err := r.db.Exec(ctx, query, username, secret)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to save MFA secret")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start MFA enrollment", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.NewBadRequestError("MFA_ALREADY_ENABLED", "MFA is already enabled for this account", nil)
+	}
+
+	return nil
+}
+
+// UpdateSecret rewrites the stored secret without changing it, to re-encrypt a secret stored
+// before encryption was introduced.
+func (r *mfaRepository) UpdateSecret(ctx context.Context, username, secret string) error {
+	_, err := r.db.Exec(ctx, "UPDATE user_mfa SET secret = $2 WHERE username = $1", username, secret)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to update MFA secret")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update MFA secret", err)
+	}
+	return nil
+}
+
+// Enable completes an enrollment and stores its recovery codes in one transaction.
+func (r *mfaRepository) Enable(ctx context.Context, username string, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin MFA enrollment transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_mfa
+		SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2, failed_attempts = 0, locked_until = NULL
+		WHERE username = $1 AND enabled_at IS NULL
+	`, username, step)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to enable MFA")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to enable MFA", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.NewBadRequestError("MFA_ALREADY_ENABLED", "MFA is already enabled for this account", nil)
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, username, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to commit MFA enrollment")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to enable MFA", err)
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, username string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin MFA removal transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, query := range []string{
+		"DELETE FROM mfa_recovery_code WHERE username = $1",
+		"DELETE FROM user_mfa WHERE username = $1",
+	} {
+		if _, err := tx.Exec(ctx, query, username); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Failed to remove MFA")
+			return utils.NewInternalServerError("DATABASE_ERROR", "Failed to disable MFA", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to commit MFA removal")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to disable MFA", err)
+	}
+
+	return nil
+}
+
+// RecordCodeUsed stores the time step of an accepted code and clears failed attempts. It
+// returns false if a code from the same or a later step was already accepted, so a code
+// cannot be replayed.
+func (r *mfaRepository) RecordCodeUsed(ctx context.Context, username string, step int64) (bool, error) {
+	query := `
+		UPDATE user_mfa
+		SET last_used_step = $2, failed_attempts = 0, locked_until = NULL
+		WHERE username = $1 AND (last_used_step IS NULL OR last_used_step < $2)
+	`
+
+	tag, err := r.db.Exec(ctx, query, username, step)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to record MFA code use")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to verify MFA code", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReserveAttempt counts a verification attempt before the code is checked, and returns false
+// if verification is locked. Reaching maxAttempts locks verification for the lockout duration
+// and starts the count again. A correct code clears the count, so only wrong codes add up, and
+// concurrent requests cannot make more than maxAttempts guesses between lockouts.
+func (r *mfaRepository) ReserveAttempt(ctx context.Context, username string, maxAttempts int, lockout time.Duration) (bool, error) {
+	query := `
+		UPDATE user_mfa
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE NULL END
+		WHERE username = $1 AND enabled_at IS NOT NULL AND (locked_until IS NULL OR locked_until <= $4)
+	`
+
+	now := time.Now()
+	tag, err := r.db.Exec(ctx, query, username, maxAttempts, now.Add(lockout), now)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to record MFA attempt")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to verify MFA code", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepository) ResetFailedAttempts(ctx context.Context, username string) error {
+	_, err := r.db.Exec(ctx, "UPDATE user_mfa SET failed_attempts = 0, locked_until = NULL WHERE username = $1", username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to reset MFA failures")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to verify MFA code", err)
+	}
+	return nil
+}
+
+func (r *mfaRepository) GetUnusedRecoveryCodes(ctx context.Context, username string) ([]models.MFARecoveryCode, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, username, code_hash FROM mfa_recovery_code WHERE username = $1 AND used_at IS NULL", username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to fetch recovery codes")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching recovery codes", err)
+	}
+	defer rows.Close()
+
+	var codes []models.MFARecoveryCode
+	for rows.Next() {
+		var code models.MFARecoveryCode
+		if err := rows.Scan(&code.ID, &code.Username, &code.CodeHash); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Failed to scan recovery code")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching recovery codes", err)
+		}
+		codes = append(codes, code)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to iterate recovery codes")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching recovery codes", err)
+	}
+
+	return codes, nil
+}
+
+// UseRecoveryCode marks a recovery code used. It returns false if another request used it first.
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, codeID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, "UPDATE mfa_recovery_code SET used_at = CURRENT_TIMESTAMP WHERE id = $1 AND used_at IS NULL", codeID)
+	if err != nil {
+		log.Error().Err(err).Int64("codeId", codeID).Msg("Failed to use recovery code")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to use recovery code", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, username string, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin recovery code transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, username, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to commit recovery codes")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to save recovery codes", err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, username string, recoveryCodeHashes []string) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM mfa_recovery_code WHERE username = $1", username); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to delete old recovery codes")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to save recovery codes", err)
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.Exec(ctx, "INSERT INTO mfa_recovery_code (username, code_hash) VALUES ($1, $2)", username, hash); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Failed to insert recovery code")
+			return utils.NewInternalServerError("DATABASE_ERROR", "Failed to save recovery codes", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) IsRequiredForRole(ctx context.Context, role string) (bool, error) {
+	var required bool
+	err := r.db.QueryRow(ctx, "SELECT required FROM mfa_role_policy WHERE role::text = $1", role).Scan(&required)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		log.Error().Err(err).Str("role", role).Msg("Failed to fetch MFA policy")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching MFA policy", err)
+	}
+	return required, nil
+}
+
+func (r *mfaRepository) GetRolePolicies(ctx context.Context) ([]models.MFARolePolicy, error) {
+	rows, err := r.db.Query(ctx, "SELECT role::text, required, updated_by, updated_at FROM mfa_role_policy ORDER BY role")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch MFA policies")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching MFA policies", err)
+	}
+	defer rows.Close()
+
+	var policies []models.MFARolePolicy
+	for rows.Next() {
+		var policy models.MFARolePolicy
+		if err := rows.Scan(&policy.Role, &policy.Required, &policy.UpdatedBy, &policy.UpdatedAt); err != nil {
+			log.Error().Err(err).Msg("Failed to scan MFA policy")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching MFA policies", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to iterate MFA policies")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching MFA policies", err)
+	}
+
+	return policies, nil
+}
+
+func (r *mfaRepository) UpdateRolePolicy(ctx context.Context, role string, required bool, updatedBy string) (*models.MFARolePolicy, error) {
+	query := `
+		INSERT INTO mfa_role_policy (role, required, updated_by, updated_at)
+		VALUES ($1::user_role_enum, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (role) DO UPDATE
+		SET required = EXCLUDED.required, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+		RETURNING role::text, required, updated_by, updated_at
+	`
+
+	var policy models.MFARolePolicy
+	err := r.db.QueryRow(ctx, query, role, required, updatedBy).Scan(&policy.Role, &policy.Required, &policy.UpdatedBy, &policy.UpdatedAt)
+	if err != nil {
+		log.Error().Err(err).Str("role", role).Msg("Failed to update MFA policy")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", fmt.Sprintf("Failed to update MFA policy for %s", role), err)
+	}
+
+	return &policy, nil
+}
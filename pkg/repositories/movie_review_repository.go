@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieReviewRepository interface {
+	// HasCheckedInBooking reports whether the customer checked in to any show of the movie.
+	HasCheckedInBooking(ctx context.Context, username, movieID string) (bool, error)
+	Create(ctx context.Context, review *models.MovieReview) error
+	// Update changes the rating and text of the customer's review of review.MovieId and
+	// reports whether such a review exists.
+	Update(ctx context.Context, review *models.MovieReview) (bool, error)
+	Find(ctx context.Context, filter models.MovieReviewFilter) ([]models.MovieReview, error)
+	// SetHidden hides or unhides a review and returns it, or nil if it does not exist.
+	SetHidden(ctx context.Context, id int64, hidden bool, moderator string, reason *string) (*models.MovieReview, error)
+	// GetRatingSummaries aggregates visible reviews per movie. Movies without visible
+	// reviews are left out of the map.
+	GetRatingSummaries(ctx context.Context, movieIDs []string) (map[string]models.MovieRatingSummary, error)
+}
+
+type movieReviewRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMovieReviewRepository(db *pgxpool.Pool) MovieReviewRepository {
+	return &movieReviewRepository{db: db}
+}
+
+const movieReviewColumns = `id, username, movie_id, rating, review, hidden, hidden_at, hidden_by, hidden_reason, created_at, updated_at`
+
+func scanMovieReview(row pgx.Row, review *models.MovieReview) error {
+	return row.Scan(
+		&review.ID,
+		&review.Username,
+		&review.MovieId,
+		&review.Rating,
+		&review.Review,
+		&review.Hidden,
+		&review.HiddenAt,
+		&review.HiddenBy,
+		&review.HiddenReason,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+}
+
+func (r *movieReviewRepository) HasCheckedInBooking(ctx context.Context, username, movieID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM booking b
+			JOIN show s ON s.id = b.show_id
+			WHERE b.customer_username = $1 AND s.movie_id = $2 AND b.status = 'CheckedIn'
+		)
+	`
+
+	var attended bool
+	if err := r.db.QueryRow(ctx, query, username, movieID).Scan(&attended); err != nil {
+		log.Error().Err(err).Str("username", username).Str("movieId", movieID).Msg("Failed to check bookings for review eligibility")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to check booking history", err)
+	}
+	return attended, nil
+}
+
+func (r *movieReviewRepository) Create(ctx context.Context, review *models.MovieReview) error {
+	query := `
+		INSERT INTO movie_review (username, movie_id, rating, review)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + movieReviewColumns
+
+	err := scanMovieReview(r.db.QueryRow(ctx, query, review.Username, review.MovieId, review.Rating, review.Review), review)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return utils.NewBadRequestError("REVIEW_ALREADY_EXISTS", "You have already reviewed this movie, edit your review instead", nil)
+		}
+		log.Error().Err(err).Str("username", review.Username).Str("movieId", review.MovieId).Msg("Failed to create movie review")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to save review", err)
+	}
+
+	return nil
+}
+
+func (r *movieReviewRepository) Update(ctx context.Context, review *models.MovieReview) (bool, error) {
+	query := `
+		UPDATE movie_review
+		SET rating = $3, review = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE username = $1 AND movie_id = $2
+		RETURNING ` + movieReviewColumns
+
+	err := scanMovieReview(r.db.QueryRow(ctx, query, review.Username, review.MovieId, review.Rating, review.Review), review)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		log.Error().Err(err).Str("username", review.Username).Str("movieId", review.MovieId).Msg("Failed to update movie review")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to update review", err)
+	}
+
+	return true, nil
+}
+
+func (r *movieReviewRepository) Find(ctx context.Context, filter models.MovieReviewFilter) ([]models.MovieReview, error) {
+	query := `SELECT ` + movieReviewColumns + ` FROM movie_review WHERE TRUE`
+
+	args := []interface{}{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.MovieID != "" {
+		addCondition("movie_id = $%d", filter.MovieID)
+	}
+	if filter.Username != "" {
+		addCondition("username = $%d", filter.Username)
+	}
+	if filter.Hidden != nil {
+		addCondition("hidden = $%d", *filter.Hidden)
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Str("movieId", filter.MovieID).Str("username", filter.Username).Msg("Failed to query movie reviews")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve reviews", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.MovieReview{}
+	for rows.Next() {
+		var review models.MovieReview
+		if err := scanMovieReview(rows, &review); err != nil {
+			log.Error().Err(err).Msg("Error scanning movie review row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan review data", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over movie review rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over reviews", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *movieReviewRepository) SetHidden(ctx context.Context, id int64, hidden bool, moderator string, reason *string) (*models.MovieReview, error) {
+	query := `
+		UPDATE movie_review
+		SET hidden = $2,
+		    hidden_at = CASE WHEN $2 THEN CURRENT_TIMESTAMP END,
+		    hidden_by = CASE WHEN $2 THEN $3 END,
+		    hidden_reason = CASE WHEN $2 THEN $4 END
+		WHERE id = $1
+		RETURNING ` + movieReviewColumns
+
+	var review models.MovieReview
+	err := scanMovieReview(r.db.QueryRow(ctx, query, id, hidden, moderator, reason), &review)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Int64("reviewId", id).Bool("hidden", hidden).Msg("Failed to moderate movie review")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to update review", err)
+	}
+
+	return &review, nil
+}
+
+func (r *movieReviewRepository) GetRatingSummaries(ctx context.Context, movieIDs []string) (map[string]models.MovieRatingSummary, error) {
+	summaries := make(map[string]models.MovieRatingSummary)
+	if len(movieIDs) == 0 {
+		return summaries, nil
+	}
+
+	query := `
+		SELECT movie_id, ROUND(AVG(rating), 1)::float8, COUNT(*)
+		FROM movie_review
+		WHERE movie_id = ANY($1) AND hidden = FALSE
+		GROUP BY movie_id
+	`
+
+	rows, err := r.db.Query(ctx, query, movieIDs)
+	if err != nil {
+		log.Error().Err(err).Int("movies", len(movieIDs)).Msg("Failed to aggregate movie ratings")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve movie ratings", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var summary models.MovieRatingSummary
+		if err := rows.Scan(&summary.MovieId, &summary.AverageRating, &summary.ReviewCount); err != nil {
+			log.Error().Err(err).Msg("Error scanning movie rating row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan movie rating data", err)
+		}
+		summaries[summary.MovieId] = summary
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over movie rating rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over movie ratings", err)
+	}
+
+	return summaries, nil
+}
@@ -12,6 +12,7 @@ import (
 type PaymentTransactionRepository interface {
 	CreateTransaction(ctx context.Context, transaction *models.PaymentTransaction) error
 	GetTransactionByBookingId(ctx context.Context, bookingId int) (*models.PaymentTransaction, error)
+	GetTransactionsByBookingId(ctx context.Context, bookingId int) ([]models.PaymentTransaction, error)
 	GetWalletTransactionsByUsername(ctx context.Context, username string) ([]models.PaymentTransaction, error)
 }
 
@@ -123,3 +124,46 @@ func (repo *paymentTransactionRepository) GetWalletTransactionsByUsername(ctx co
 	
 	return transactions, nil
 }
+
+func (repo *paymentTransactionRepository) GetTransactionsByBookingId(ctx context.Context, bookingId int) ([]models.PaymentTransaction, error) {
+	query := `
+		SELECT id, booking_id, transaction_id, payment_method,
+			amount, status, processed_at
+		FROM payment_transaction
+		WHERE booking_id = $1
+		ORDER BY processed_at ASC, id ASC
+	`
+
+	rows, err := repo.db.Query(ctx, query, bookingId)
+	if err != nil {
+		log.Error().Err(err).Int("bookingId", bookingId).Msg("Failed to get payment transactions for booking")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve payment transactions", err)
+	}
+	defer rows.Close()
+
+	transactions := []models.PaymentTransaction{}
+	for rows.Next() {
+		var transaction models.PaymentTransaction
+		err := rows.Scan(
+			&transaction.Id,
+			&transaction.BookingId,
+			&transaction.TransactionId,
+			&transaction.PaymentMethod,
+			&transaction.Amount,
+			&transaction.Status,
+			&transaction.ProcessedAt,
+		)
+		if err != nil {
+			log.Error().Err(err).Int("bookingId", bookingId).Msg("Failed to scan payment transaction")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan payment transaction", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Int("bookingId", bookingId).Msg("Error iterating payment transactions")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error iterating payment transactions", err)
+	}
+
+	return transactions, nil
+}
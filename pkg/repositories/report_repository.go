@@ -0,0 +1,312 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type ReportRepository interface {
+	CreateSchedule(ctx context.Context, schedule *models.ReportSchedule) error
+	GetSchedules(ctx context.Context) ([]models.ReportSchedule, error)
+	GetScheduleById(ctx context.Context, id int64) (*models.ReportSchedule, error)
+	DeleteSchedule(ctx context.Context, id int64) (bool, error)
+	FindDueSchedules(ctx context.Context, now time.Time) ([]models.ReportSchedule, error)
+	ClaimSchedule(ctx context.Context, id int64, dueAt, nextRunAt time.Time) (bool, error)
+	FindRetryableRuns(ctx context.Context, now time.Time) ([]models.ReportRun, error)
+	ClaimRetry(ctx context.Context, id int64, retryAt time.Time) (bool, error)
+	CreateRun(ctx context.Context, run *models.ReportRun) error
+	CompleteRun(ctx context.Context, run *models.ReportRun) error
+	GetRuns(ctx context.Context, scheduleId *int64, limit int) ([]models.ReportRun, error)
+	GetRunById(ctx context.Context, id int64) (*models.ReportRun, error)
+}
+
+type reportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepository(db *pgxpool.Pool) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+const reportScheduleColumns = `
+	id, name, report_type, format, period, cron_expression, recipients,
+	enabled, next_run_at, last_run_at, created_by, created_at
+`
+
+const reportRunColumns = `
+	id, schedule_id, report_type, format, period_start, period_end, status, object_key,
+	file_name, size_bytes, delivery_status, error, triggered_by, attempt, retry_at, started_at, completed_at
+`
+
+func scanReportSchedule(row pgx.Row, s *models.ReportSchedule) error {
+	return row.Scan(
+		&s.ID, &s.Name, &s.ReportType, &s.Format, &s.Period, &s.CronExpression, &s.Recipients,
+		&s.Enabled, &s.NextRunAt, &s.LastRunAt, &s.CreatedBy, &s.CreatedAt,
+	)
+}
+
+func scanReportRun(row pgx.Row, r *models.ReportRun) error {
+	return row.Scan(
+		&r.ID, &r.ScheduleID, &r.ReportType, &r.Format, &r.PeriodStart, &r.PeriodEnd, &r.Status, &r.ObjectKey,
+		&r.FileName, &r.SizeBytes, &r.DeliveryStatus, &r.Error, &r.TriggeredBy, &r.Attempt, &r.RetryAt, &r.StartedAt, &r.CompletedAt,
+	)
+}
+
+func (r *reportRepository) CreateSchedule(ctx context.Context, schedule *models.ReportSchedule) error {
+	query := `
+		INSERT INTO report_schedule (name, report_type, format, period, cron_expression, recipients, enabled, next_run_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		schedule.Name,
+		schedule.ReportType,
+		schedule.Format,
+		schedule.Period,
+		schedule.CronExpression,
+		schedule.Recipients,
+		schedule.Enabled,
+		schedule.NextRunAt,
+		schedule.CreatedBy,
+	).Scan(&schedule.ID, &schedule.CreatedAt)
+	if err != nil {
+		log.Error().Err(err).Str("name", schedule.Name).Msg("Failed to create report schedule")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to create report schedule", err)
+	}
+
+	return nil
+}
+
+func (r *reportRepository) GetSchedules(ctx context.Context) ([]models.ReportSchedule, error) {
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedule ORDER BY id`
+	return r.querySchedules(ctx, query)
+}
+
+func (r *reportRepository) GetScheduleById(ctx context.Context, id int64) (*models.ReportSchedule, error) {
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedule WHERE id = $1`
+
+	var schedule models.ReportSchedule
+	if err := scanReportSchedule(r.db.QueryRow(ctx, query, id), &schedule); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Int64("scheduleId", id).Msg("Failed to fetch report schedule")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve report schedule", err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *reportRepository) DeleteSchedule(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM report_schedule WHERE id = $1`, id)
+	if err != nil {
+		log.Error().Err(err).Int64("scheduleId", id).Msg("Failed to delete report schedule")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to delete report schedule", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *reportRepository) FindDueSchedules(ctx context.Context, now time.Time) ([]models.ReportSchedule, error) {
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedule WHERE enabled AND next_run_at <= $1 ORDER BY next_run_at`
+	return r.querySchedules(ctx, query, now)
+}
+
+// ClaimSchedule moves a due schedule on to its next run. It only succeeds while the schedule
+// is still due at dueAt, so when several instances race for the same run exactly one wins.
+func (r *reportRepository) ClaimSchedule(ctx context.Context, id int64, dueAt, nextRunAt time.Time) (bool, error) {
+	query := `
+		UPDATE report_schedule
+		SET next_run_at = $3, last_run_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND next_run_at = $2 AND enabled
+	`
+
+	result, err := r.db.Exec(ctx, query, id, dueAt, nextRunAt)
+	if err != nil {
+		log.Error().Err(err).Int64("scheduleId", id).Msg("Failed to claim report schedule")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to claim report schedule", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// FindRetryableRuns returns failed runs whose retry is due, oldest first.
+func (r *reportRepository) FindRetryableRuns(ctx context.Context, now time.Time) ([]models.ReportRun, error) {
+	query := `
+		SELECT ` + reportRunColumns + `
+		FROM report_run
+		WHERE retry_at <= $1
+		ORDER BY retry_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query retryable report runs")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve report runs", err)
+	}
+	defer rows.Close()
+
+	runs := []models.ReportRun{}
+	for rows.Next() {
+		var run models.ReportRun
+		if err := scanReportRun(rows, &run); err != nil {
+			log.Error().Err(err).Msg("Error scanning report run row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read report runs", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over report run rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process report runs", err)
+	}
+
+	return runs, nil
+}
+
+// ClaimRetry clears a failed run's retry so exactly one instance retries it, in the same way
+// ClaimSchedule claims a due schedule.
+func (r *reportRepository) ClaimRetry(ctx context.Context, id int64, retryAt time.Time) (bool, error) {
+	query := `UPDATE report_run SET retry_at = NULL WHERE id = $1 AND retry_at = $2`
+
+	result, err := r.db.Exec(ctx, query, id, retryAt)
+	if err != nil {
+		log.Error().Err(err).Int64("runId", id).Msg("Failed to claim report run retry")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to claim report run retry", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *reportRepository) CreateRun(ctx context.Context, run *models.ReportRun) error {
+	query := `
+		INSERT INTO report_run (schedule_id, report_type, format, period_start, period_end, triggered_by, attempt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, started_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		run.ScheduleID,
+		run.ReportType,
+		run.Format,
+		run.PeriodStart,
+		run.PeriodEnd,
+		run.TriggeredBy,
+		run.Attempt,
+	).Scan(&run.ID, &run.Status, &run.StartedAt)
+	if err != nil {
+		log.Error().Err(err).Str("reportType", run.ReportType).Msg("Failed to create report run")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to record report run", err)
+	}
+
+	return nil
+}
+
+func (r *reportRepository) CompleteRun(ctx context.Context, run *models.ReportRun) error {
+	query := `
+		UPDATE report_run
+		SET status = $2, object_key = $3, file_name = $4, size_bytes = $5,
+		    delivery_status = $6, error = $7, retry_at = $8, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING completed_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		run.ID,
+		run.Status,
+		run.ObjectKey,
+		run.FileName,
+		run.SizeBytes,
+		run.DeliveryStatus,
+		run.Error,
+		run.RetryAt,
+	).Scan(&run.CompletedAt)
+	if err != nil {
+		log.Error().Err(err).Int64("runId", run.ID).Msg("Failed to complete report run")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update report run", err)
+	}
+
+	return nil
+}
+
+func (r *reportRepository) GetRuns(ctx context.Context, scheduleId *int64, limit int) ([]models.ReportRun, error) {
+	query := `
+		SELECT ` + reportRunColumns + `
+		FROM report_run
+		WHERE ($1::bigint IS NULL OR schedule_id = $1)
+		ORDER BY started_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, scheduleId, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query report runs")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve report runs", err)
+	}
+	defer rows.Close()
+
+	runs := []models.ReportRun{}
+	for rows.Next() {
+		var run models.ReportRun
+		if err := scanReportRun(rows, &run); err != nil {
+			log.Error().Err(err).Msg("Error scanning report run row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read report runs", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over report run rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process report runs", err)
+	}
+
+	return runs, nil
+}
+
+func (r *reportRepository) GetRunById(ctx context.Context, id int64) (*models.ReportRun, error) {
+	query := `SELECT ` + reportRunColumns + ` FROM report_run WHERE id = $1`
+
+	var run models.ReportRun
+	if err := scanReportRun(r.db.QueryRow(ctx, query, id), &run); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Int64("runId", id).Msg("Failed to fetch report run")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve report run", err)
+	}
+
+	return &run, nil
+}
+
+func (r *reportRepository) querySchedules(ctx context.Context, query string, args ...any) ([]models.ReportSchedule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query report schedules")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve report schedules", err)
+	}
+	defer rows.Close()
+
+	schedules := []models.ReportSchedule{}
+	for rows.Next() {
+		var schedule models.ReportSchedule
+		if err := scanReportSchedule(rows, &schedule); err != nil {
+			log.Error().Err(err).Msg("Error scanning report schedule row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read report schedules", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over report schedule rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process report schedules", err)
+	}
+
+	return schedules, nil
+}
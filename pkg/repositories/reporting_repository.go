@@ -0,0 +1,370 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type ReportingRepository interface {
+	GetRevenueSummary(ctx context.Context, filter models.RevenueFilter) (*models.RevenueAggregate, error)
+	GetRevenueGroups(ctx context.Context, filter models.RevenueFilter, groupBy []models.RevenueGroupKey, timeframe string) ([]models.RevenueAggregate, error)
+	GetOccupancy(ctx context.Context, filter models.RevenueFilter, dimension models.OccupancyDimension, timeframe string) ([]models.OccupancyAggregate, error)
+	GetShowSales(ctx context.Context, filter models.RevenueFilter, from, to time.Time) ([]models.ShowSales, error)
+}
+
+type reportingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReportingRepository(db *pgxpool.Pool) ReportingRepository {
+	return &reportingRepository{db: db}
+}
+
+const revenueAggregateColumns = `
+	COALESCE(SUM(b.amount_paid), 0)::float8,
+	COALESCE(AVG(b.amount_paid), 0)::float8,
+	COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY b.amount_paid), 0)::float8,
+	COUNT(*),
+	COALESCE(SUM(b.no_of_seats), 0)
+`
+
+// Time buckets follow the database session time zone.
+var revenueBucketFormats = map[string]string{
+	"daily":   "YYYY-MM-DD",
+	"weekly":  `IYYY-"W"IW`,
+	"monthly": "YYYY-MM",
+	"yearly":  "YYYY",
+}
+
+func (r *reportingRepository) GetRevenueSummary(ctx context.Context, filter models.RevenueFilter) (*models.RevenueAggregate, error) {
+	where, args := buildRevenueWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM booking b
+		LEFT JOIN show s ON s.id = b.show_id
+		WHERE %s
+	`, revenueAggregateColumns, where)
+
+	var summary models.RevenueAggregate
+	err := r.db.QueryRow(ctx, query, args...).Scan(
+		&summary.TotalRevenue,
+		&summary.MeanRevenue,
+		&summary.MedianRevenue,
+		&summary.TotalBookings,
+		&summary.TotalSeats,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to aggregate revenue summary")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to calculate revenue", err)
+	}
+
+	return &summary, nil
+}
+
+func (r *reportingRepository) GetRevenueGroups(ctx context.Context, filter models.RevenueFilter, groupBy []models.RevenueGroupKey, timeframe string) ([]models.RevenueAggregate, error) {
+	movieExpr, slotExpr, bucketExpr := "''", "''", "''"
+	var groupColumns []string
+
+	for _, key := range groupBy {
+		switch key {
+		case models.RevenueGroupByMovie:
+			movieExpr = "s.movie_id"
+			groupColumns = append(groupColumns, "1")
+		case models.RevenueGroupBySlot:
+			slotExpr = "sl.name"
+			groupColumns = append(groupColumns, "2")
+		case models.RevenueGroupByBucket:
+			format, ok := revenueBucketFormats[timeframe]
+			if !ok {
+				bucketExpr = "'All'"
+				continue
+			}
+			bucketExpr = fmt.Sprintf("to_char(b.booking_time, '%s')", format)
+			groupColumns = append(groupColumns, "3")
+		}
+	}
+
+	where, args := buildRevenueWhere(filter)
+
+	groupClause := ""
+	if len(groupColumns) > 0 {
+		groupClause = "GROUP BY " + strings.Join(groupColumns, ", ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s, %s, %s
+		FROM booking b
+		JOIN show s ON s.id = b.show_id
+		JOIN slot sl ON sl.id = s.slot_id
+		WHERE %s
+		%s
+		HAVING COUNT(*) > 0
+	`, movieExpr, slotExpr, bucketExpr, revenueAggregateColumns, where, groupClause)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to aggregate revenue groups")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to calculate revenue groups", err)
+	}
+	defer rows.Close()
+
+	var groups []models.RevenueAggregate
+	for rows.Next() {
+		var g models.RevenueAggregate
+		if err := rows.Scan(
+			&g.MovieID,
+			&g.SlotName,
+			&g.Bucket,
+			&g.TotalRevenue,
+			&g.MeanRevenue,
+			&g.MedianRevenue,
+			&g.TotalBookings,
+			&g.TotalSeats,
+		); err != nil {
+			log.Error().Err(err).Msg("Error scanning revenue group row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read revenue data", err)
+		}
+		groups = append(groups, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over revenue group rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process revenue data", err)
+	}
+
+	return groups, nil
+}
+
+// occupancyDimensions maps each dimension to its key, label and sort expressions over show_occupancy.
+var occupancyDimensions = map[models.OccupancyDimension][3]string{
+	models.OccupancyByShow:    {"so.id::text", "to_char(so.date, 'YYYY-MM-DD') || ' ' || so.slot_name", "MIN(so.date), MIN(so.start_time)"},
+	models.OccupancyByMovie:   {"so.movie_id", "so.movie_id", "1"},
+	models.OccupancyBySlot:    {"so.slot_id::text", "so.slot_name", "MIN(so.start_time)"},
+	models.OccupancyByWeekday: {"EXTRACT(ISODOW FROM so.date)::text", "to_char(so.date, 'FMDay')", "1"},
+}
+
+// GetOccupancy reports seats sold against capacity for shows up to today. Period filters
+// apply to the show date, capacity is the seat count of the hall for every show, and
+// lead time is measured from booking time to the start of the show.
+func (r *reportingRepository) GetOccupancy(ctx context.Context, filter models.RevenueFilter, dimension models.OccupancyDimension, timeframe string) ([]models.OccupancyAggregate, error) {
+	keyExpr, labelExpr, orderExpr := "''", "'All'", ""
+	switch dimension {
+	case models.OccupancyOverall:
+	case models.OccupancyByBucket:
+		format, ok := revenueBucketFormats[timeframe]
+		if !ok {
+			format = revenueBucketFormats["daily"]
+		}
+		keyExpr = fmt.Sprintf("to_char(so.date, '%s')", format)
+		labelExpr, orderExpr = keyExpr, "1"
+	default:
+		exprs, ok := occupancyDimensions[dimension]
+		if !ok {
+			return nil, utils.NewBadRequestError("INVALID_DIMENSION", fmt.Sprintf("Unknown occupancy dimension %q", dimension), nil)
+		}
+		keyExpr, labelExpr, orderExpr = exprs[0], exprs[1], exprs[2]
+	}
+
+	where, args := buildReportWhere(filter, "s.date", []string{"s.date <= CURRENT_DATE"}, []any{filter.Statuses})
+
+	groupClause := ""
+	if orderExpr != "" {
+		groupClause = "GROUP BY 1, 2 ORDER BY " + orderExpr
+	}
+
+	query := fmt.Sprintf(`
+		WITH seat_capacity AS (
+			SELECT COUNT(*) AS total_seats,
+			       COUNT(*) FILTER (WHERE seat_type = 'Deluxe') AS deluxe_seats
+			FROM seat
+		),
+		booking_seats AS (
+			SELECT b.show_id, b.booking_time,
+			       COUNT(bsm.id) AS seats,
+			       COUNT(bsm.id) FILTER (WHERE st.seat_type = 'Deluxe') AS deluxe_seats
+			FROM booking b
+			LEFT JOIN booking_seat_mapping bsm ON bsm.booking_id = b.id
+			LEFT JOIN seat st ON st.seat_number = bsm.seat_number
+			WHERE b.status = ANY($1)
+			GROUP BY b.id
+		),
+		show_occupancy AS (
+			SELECT s.id, s.movie_id, s.slot_id, s.date, sl.name AS slot_name, sl.start_time,
+			       COALESCE(SUM(bs.seats), 0) AS seats_sold,
+			       COALESCE(SUM(bs.deluxe_seats), 0) AS deluxe_sold,
+			       SUM(EXTRACT(EPOCH FROM (s.date + sl.start_time)::timestamptz - bs.booking_time)) AS lead_seconds,
+			       COUNT(bs.show_id) AS bookings
+			FROM show s
+			JOIN slot sl ON sl.id = s.slot_id
+			LEFT JOIN booking_seats bs ON bs.show_id = s.id
+			WHERE %s
+			GROUP BY s.id, sl.name, sl.start_time
+		)
+		SELECT %s, %s,
+		       COUNT(*),
+		       COALESCE(SUM(so.seats_sold), 0)::bigint,
+		       COUNT(*) * COALESCE(MAX(c.total_seats), 0),
+		       COALESCE(SUM(so.deluxe_sold), 0)::bigint,
+		       COUNT(*) * COALESCE(MAX(c.deluxe_seats), 0),
+		       COALESCE(SUM(so.lead_seconds) / NULLIF(SUM(so.bookings), 0) / 3600, 0)::float8
+		FROM show_occupancy so
+		CROSS JOIN seat_capacity c
+		%s
+	`, where, keyExpr, labelExpr, groupClause)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Str("dimension", string(dimension)).Msg("Failed to aggregate occupancy")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to calculate occupancy", err)
+	}
+	defer rows.Close()
+
+	var aggregates []models.OccupancyAggregate
+	for rows.Next() {
+		var a models.OccupancyAggregate
+		if err := rows.Scan(
+			&a.Key,
+			&a.Label,
+			&a.Shows,
+			&a.SeatsSold,
+			&a.Capacity,
+			&a.DeluxeSeatsSold,
+			&a.DeluxeCapacity,
+			&a.AverageLeadTimeHours,
+		); err != nil {
+			log.Error().Err(err).Msg("Error scanning occupancy row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read occupancy data", err)
+		}
+		aggregates = append(aggregates, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over occupancy rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process occupancy data", err)
+	}
+
+	return aggregates, nil
+}
+
+// GetShowSales returns per-show sales for shows dated in [from, to), ordered by start time.
+// Only the movie and slot fields of the filter are applied.
+func (r *reportingRepository) GetShowSales(ctx context.Context, filter models.RevenueFilter, from, to time.Time) ([]models.ShowSales, error) {
+	where, args := buildReportWhere(
+		models.RevenueFilter{MovieID: filter.MovieID, SlotID: filter.SlotID, MovieIDsIn: filter.MovieIDsIn},
+		"s.date",
+		[]string{"s.date >= $2", "s.date < $3"},
+		[]any{filter.Statuses, from, to},
+	)
+
+	query := fmt.Sprintf(`
+		SELECT s.id, s.movie_id, s.slot_id, sl.name, s.date,
+		       (s.date + sl.start_time)::timestamptz,
+		       s.cost::float8,
+		       c.total_seats,
+		       LEAST(GREATEST(FLOOR(EXTRACT(EPOCH FROM (s.date + sl.start_time)::timestamptz - b.booking_time) / 86400), 0), %d)::int,
+		       COUNT(b.id),
+		       COALESCE(SUM(b.no_of_seats), 0)::bigint,
+		       COALESCE(SUM(b.amount_paid), 0)::float8
+		FROM show s
+		JOIN slot sl ON sl.id = s.slot_id
+		CROSS JOIN (SELECT COUNT(*) AS total_seats FROM seat) c
+		LEFT JOIN booking b ON b.show_id = s.id AND b.status = ANY($1)
+		WHERE %s
+		GROUP BY 1, 2, 3, 4, 5, 6, 7, 8, 9
+		ORDER BY 6, 1
+	`, models.ShowSalesMaxLeadDays, where)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query show sales")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve show sales", err)
+	}
+	defer rows.Close()
+
+	var sales []models.ShowSales
+	for rows.Next() {
+		var row models.ShowSales
+		var leadDays *int
+		var bookings, seats int
+		var revenue float64
+		if err := rows.Scan(
+			&row.ShowID,
+			&row.MovieID,
+			&row.SlotID,
+			&row.SlotName,
+			&row.Date,
+			&row.StartsAt,
+			&row.Cost,
+			&row.Capacity,
+			&leadDays,
+			&bookings,
+			&seats,
+			&revenue,
+		); err != nil {
+			log.Error().Err(err).Msg("Error scanning show sales row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read show sales", err)
+		}
+
+		if len(sales) == 0 || sales[len(sales)-1].ShowID != row.ShowID {
+			sales = append(sales, row)
+		}
+		current := &sales[len(sales)-1]
+		current.Bookings += bookings
+		current.SeatsSold += seats
+		current.Revenue += revenue
+		if leadDays != nil {
+			current.SeatsByLeadDays[*leadDays] += seats
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over show sales rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process show sales", err)
+	}
+
+	return sales, nil
+}
+
+func buildRevenueWhere(filter models.RevenueFilter) (string, []any) {
+	return buildReportWhere(filter, "b.booking_time", []string{"b.status = ANY($1)"}, []any{filter.Statuses})
+}
+
+// buildReportWhere appends the filter conditions to the given ones, numbering parameters
+// after the existing args. Period filters apply to timeColumn.
+func buildReportWhere(filter models.RevenueFilter, timeColumn string, conditions []string, args []any) (string, []any) {
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Since != nil {
+		add(timeColumn+" >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		add(timeColumn+" < $%d", *filter.Until)
+	}
+	if filter.Month != nil {
+		add("EXTRACT(MONTH FROM "+timeColumn+") = $%d", *filter.Month)
+	}
+	if filter.Year != nil {
+		add("EXTRACT(YEAR FROM "+timeColumn+") = $%d", *filter.Year)
+	}
+	if filter.MovieID != nil {
+		add("s.movie_id = $%d", *filter.MovieID)
+	}
+	if filter.SlotID != nil {
+		add("s.slot_id = $%d", *filter.SlotID)
+	}
+	if filter.MovieIDsIn != nil {
+		add("s.movie_id = ANY($%d)", filter.MovieIDsIn)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type SavedCardRepository interface {
+	Create(ctx context.Context, card *models.SavedCard, maxCards int) (bool, error)
+	FindByUsername(ctx context.Context, username string) ([]models.SavedCard, error)
+	FindByIdAndUsername(ctx context.Context, id int64, username string) (*models.SavedCard, error)
+	CountByUsername(ctx context.Context, username string) (int, error)
+	DeleteByIdAndUsername(ctx context.Context, id int64, username string) (bool, error)
+}
+
+type savedCardRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSavedCardRepository(db *pgxpool.Pool) SavedCardRepository {
+	return &savedCardRepository{db: db}
+}
+
+// Create saves the card unless the customer already has maxCards cards, in which case it
+// returns false. The customer row is locked first so concurrent saves are counted one at a time.
+func (r *savedCardRepository) Create(ctx context.Context, card *models.SavedCard, maxCards int) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("username", card.Username).Msg("Failed to begin transaction for saved card")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to save card", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM customertable WHERE username = $1 FOR UPDATE`, card.Username); err != nil {
+		log.Error().Err(err).Str("username", card.Username).Msg("Failed to lock customer for saved card")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to save card", err)
+	}
+
+	query := `
+		INSERT INTO saved_card (username, provider_token, last4, brand, expiry_month, expiry_year)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE (SELECT COUNT(*) FROM saved_card WHERE username = $1) < $7
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		card.Username,
+		card.ProviderToken,
+		card.Last4,
+		card.Brand,
+		card.ExpiryMonth,
+		card.ExpiryYear,
+		maxCards,
+	).Scan(&card.ID, &card.CreatedAt)
+
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Str("username", card.Username).Msg("Failed to create saved card")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to save card", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", card.Username).Msg("Failed to commit saved card")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to save card", err)
+	}
+
+	return true, nil
+}
+
+func (r *savedCardRepository) FindByUsername(ctx context.Context, username string) ([]models.SavedCard, error) {
+	query := `
+		SELECT id, username, provider_token, last4, brand, expiry_month, expiry_year, created_at
+		FROM saved_card
+		WHERE username = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to query saved cards")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve saved cards", err)
+	}
+	defer rows.Close()
+
+	var cards []models.SavedCard
+	for rows.Next() {
+		var card models.SavedCard
+		if err := rows.Scan(
+			&card.ID,
+			&card.Username,
+			&card.ProviderToken,
+			&card.Last4,
+			&card.Brand,
+			&card.ExpiryMonth,
+			&card.ExpiryYear,
+			&card.CreatedAt,
+		); err != nil {
+			log.Error().Err(err).Msg("Error scanning saved card row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read saved card data", err)
+		}
+		cards = append(cards, card)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over saved card rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process saved card data", err)
+	}
+
+	return cards, nil
+}
+
+func (r *savedCardRepository) FindByIdAndUsername(ctx context.Context, id int64, username string) (*models.SavedCard, error) {
+	query := `
+		SELECT id, username, provider_token, last4, brand, expiry_month, expiry_year, created_at
+		FROM saved_card
+		WHERE id = $1 AND username = $2
+	`
+
+	var card models.SavedCard
+	err := r.db.QueryRow(ctx, query, id, username).Scan(
+		&card.ID,
+		&card.Username,
+		&card.ProviderToken,
+		&card.Last4,
+		&card.Brand,
+		&card.ExpiryMonth,
+		&card.ExpiryYear,
+		&card.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Int64("id", id).Str("username", username).Msg("Failed to find saved card")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve saved card", err)
+	}
+
+	return &card, nil
+}
+
+func (r *savedCardRepository) CountByUsername(ctx context.Context, username string) (int, error) {
+	query := `SELECT COUNT(*) FROM saved_card WHERE username = $1`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, username).Scan(&count); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to count saved cards")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to count saved cards", err)
+	}
+
+	return count, nil
+}
+
+func (r *savedCardRepository) DeleteByIdAndUsername(ctx context.Context, id int64, username string) (bool, error) {
+	query := `
+		DELETE FROM saved_card
+		WHERE id = $1 AND username = $2
+	`
+
+	result, err := r.db.Exec(ctx, query, id, username)
+	if err != nil {
+		log.Error().Err(err).Int64("id", id).Str("username", username).Msg("Failed to delete saved card")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to delete saved card", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
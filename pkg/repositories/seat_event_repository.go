@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+const seatEventChannel = "seat_events"
+
+// SeatEventRepository relays seat events between server instances over Postgres
+// LISTEN/NOTIFY.
+type SeatEventRepository interface {
+	Notify(ctx context.Context, event models.SeatEvent) error
+	Listen(ctx context.Context, onListening func(), handle func(models.SeatEvent)) error
+}
+
+type seatEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSeatEventRepository(db *pgxpool.Pool) SeatEventRepository {
+	return &seatEventRepository{db: db}
+}
+
+func (r *seatEventRepository) Notify(ctx context.Context, event models.SeatEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return utils.NewInternalServerError("SEAT_EVENT_ERROR", "Failed to encode seat event", err)
+	}
+
+	if _, err := r.db.Exec(ctx, "SELECT pg_notify($1, $2)", seatEventChannel, string(payload)); err != nil {
+		log.Error().Err(err).Int("showId", event.ShowID).Msg("Failed to publish seat event")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to publish seat event", err)
+	}
+
+	return nil
+}
+
+// Listen holds a dedicated connection and hands every seat event to handle until ctx is
+// cancelled or the connection fails. onListening is called once LISTEN has taken effect.
+func (r *seatEventRepository) Listen(ctx context.Context, onListening func(), handle func(models.SeatEvent)) error {
+	poolConn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to acquire connection for seat events", err)
+	}
+	// The connection stays in LISTEN mode, so it is taken out of the pool and closed when done.
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+seatEventChannel); err != nil {
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to listen for seat events", err)
+	}
+	onListening()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event models.SeatEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Warn().Err(err).Str("payload", notification.Payload).Msg("Ignoring malformed seat event")
+			continue
+		}
+		handle(event)
+	}
+}
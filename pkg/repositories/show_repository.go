@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 	"github.com/jackc/pgx/v5"
@@ -17,6 +18,12 @@ type ShowRepository interface {
 	GetAllShowsOn(ctx context.Context, date time.Time) ([]models.Show, error)
 	FindById(ctx context.Context, id int) (*models.Show, error)
 	GetSeatMapForShow(ctx context.Context, showID int) ([]models.SeatMapEntry, error)
+	// GetUpcomingShows returns shows that have not started yet, soonest first, with their
+	// booked seat counts.
+	GetUpcomingShows(ctx context.Context, filter models.UpcomingShowFilter) ([]models.ShowAvailability, error)
+	// SearchShows returns one page of shows matching the filter with their booked seat counts,
+	// and the number of matching shows across all pages.
+	SearchShows(ctx context.Context, filter models.ShowSearchFilter) ([]models.ShowAvailability, int, error)
 }
 
 type showRepository struct {
@@ -149,6 +156,7 @@ func (repo *showRepository) GetSeatMapForShow(ctx context.Context, showID int) (
                 JOIN booking b ON bsm.booking_id = b.id
                 WHERE b.show_id = $1 
                 AND bsm.seat_number = s.seat_number
+                AND b.status IN ('Pending', 'Confirmed', 'CheckedIn')
             ) AS occupied
         FROM 
             seat s
@@ -194,3 +202,206 @@ func (repo *showRepository) GetSeatMapForShow(ctx context.Context, showID int) (
 
 	return seatMap, nil
 }
+
+func (repo *showRepository) GetUpcomingShows(ctx context.Context, filter models.UpcomingShowFilter) ([]models.ShowAvailability, error) {
+	query := `
+        SELECT s.id, s.movie_id, s.date, s.slot_id, s.cost,
+               sl.id, sl.name, sl.start_time, sl.end_time,
+               COALESCE((
+                   SELECT SUM(b.no_of_seats)
+                   FROM booking b
+                   WHERE b.show_id = s.id AND b.status IN ('Confirmed', 'CheckedIn', 'Pending')
+               ), 0)
+        FROM show s
+        JOIN slot sl ON s.slot_id = sl.id
+        WHERE (s.date + sl.start_time) > LOCALTIMESTAMP
+    `
+
+	args := []interface{}{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.From != nil {
+		addCondition("s.date >= $%d", *filter.From)
+	}
+	if filter.Until != nil {
+		addCondition("s.date <= $%d", *filter.Until)
+	}
+	if filter.MovieIDs != nil {
+		addCondition("s.movie_id = ANY($%d)", filter.MovieIDs)
+	}
+
+	query += " ORDER BY s.date, sl.start_time, s.id"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := repo.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query upcoming shows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve shows", err)
+	}
+	defer rows.Close()
+
+	shows := []models.ShowAvailability{}
+	for rows.Next() {
+		var show models.ShowAvailability
+		err := rows.Scan(
+			&show.Id,
+			&show.MovieId,
+			&show.Date,
+			&show.SlotId,
+			&show.Cost,
+			&show.Slot.Id,
+			&show.Slot.Name,
+			&show.Slot.StartTime,
+			&show.Slot.EndTime,
+			&show.BookedSeats,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning upcoming show row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan show data", err)
+		}
+		shows = append(shows, show)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over upcoming show rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over shows", err)
+	}
+
+	return shows, nil
+}
+
+var showSearchOrders = map[string]string{
+	models.ShowSearchSortTime:      "s.date, sl.start_time, s.id",
+	models.ShowSearchSortPriceAsc:  "s.cost, s.date, sl.start_time, s.id",
+	models.ShowSearchSortPriceDesc: "s.cost DESC, s.date, sl.start_time, s.id",
+	models.ShowSearchSortSeats:     "booked.seats, s.date, sl.start_time, s.id",
+	models.ShowSearchSortRating:    "rating.average DESC NULLS LAST, s.date, sl.start_time, s.id",
+}
+
+func (repo *showRepository) SearchShows(ctx context.Context, filter models.ShowSearchFilter) ([]models.ShowAvailability, int, error) {
+	from := `
+        FROM show s
+        JOIN slot sl ON s.slot_id = sl.id
+        CROSS JOIN LATERAL (
+            SELECT COALESCE(SUM(b.no_of_seats), 0) AS seats
+            FROM booking b
+            WHERE b.show_id = s.id AND b.status IN ('Confirmed', 'CheckedIn', 'Pending')
+        ) booked
+        LEFT JOIN (
+            SELECT movie_id, AVG(rating) AS average
+            FROM movie_review
+            WHERE NOT hidden
+            GROUP BY movie_id
+        ) rating ON rating.movie_id = s.movie_id
+        WHERE s.date >= $1 AND s.date <= $2
+    `
+
+	args := []interface{}{filter.From, filter.Until}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		from += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.UpcomingOnly {
+		from += " AND (s.date + sl.start_time) > LOCALTIMESTAMP"
+	}
+	if filter.MovieIDs != nil {
+		addCondition("s.movie_id = ANY($%d)", filter.MovieIDs)
+	}
+	if filter.SlotID != nil {
+		addCondition("s.slot_id = $%d", *filter.SlotID)
+	}
+	if filter.StartFrom != "" && filter.StartBefore != "" {
+		args = append(args, filter.StartFrom, filter.StartBefore)
+		operator := "AND"
+		if filter.StartFrom > filter.StartBefore {
+			operator = "OR"
+		}
+		from += fmt.Sprintf(" AND (sl.start_time >= $%d::time %s sl.start_time < $%d::time)", len(args)-1, operator, len(args))
+	}
+	if filter.MinPrice != nil || filter.MaxPrice != nil {
+		args = append(args, constants.DELUXE_OFFSET)
+		offset := len(args)
+		priceInRange := func(price string) string {
+			condition := "TRUE"
+			if filter.MinPrice != nil {
+				args = append(args, *filter.MinPrice)
+				condition += fmt.Sprintf(" AND %s >= $%d", price, len(args))
+			}
+			if filter.MaxPrice != nil {
+				args = append(args, *filter.MaxPrice)
+				condition += fmt.Sprintf(" AND %s <= $%d", price, len(args))
+			}
+			return condition
+		}
+		standard := priceInRange("s.cost")
+		deluxe := priceInRange(fmt.Sprintf("(s.cost + $%d::numeric)", offset))
+		from += fmt.Sprintf(" AND ((%s) OR (%s))", standard, deluxe)
+	}
+	if filter.MinSeats != nil {
+		addCondition(fmt.Sprintf("%d - booked.seats >= $%%d", constants.TOTAL_NO_OF_SEATS), *filter.MinSeats)
+	}
+
+	var total int
+	if err := repo.db.QueryRow(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		log.Error().Err(err).Msg("Failed to count searched shows")
+		return nil, 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to search shows", err)
+	}
+
+	order, ok := showSearchOrders[filter.Sort]
+	if !ok {
+		order = showSearchOrders[models.ShowSearchSortTime]
+	}
+
+	query := `
+        SELECT s.id, s.movie_id, s.date, s.slot_id, s.cost,
+               sl.id, sl.name, sl.start_time, sl.end_time,
+               booked.seats
+    ` + from + " ORDER BY " + order
+
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := repo.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search shows")
+		return nil, 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to search shows", err)
+	}
+	defer rows.Close()
+
+	shows := []models.ShowAvailability{}
+	for rows.Next() {
+		var show models.ShowAvailability
+		err := rows.Scan(
+			&show.Id,
+			&show.MovieId,
+			&show.Date,
+			&show.SlotId,
+			&show.Cost,
+			&show.Slot.Id,
+			&show.Slot.Name,
+			&show.Slot.StartTime,
+			&show.Slot.EndTime,
+			&show.BookedSeats,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning searched show row")
+			return nil, 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan show data", err)
+		}
+		shows = append(shows, show)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over searched show rows")
+		return nil, 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over shows", err)
+	}
+
+	return shows, total, nil
+}
@@ -2,12 +2,17 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
 )
 
 type SkyCustomerRepository interface {
@@ -17,9 +22,12 @@ type SkyCustomerRepository interface {
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	ExistsByMobileNumber(ctx context.Context, mobileNumber string) (bool, error)
 	Create(ctx context.Context, customer *models.SkyCustomer) error
+	CreateCustomerAccount(ctx context.Context, user *models.User, passwordHistory *models.PasswordHistory, customer *models.SkyCustomer, wallet *models.CustomerWallet) error
 	GetCustomerProfileImg(ctx context.Context, username string) (string, error)
 	UpdateCustomerDetails(ctx context.Context, username string, updates map[string]interface{}) error
-	UpdateProfileImageURL(ctx context.Context, username string, profileImgURL string) error
+	UpdateProfileImageKey(ctx context.Context, username string, objectKey string) error
+	GetReferencedProfileImageKeys(ctx context.Context) (map[string]bool, error)
+	DeleteCustomerAccount(ctx context.Context, username, placeholderName, placeholderNumber string) (int, error)
 }
 
 type skyCustomerRepository struct {
@@ -31,7 +39,7 @@ func NewSkyCustomerRepository(db *pgxpool.Pool) SkyCustomerRepository {
 }
 
 func (repo *skyCustomerRepository) FindByUsername(ctx context.Context, username string) (*models.SkyCustomer, error) {
-	query := `SELECT id, name, username, number, email, profile_img, security_question_id, security_answer_hash FROM customertable WHERE username = $1`
+	query := `SELECT id, name, username, number, email, profile_img, security_question_id, security_answer_hash, email_verified_at, number_verified_at, pending_email, pending_number FROM customertable WHERE username = $1`
 
 	var customer models.SkyCustomer
 	err := repo.db.QueryRow(ctx, query, username).Scan(
@@ -43,6 +51,10 @@ func (repo *skyCustomerRepository) FindByUsername(ctx context.Context, username
 		&customer.ProfileImg,
 		&customer.SecurityQuestionID,
 		&customer.SecurityAnswerHash,
+		&customer.EmailVerifiedAt,
+		&customer.NumberVerifiedAt,
+		&customer.PendingEmail,
+		&customer.PendingNumber,
 	)
 
 	if err != nil {
@@ -56,7 +68,7 @@ func (repo *skyCustomerRepository) FindByUsername(ctx context.Context, username
 }
 
 func (repo *skyCustomerRepository) FindByEmail(ctx context.Context, email string) (*models.SkyCustomer, error) {
-	query := `SELECT id, name, username, number, email, profile_img, security_question_id, security_answer_hash FROM customertable WHERE email = $1`
+	query := `SELECT id, name, username, number, email, profile_img, security_question_id, security_answer_hash, email_verified_at, number_verified_at, pending_email, pending_number FROM customertable WHERE email = $1`
 
 	var customer models.SkyCustomer
 	err := repo.db.QueryRow(ctx, query, email).Scan(
@@ -68,6 +80,10 @@ func (repo *skyCustomerRepository) FindByEmail(ctx context.Context, email string
 		&customer.ProfileImg,
 		&customer.SecurityQuestionID,
 		&customer.SecurityAnswerHash,
+		&customer.EmailVerifiedAt,
+		&customer.NumberVerifiedAt,
+		&customer.PendingEmail,
+		&customer.PendingNumber,
 	)
 
 	if err != nil {
@@ -150,6 +166,87 @@ func (repo *skyCustomerRepository) Create(ctx context.Context, customer *models.
 	return nil
 }
 
+// CreateCustomerAccount inserts the user, password history, customer and wallet rows for a
+// signup in one transaction, so a failure part way through leaves nothing behind and the
+// username stays free. A unique violation from a concurrent signup is reported the same way
+// as the up-front existence checks.
+func (repo *skyCustomerRepository) CreateCustomerAccount(ctx context.Context, user *models.User, passwordHistory *models.PasswordHistory, customer *models.SkyCustomer, wallet *models.CustomerWallet) error {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin signup transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO usertable (username, password, role) VALUES ($1, $2, $3) RETURNING id`,
+		user.Username, user.Password, user.Role,
+	).Scan(&user.ID)
+	if err != nil {
+		return signupInsertError(err, user.Username, "Failed to create user")
+	}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO password_history (username, password_hash)
+		VALUES ($1, $2)
+		RETURNING id, created_at`,
+		passwordHistory.Username,
+		passwordHistory.PasswordHash,
+	).Scan(&passwordHistory.ID, &passwordHistory.CreatedAt)
+	if err != nil {
+		return signupInsertError(err, user.Username, "Error saving password history")
+	}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO customertable (name, username, number, email, profile_img, security_question_id, security_answer_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		customer.Name,
+		customer.Username,
+		customer.Number,
+		customer.Email,
+		customer.ProfileImg,
+		customer.SecurityQuestionID,
+		customer.SecurityAnswerHash,
+	).Scan(&customer.ID)
+	if err != nil {
+		return signupInsertError(err, user.Username, "Error creating customer")
+	}
+
+	now := time.Now()
+	wallet.CreatedAt = now
+	wallet.UpdatedAt = now
+	err = tx.QueryRow(ctx,
+		`INSERT INTO customer_wallet (username, balance, created_at, updated_at) VALUES ($1, $2, $3, $4) RETURNING id`,
+		wallet.Username, wallet.Balance, wallet.CreatedAt, wallet.UpdatedAt,
+	).Scan(&wallet.ID)
+	if err != nil {
+		return signupInsertError(err, user.Username, "Error creating wallet")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("Failed to commit signup")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit signup", err)
+	}
+
+	log.Info().Str("username", user.Username).Int("id", user.ID).Msg("Customer account created successfully")
+	return nil
+}
+
+func signupInsertError(err error, username, message string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		switch {
+		case strings.Contains(pgErr.ConstraintName, "email"):
+			return utils.NewBadRequestError("EMAIL_EXISTS", "Email already exists", nil)
+		case strings.Contains(pgErr.ConstraintName, "username"):
+			return utils.NewBadRequestError("USERNAME_EXISTS", "Username is already taken", nil)
+		}
+	}
+	log.Error().Err(err).Str("username", username).Msg(message)
+	return utils.NewInternalServerError("DATABASE_ERROR", message, err)
+}
+
 func (repo *skyCustomerRepository) UpdateCustomerDetails(ctx context.Context, username string, updates map[string]interface{}) error {
 	query := "UPDATE customertable SET "
 	args := []interface{}{username}
@@ -190,13 +287,117 @@ func (repo *skyCustomerRepository) GetCustomerProfileImg(ctx context.Context, us
 	return profileImg, nil
 }
 
-func (repo *skyCustomerRepository) UpdateProfileImageURL(ctx context.Context, username string, profileImgURL string) error {
+func (repo *skyCustomerRepository) UpdateProfileImageKey(ctx context.Context, username string, objectKey string) error {
 	query := "UPDATE customertable SET profile_img = $1 WHERE username = $2"
 
-	_, err := repo.db.Exec(ctx, query, profileImgURL, username)
+	_, err := repo.db.Exec(ctx, query, objectKey, username)
 	if err != nil {
-		return utils.NewInternalServerError("DATABASE_ERROR", "Error updating profile image URL", err)
+		return utils.NewInternalServerError("DATABASE_ERROR", "Error updating profile image", err)
 	}
 
 	return nil
 }
+
+// GetReferencedProfileImageKeys returns every profile image key a customer still points at.
+func (repo *skyCustomerRepository) GetReferencedProfileImageKeys(ctx context.Context) (map[string]bool, error) {
+	rows, err := repo.db.Query(ctx, "SELECT profile_img FROM customertable WHERE profile_img IS NOT NULL AND profile_img <> ''")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch profile image keys")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching profile image keys", err)
+	}
+	defer rows.Close()
+
+	keys := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Error().Err(err).Msg("Failed to scan profile image key")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching profile image keys", err)
+		}
+		keys[key] = true
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to iterate profile image keys")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching profile image keys", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteCustomerAccount removes a customer and everything that cascades from usertable, in one
+// transaction. Their bookings are kept for revenue reporting: each is moved to a new
+// admin_booked_customer row holding the placeholder name and number, the same shape as a walk-in
+// booking. It returns the number of bookings anonymized this way, and refuses if the wallet is
+// not empty.
+func (repo *skyCustomerRepository) DeleteCustomerAccount(ctx context.Context, username, placeholderName, placeholderNumber string) (int, error) {
+	tx, err := repo.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin account deletion transaction")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var email string
+	if err := tx.QueryRow(ctx, "SELECT email FROM customertable WHERE username = $1 FOR UPDATE", username).Scan(&email); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No customer found with username: %s", username), nil)
+		}
+		log.Error().Err(err).Str("username", username).Msg("Failed to lock customer for deletion")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching customer", err)
+	}
+
+	// Lock the wallet too, so a top-up or transfer cannot land between the caller's balance
+	// check and the delete.
+	var walletEmpty bool
+	err = tx.QueryRow(ctx, "SELECT balance = 0 FROM customer_wallet WHERE username = $1 FOR UPDATE", username).Scan(&walletEmpty)
+	if err != nil && err != pgx.ErrNoRows {
+		log.Error().Err(err).Str("username", username).Msg("Failed to lock wallet for account deletion")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching wallet", err)
+	}
+	if err == nil && !walletEmpty {
+		return 0, utils.NewBadRequestError("WALLET_BALANCE_NOT_ZERO", "Wallet balance must be used or refunded before the account can be deleted", nil)
+	}
+
+	anonymizeQuery := `
+		WITH anonymized AS (
+			INSERT INTO admin_booked_customer (name, number, booking_id)
+			SELECT $2, $3, id FROM booking WHERE customer_username = $1
+			RETURNING id, booking_id
+		)
+		UPDATE booking b
+		SET customer_id = a.id, customer_username = NULL
+		FROM anonymized a
+		WHERE b.id = a.booking_id
+	`
+	tag, err := tx.Exec(ctx, anonymizeQuery, username, placeholderName, placeholderNumber)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to anonymize customer bookings")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to anonymize bookings", err)
+	}
+
+	cleanup := []struct {
+		query string
+		arg   string
+	}{
+		{"UPDATE booking_audit_event SET actor = NULL WHERE actor = $1", username},
+		{"UPDATE wallet_transaction SET counterparty_username = NULL WHERE counterparty_username = $1", username},
+		// Completed transfers stay for the other party; the foreign keys set the deleted
+		// username to NULL. A pending transfer can no longer complete, so it is expired.
+		{"UPDATE wallet_transfer SET status = 'EXPIRED' WHERE status = 'PENDING' AND (sender_username = $1 OR recipient_username = $1)", username},
+		{"DELETE FROM password_reset_tokens WHERE email = $1", email},
+		{"DELETE FROM usertable WHERE username = $1", username},
+	}
+	for _, step := range cleanup {
+		if _, err := tx.Exec(ctx, step.query, step.arg); err != nil {
+			log.Error().Err(err).Str("username", username).Str("query", step.query).Msg("Failed to delete customer data")
+			return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to delete customer data", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to commit account deletion")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit account deletion", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
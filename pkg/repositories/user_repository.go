@@ -13,8 +13,8 @@ import (
 type UserRepository interface {
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
 	Create(ctx context.Context, user *models.User) error
-	SavePassword(ctx context.Context, username, password string) error
-	FindByUsernameinPasswordHistory(ctx context.Context, username string) (*models.PasswordHistory, error)
+	UpdatePassword(ctx context.Context, username, passwordHash string, historyDepth int) error
+	GetPasswordHistory(ctx context.Context, username string, limit int) ([]models.PasswordHistory, error)
 	SavePasswordHistory(ctx context.Context, passwordHistory *models.PasswordHistory) error
 }
 
@@ -29,7 +29,7 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 }
 
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
-	query := `SELECT id, username, password, role, created_at FROM usertable WHERE username = $1`
+	query := `SELECT id, username, password, role, created_at, password_changed_at FROM usertable WHERE username = $1`
 
 	var user models.User
 	err := r.db.QueryRow(ctx, query, username).Scan(
@@ -38,6 +38,7 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 		&user.Password,
 		&user.Role,
 		&user.CreatedAt,
+		&user.PasswordChangedAt,
 	)
 
 	if err != nil {
@@ -64,55 +65,87 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-func (r *userRepository) SavePassword(ctx context.Context, username, password string) error {
-	query := `UPDATE usertable SET password = $1 WHERE username = $2`
+// UpdatePassword sets a new password, records it in the password history and trims the
+// history to the newest historyDepth entries, all in one transaction.
+func (r *userRepository) UpdatePassword(ctx context.Context, username, passwordHash string, historyDepth int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to begin password update transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
 
-	_, err := r.db.Exec(ctx, query, password, username)
+	_, err = tx.Exec(ctx, `UPDATE usertable SET password = $1, password_changed_at = CURRENT_TIMESTAMP WHERE username = $2`, passwordHash, username)
 	if err != nil {
 		log.Error().Err(err).Str("username", username).Msg("Failed to save password")
 		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to save password", err)
 	}
 
+	_, err = tx.Exec(ctx, `INSERT INTO password_history (username, password_hash) VALUES ($1, $2)`, username, passwordHash)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to save password history")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Error saving password history", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM password_history
+		WHERE username = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE username = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)`, username, historyDepth)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to trim password history")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Error saving password history", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to commit password update")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to save password", err)
+	}
+
 	return nil
 }
 
-func (r *userRepository) FindByUsernameinPasswordHistory(ctx context.Context, username string) (*models.PasswordHistory, error) {
-	query := `SELECT id, username, previous_password_1, previous_password_2, previous_password_3 
-              FROM password_history WHERE username = $1`
-
-	var history models.PasswordHistory
-	err := r.db.QueryRow(ctx, query, username).Scan(
-		&history.ID,
-		&history.Username,
-		&history.PreviousPassword1,
-		&history.PreviousPassword2,
-		&history.PreviousPassword3,
-	)
+// GetPasswordHistory returns up to limit of the user's most recent passwords, newest first.
+func (r *userRepository) GetPasswordHistory(ctx context.Context, username string, limit int) ([]models.PasswordHistory, error) {
+	query := `SELECT id, username, password_hash, created_at
+              FROM password_history
+              WHERE username = $1
+              ORDER BY created_at DESC, id DESC
+              LIMIT $2`
 
+	rows, err := r.db.Query(ctx, query, username, limit)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
 		log.Error().Err(err).Str("username", username).Msg("Error finding password history")
 		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error finding password history", err)
 	}
+	defer rows.Close()
+
+	history := make([]models.PasswordHistory, 0, limit)
+	for rows.Next() {
+		var entry models.PasswordHistory
+		if err := rows.Scan(&entry.ID, &entry.Username, &entry.PasswordHash, &entry.CreatedAt); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Error scanning password history")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error finding password history", err)
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Error iterating password history")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error finding password history", err)
+	}
 
-	return &history, nil
+	return history, nil
 }
 
 func (r *userRepository) SavePasswordHistory(ctx context.Context, history *models.PasswordHistory) error {
-	query := `INSERT INTO password_history (username, previous_password_1, previous_password_2, previous_password_3) 
-              VALUES ($1, $2, $3, $4) 
-              ON CONFLICT (username) DO UPDATE 
-              SET previous_password_1 = $2, previous_password_2 = $3, previous_password_3 = $4
-              RETURNING id`
-
-	err := r.db.QueryRow(ctx, query,
-		history.Username,
-		history.PreviousPassword1,
-		history.PreviousPassword2,
-		history.PreviousPassword3).Scan(&history.ID)
+	query := `INSERT INTO password_history (username, password_hash)
+              VALUES ($1, $2)
+              RETURNING id, created_at`
 
+	err := r.db.QueryRow(ctx, query, history.Username, history.PasswordHash).Scan(&history.ID, &history.CreatedAt)
 	if err != nil {
 		log.Error().Err(err).Str("username", history.Username).Msg("Failed to save password history")
 		return utils.NewInternalServerError("DATABASE_ERROR", "Error saving password history", err)
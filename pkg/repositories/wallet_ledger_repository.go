@@ -0,0 +1,653 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	checkViolationCode  = "23514"
+	uniqueViolationCode = "23505"
+)
+
+type WalletLedgerRepository interface {
+	PostEntry(ctx context.Context, entry *models.LedgerJournalEntry) error
+	GetWalletJournalBalance(ctx context.Context, walletId int64) (decimal.Decimal, error)
+	GetWalletJournalBalanceAsOf(ctx context.Context, walletId int64, asOf time.Time) (decimal.Decimal, error)
+	GetLedgerStartTime(ctx context.Context) (*time.Time, error)
+	FindMismatchedWallets(ctx context.Context) ([]models.WalletReconciliation, error)
+	ResyncCachedBalance(ctx context.Context, walletId int64) error
+	PostCreditGrant(ctx context.Context, grant *models.WalletCreditGrant, entry *models.LedgerJournalEntry) error
+	FindExpiredCreditGrants(ctx context.Context, now time.Time) ([]models.WalletCreditGrant, error)
+	ExpireCreditGrant(ctx context.Context, grantId int64, reference string) (decimal.Decimal, error)
+	// PostTransfer posts a confirmed wallet transfer and marks it COMPLETED in one transaction.
+	// The sender's wallet row is locked first, so concurrent transfers are counted against the
+	// daily limit one at a time.
+	PostTransfer(ctx context.Context, transferId int64, senderWalletId int64, senderUsername string, since time.Time, dailyLimit decimal.Decimal, entry *models.LedgerJournalEntry) error
+}
+
+type walletLedgerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWalletLedgerRepository(db *pgxpool.Pool) WalletLedgerRepository {
+	return &walletLedgerRepository{db: db}
+}
+
+// PostEntry writes a balanced journal entry and, in the same database transaction,
+// moves the cached balance of every wallet it touches and mirrors the movement into
+// wallet_transaction so the customer-facing history stays in step with the journal.
+func (r *walletLedgerRepository) PostEntry(ctx context.Context, entry *models.LedgerJournalEntry) error {
+	if err := validateLedgerEntry(entry); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin ledger transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.postEntryTx(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("reference", entry.Reference).Msg("Failed to commit journal entry")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit journal entry", err)
+	}
+
+	return nil
+}
+
+func (r *walletLedgerRepository) postEntryTx(ctx context.Context, tx pgx.Tx, entry *models.LedgerJournalEntry) error {
+	entryQuery := `
+		INSERT INTO ledger_journal_entry (reference, entry_type, description, booking_id, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err := tx.QueryRow(ctx, entryQuery,
+		entry.Reference,
+		entry.EntryType,
+		entry.Description,
+		entry.BookingID,
+		entry.CreatedBy,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return utils.NewBadRequestError("DUPLICATE_TRANSACTION", "This transaction has already been posted", nil)
+		}
+		log.Error().Err(err).Str("reference", entry.Reference).Msg("Failed to create journal entry")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to create journal entry", err)
+	}
+
+	var touchedWallets []int64
+	for i := range entry.Postings {
+		posting := &entry.Postings[i]
+		posting.JournalEntryID = entry.ID
+
+		accountId, err := r.resolveAccountId(ctx, tx, posting)
+		if err != nil {
+			return err
+		}
+
+		postingQuery := `
+			INSERT INTO ledger_posting (journal_entry_id, account_id, direction, amount)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`
+		if err := tx.QueryRow(ctx, postingQuery, entry.ID, accountId, posting.Direction, posting.Amount).Scan(&posting.ID); err != nil {
+			log.Error().Err(err).Str("reference", entry.Reference).Msg("Failed to create ledger posting")
+			return utils.NewInternalServerError("DATABASE_ERROR", "Failed to create ledger posting", err)
+		}
+
+		if posting.WalletID == nil {
+			continue
+		}
+
+		if err := r.applyWalletPosting(ctx, tx, entry, posting); err != nil {
+			return err
+		}
+		touchedWallets = append(touchedWallets, *posting.WalletID)
+	}
+
+	for _, walletId := range touchedWallets {
+		r.checkWalletConsistency(ctx, tx, walletId)
+	}
+
+	return nil
+}
+
+func (r *walletLedgerRepository) resolveAccountId(ctx context.Context, tx pgx.Tx, posting *models.LedgerPosting) (int64, error) {
+	var accountId int64
+	var err error
+	if posting.WalletID != nil {
+		err = tx.QueryRow(ctx, `SELECT id FROM ledger_account WHERE wallet_id = $1`, *posting.WalletID).Scan(&accountId)
+	} else {
+		err = tx.QueryRow(ctx, `SELECT id FROM ledger_account WHERE code = $1`, posting.AccountCode).Scan(&accountId)
+	}
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, utils.NewInternalServerError("LEDGER_ACCOUNT_NOT_FOUND", "Ledger account not found", err)
+		}
+		log.Error().Err(err).Str("accountCode", posting.AccountCode).Msg("Failed to resolve ledger account")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to resolve ledger account", err)
+	}
+
+	return accountId, nil
+}
+
+func (r *walletLedgerRepository) applyWalletPosting(ctx context.Context, tx pgx.Tx, entry *models.LedgerJournalEntry, posting *models.LedgerPosting) error {
+	delta := posting.Amount
+	transactionType := "ADD"
+	if posting.Direction == "DEBIT" {
+		delta = posting.Amount.Neg()
+		transactionType = "DEDUCT"
+	}
+	if posting.TransactionType != "" {
+		transactionType = posting.TransactionType
+	}
+
+	// The journal entry's timestamp is used so wallet history and journal balances agree on
+	// which day a movement belongs to.
+	now := entry.CreatedAt
+	balanceQuery := `
+		UPDATE customer_wallet
+		SET balance = balance + $1, updated_at = $2
+		WHERE id = $3
+		RETURNING username, balance
+	`
+	var username string
+	var balance decimal.Decimal
+	if err := tx.QueryRow(ctx, balanceQuery, delta, now, *posting.WalletID).Scan(&username, &balance); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == checkViolationCode {
+			return utils.NewBadRequestError("INSUFFICIENT_BALANCE", "Insufficient wallet balance", nil)
+		}
+		if err == pgx.ErrNoRows {
+			return utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found", nil)
+		}
+		log.Error().Err(err).Int64("walletId", *posting.WalletID).Msg("Failed to update cached wallet balance")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update wallet balance", err)
+	}
+
+	txnQuery := `
+		INSERT INTO wallet_transaction
+			(wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type, counterparty_username, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	if _, err := tx.Exec(ctx, txnQuery,
+		*posting.WalletID,
+		username,
+		entry.BookingID,
+		entry.Reference,
+		posting.Amount,
+		now,
+		transactionType,
+		posting.CounterpartyUsername,
+		posting.Note,
+	); err != nil {
+		log.Error().Err(err).Int64("walletId", *posting.WalletID).Msg("Failed to record wallet transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to record wallet transaction", err)
+	}
+
+	// Expiring credits cannot leave the wallet through a transfer, otherwise they would become
+	// permanent money in the recipient's wallet and escape the expiry clawback.
+	if transactionType == "TRANSFER_OUT" {
+		return r.checkTransferableBalance(ctx, tx, *posting.WalletID, balance)
+	}
+
+	if posting.Direction == "DEBIT" && transactionType != "CREDIT_EXPIRY" {
+		return r.consumeCreditGrants(ctx, tx, *posting.WalletID, posting.Amount)
+	}
+
+	return nil
+}
+
+// consumeCreditGrants draws spending from expiring goodwill credits first, soonest
+// expiry first, so only the unspent part of a credit is clawed back when it expires.
+func (r *walletLedgerRepository) consumeCreditGrants(ctx context.Context, tx pgx.Tx, walletId int64, amount decimal.Decimal) error {
+	query := `
+		SELECT id, remaining_amount
+		FROM wallet_credit_grant
+		WHERE wallet_id = $1 AND expires_at IS NOT NULL AND expired_at IS NULL AND remaining_amount > 0
+		ORDER BY expires_at
+		FOR UPDATE
+	`
+	rows, err := tx.Query(ctx, query, walletId)
+	if err != nil {
+		log.Error().Err(err).Int64("walletId", walletId).Msg("Failed to query active credit grants")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet credits", err)
+	}
+
+	type grantBalance struct {
+		id        int64
+		remaining decimal.Decimal
+	}
+	var grants []grantBalance
+	for rows.Next() {
+		var g grantBalance
+		if err := rows.Scan(&g.id, &g.remaining); err != nil {
+			rows.Close()
+			log.Error().Err(err).Msg("Error scanning credit grant row")
+			return utils.NewInternalServerError("DATABASE_ERROR", "Failed to read wallet credits", err)
+		}
+		grants = append(grants, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over credit grant rows")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to process wallet credits", err)
+	}
+
+	outstanding := amount
+	for _, g := range grants {
+		if outstanding.Cmp(decimal.Zero) != 1 {
+			break
+		}
+		consumed := g.remaining.Min(outstanding)
+		if _, err := tx.Exec(ctx, `UPDATE wallet_credit_grant SET remaining_amount = remaining_amount - $1 WHERE id = $2`, consumed, g.id); err != nil {
+			log.Error().Err(err).Int64("grantId", g.id).Msg("Failed to consume credit grant")
+			return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update wallet credits", err)
+		}
+		outstanding, _ = outstanding.Sub(consumed)
+	}
+
+	return nil
+}
+
+// checkTransferableBalance rejects a transfer that leaves the wallet with less than its unspent
+// expiring credits.
+func (r *walletLedgerRepository) checkTransferableBalance(ctx context.Context, tx pgx.Tx, walletId int64, balance decimal.Decimal) error {
+	query := `
+		SELECT COALESCE(SUM(remaining_amount), 0)
+		FROM wallet_credit_grant
+		WHERE wallet_id = $1 AND expires_at IS NOT NULL AND expired_at IS NULL
+	`
+	var expiringCredit decimal.Decimal
+	if err := tx.QueryRow(ctx, query, walletId).Scan(&expiringCredit); err != nil {
+		log.Error().Err(err).Int64("walletId", walletId).Msg("Failed to sum expiring credit grants")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet credits", err)
+	}
+
+	if balance.Cmp(expiringCredit) < 0 {
+		return utils.NewBadRequestError("INSUFFICIENT_TRANSFERABLE_BALANCE", "Expiring goodwill credits cannot be transferred", nil)
+	}
+
+	return nil
+}
+
+// checkWalletConsistency compares the cached balance with the journal. A mismatch
+// means the wallet drifted before this entry; it is logged for reconciliation
+// rather than blocking the customer.
+func (r *walletLedgerRepository) checkWalletConsistency(ctx context.Context, tx pgx.Tx, walletId int64) {
+	query := `
+		SELECT cw.balance, COALESCE(SUM(CASE WHEN lp.direction = 'CREDIT' THEN lp.amount ELSE -lp.amount END), 0)
+		FROM customer_wallet cw
+		LEFT JOIN ledger_account la ON la.wallet_id = cw.id
+		LEFT JOIN ledger_posting lp ON lp.account_id = la.id
+		WHERE cw.id = $1
+		GROUP BY cw.balance
+	`
+	var cached, journal decimal.Decimal
+	if err := tx.QueryRow(ctx, query, walletId).Scan(&cached, &journal); err != nil {
+		log.Error().Err(err).Int64("walletId", walletId).Msg("Failed to run wallet consistency check")
+		return
+	}
+
+	if cached.Cmp(journal) != 0 {
+		log.Error().
+			Int64("walletId", walletId).
+			Str("cachedBalance", cached.String()).
+			Str("journalBalance", journal.String()).
+			Msg("Wallet cached balance disagrees with journal")
+	}
+}
+
+func (r *walletLedgerRepository) GetWalletJournalBalance(ctx context.Context, walletId int64) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN lp.direction = 'CREDIT' THEN lp.amount ELSE -lp.amount END), 0)
+		FROM ledger_account la
+		LEFT JOIN ledger_posting lp ON lp.account_id = la.id
+		WHERE la.wallet_id = $1
+	`
+	var balance decimal.Decimal
+	if err := r.db.QueryRow(ctx, query, walletId).Scan(&balance); err != nil {
+		log.Error().Err(err).Int64("walletId", walletId).Msg("Failed to compute journal balance")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to compute journal balance", err)
+	}
+
+	return balance, nil
+}
+
+// GetWalletJournalBalanceAsOf returns the wallet balance from journal entries created before asOf.
+func (r *walletLedgerRepository) GetWalletJournalBalanceAsOf(ctx context.Context, walletId int64, asOf time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN lp.direction = 'CREDIT' THEN lp.amount ELSE -lp.amount END), 0)
+		FROM ledger_account la
+		JOIN ledger_posting lp ON lp.account_id = la.id
+		JOIN ledger_journal_entry je ON je.id = lp.journal_entry_id
+		WHERE la.wallet_id = $1 AND je.created_at < $2
+	`
+	var balance decimal.Decimal
+	if err := r.db.QueryRow(ctx, query, walletId, asOf).Scan(&balance); err != nil {
+		log.Error().Err(err).Int64("walletId", walletId).Time("asOf", asOf).Msg("Failed to compute journal balance")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to compute journal balance", err)
+	}
+
+	return balance, nil
+}
+
+// GetLedgerStartTime returns when the first journal entry was posted, which for an existing
+// database is when the opening balances were migrated in, or nil if the journal is empty.
+func (r *walletLedgerRepository) GetLedgerStartTime(ctx context.Context) (*time.Time, error) {
+	var startedAt time.Time
+	err := r.db.QueryRow(ctx, `SELECT created_at FROM ledger_journal_entry ORDER BY id LIMIT 1`).Scan(&startedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Failed to find first journal entry")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to find when the ledger started", err)
+	}
+
+	return &startedAt, nil
+}
+
+func (r *walletLedgerRepository) FindMismatchedWallets(ctx context.Context) ([]models.WalletReconciliation, error) {
+	query := `
+		SELECT cw.id, cw.username, cw.balance,
+			COALESCE(SUM(CASE WHEN lp.direction = 'CREDIT' THEN lp.amount ELSE -lp.amount END), 0) AS journal_balance
+		FROM customer_wallet cw
+		LEFT JOIN ledger_account la ON la.wallet_id = cw.id
+		LEFT JOIN ledger_posting lp ON lp.account_id = la.id
+		GROUP BY cw.id, cw.username, cw.balance
+		HAVING cw.balance <> COALESCE(SUM(CASE WHEN lp.direction = 'CREDIT' THEN lp.amount ELSE -lp.amount END), 0)
+		ORDER BY cw.id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query wallet reconciliation")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to reconcile wallets", err)
+	}
+	defer rows.Close()
+
+	var mismatches []models.WalletReconciliation
+	for rows.Next() {
+		var m models.WalletReconciliation
+		if err := rows.Scan(&m.WalletID, &m.Username, &m.CachedBalance, &m.JournalBalance); err != nil {
+			log.Error().Err(err).Msg("Error scanning wallet reconciliation row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read reconciliation data", err)
+		}
+		mismatches = append(mismatches, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over wallet reconciliation rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process reconciliation data", err)
+	}
+
+	return mismatches, nil
+}
+
+func (r *walletLedgerRepository) ResyncCachedBalance(ctx context.Context, walletId int64) error {
+	query := `
+		UPDATE customer_wallet cw
+		SET balance = (
+			SELECT COALESCE(SUM(CASE WHEN lp.direction = 'CREDIT' THEN lp.amount ELSE -lp.amount END), 0)
+			FROM ledger_account la
+			LEFT JOIN ledger_posting lp ON lp.account_id = la.id
+			WHERE la.wallet_id = cw.id
+		), updated_at = $1
+		WHERE cw.id = $2
+	`
+	if _, err := r.db.Exec(ctx, query, time.Now(), walletId); err != nil {
+		log.Error().Err(err).Int64("walletId", walletId).Msg("Failed to resync cached wallet balance")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to resync wallet balance", err)
+	}
+
+	return nil
+}
+
+func (r *walletLedgerRepository) PostCreditGrant(ctx context.Context, grant *models.WalletCreditGrant, entry *models.LedgerJournalEntry) error {
+	if err := validateLedgerEntry(entry); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin ledger transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.postEntryTx(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO wallet_credit_grant (wallet_id, reference, amount, remaining_amount, reason, granted_by, expires_at)
+		VALUES ($1, $2, $3, $3, $4, $5, $6)
+		RETURNING id, remaining_amount, created_at
+	`
+	err = tx.QueryRow(ctx, query,
+		grant.WalletID,
+		grant.Reference,
+		grant.Amount,
+		grant.Reason,
+		grant.GrantedBy,
+		grant.ExpiresAt,
+	).Scan(&grant.ID, &grant.RemainingAmount, &grant.CreatedAt)
+	if err != nil {
+		log.Error().Err(err).Int64("walletId", grant.WalletID).Msg("Failed to create credit grant")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to create wallet credit", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Str("reference", grant.Reference).Msg("Failed to commit credit grant")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit wallet credit", err)
+	}
+
+	return nil
+}
+
+func (r *walletLedgerRepository) FindExpiredCreditGrants(ctx context.Context, now time.Time) ([]models.WalletCreditGrant, error) {
+	query := `
+		SELECT id, wallet_id, reference, amount, remaining_amount, reason, granted_by, expires_at, expired_at, created_at
+		FROM wallet_credit_grant
+		WHERE expires_at IS NOT NULL AND expires_at <= $1 AND expired_at IS NULL
+		ORDER BY expires_at
+	`
+
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query expired credit grants")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve expired wallet credits", err)
+	}
+	defer rows.Close()
+
+	var grants []models.WalletCreditGrant
+	for rows.Next() {
+		var g models.WalletCreditGrant
+		if err := rows.Scan(
+			&g.ID,
+			&g.WalletID,
+			&g.Reference,
+			&g.Amount,
+			&g.RemainingAmount,
+			&g.Reason,
+			&g.GrantedBy,
+			&g.ExpiresAt,
+			&g.ExpiredAt,
+			&g.CreatedAt,
+		); err != nil {
+			log.Error().Err(err).Msg("Error scanning credit grant row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read wallet credit data", err)
+		}
+		grants = append(grants, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over credit grant rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process wallet credit data", err)
+	}
+
+	return grants, nil
+}
+
+// ExpireCreditGrant claws back the unspent part of an expired credit, capped at the
+// wallet's current balance, and marks the grant expired. It returns the amount removed.
+func (r *walletLedgerRepository) ExpireCreditGrant(ctx context.Context, grantId int64, reference string) (decimal.Decimal, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin ledger transaction")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	lockQuery := `
+		SELECT g.wallet_id, g.remaining_amount, g.reason, cw.balance
+		FROM wallet_credit_grant g
+		JOIN customer_wallet cw ON cw.id = g.wallet_id
+		WHERE g.id = $1 AND g.expired_at IS NULL
+		FOR UPDATE OF g, cw
+	`
+	var walletId int64
+	var remaining, balance decimal.Decimal
+	var reason string
+	if err := tx.QueryRow(ctx, lockQuery, grantId).Scan(&walletId, &remaining, &reason, &balance); err != nil {
+		if err == pgx.ErrNoRows {
+			return decimal.Zero, nil
+		}
+		log.Error().Err(err).Int64("grantId", grantId).Msg("Failed to lock credit grant")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet credit", err)
+	}
+
+	clawback := remaining.Min(balance)
+	if clawback.Cmp(decimal.Zero) == 1 {
+		note := "Expired credit: " + reason
+		entry := &models.LedgerJournalEntry{
+			Reference:   reference,
+			EntryType:   "CREDIT_EXPIRY",
+			Description: note,
+			Postings: []models.LedgerPosting{
+				{WalletID: &walletId, Direction: "DEBIT", Amount: clawback, TransactionType: "CREDIT_EXPIRY", Note: &note},
+				{AccountCode: constants.LEDGER_ACCOUNT_GOODWILL, Direction: "CREDIT", Amount: clawback},
+			},
+		}
+		if err := r.postEntryTx(ctx, tx, entry); err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE wallet_credit_grant SET remaining_amount = 0, expired_at = $1 WHERE id = $2`, time.Now(), grantId); err != nil {
+		log.Error().Err(err).Int64("grantId", grantId).Msg("Failed to mark credit grant expired")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to expire wallet credit", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Int64("grantId", grantId).Msg("Failed to commit credit expiry")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit wallet credit expiry", err)
+	}
+
+	return clawback, nil
+}
+
+func (r *walletLedgerRepository) PostTransfer(ctx context.Context, transferId int64, senderWalletId int64, senderUsername string, since time.Time, dailyLimit decimal.Decimal, entry *models.LedgerJournalEntry) error {
+	if err := validateLedgerEntry(entry); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin ledger transaction")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to start database transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM customer_wallet WHERE id = $1 FOR UPDATE`, senderWalletId); err != nil {
+		log.Error().Err(err).Int64("walletId", senderWalletId).Msg("Failed to lock sender wallet")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to lock wallet", err)
+	}
+
+	var sentToday decimal.Decimal
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM wallet_transfer
+		WHERE sender_username = $1 AND status = 'COMPLETED' AND completed_at >= $2
+	`, senderUsername, since).Scan(&sentToday)
+	if err != nil {
+		log.Error().Err(err).Str("sender", senderUsername).Msg("Failed to sum completed wallet transfers")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to check transfer limits", err)
+	}
+
+	total, err := sentToday.Add(entry.Postings[0].Amount)
+	if err != nil || total.Cmp(dailyLimit) > 0 {
+		return utils.NewBadRequestError("DAILY_TRANSFER_LIMIT_EXCEEDED", "This transfer would exceed the daily limit of 10000", nil)
+	}
+
+	if err := r.postEntryTx(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE wallet_transfer
+		SET status = 'COMPLETED', completed_at = $1
+		WHERE id = $2 AND status = 'PENDING'
+	`, time.Now(), transferId)
+	if err != nil {
+		log.Error().Err(err).Int64("transferId", transferId).Msg("Failed to complete wallet transfer")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update wallet transfer", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.NewBadRequestError("INVALID_TRANSFER_STATUS", "Transfer is not awaiting confirmation", nil)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Int64("transferId", transferId).Msg("Failed to commit wallet transfer")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to commit wallet transfer", err)
+	}
+
+	return nil
+}
+
+func validateLedgerEntry(entry *models.LedgerJournalEntry) error {
+	if len(entry.Postings) < 2 {
+		return utils.NewInternalServerError("UNBALANCED_ENTRY", "A journal entry needs at least two postings", nil)
+	}
+
+	debits, credits := decimal.Zero, decimal.Zero
+	for _, posting := range entry.Postings {
+		if posting.Amount.Cmp(decimal.Zero) != 1 {
+			return utils.NewBadRequestError("INVALID_AMOUNT", "Amount must be positive", nil)
+		}
+		switch posting.Direction {
+		case "DEBIT":
+			debits, _ = debits.Add(posting.Amount)
+		case "CREDIT":
+			credits, _ = credits.Add(posting.Amount)
+		default:
+			return utils.NewInternalServerError("INVALID_POSTING", "Posting direction must be DEBIT or CREDIT", nil)
+		}
+	}
+
+	if debits.Cmp(credits) != 0 {
+		return utils.NewInternalServerError("UNBALANCED_ENTRY", "Journal entry debits and credits do not match", nil)
+	}
+
+	return nil
+}
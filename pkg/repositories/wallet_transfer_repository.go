@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type WalletTransferRepository interface {
+	Create(ctx context.Context, transfer *models.WalletTransfer) error
+	FindByIdAndSender(ctx context.Context, id int64, senderUsername string) (*models.WalletTransfer, error)
+	UpdateStatus(ctx context.Context, id int64, status string) error
+	GetCompletedTotalSince(ctx context.Context, senderUsername string, since time.Time) (decimal.Decimal, error)
+}
+
+type walletTransferRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWalletTransferRepository(db *pgxpool.Pool) WalletTransferRepository {
+	return &walletTransferRepository{db: db}
+}
+
+func (r *walletTransferRepository) Create(ctx context.Context, transfer *models.WalletTransfer) error {
+	query := `
+		INSERT INTO wallet_transfer (reference, sender_username, recipient_username, amount, note, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		transfer.Reference,
+		transfer.SenderUsername,
+		transfer.RecipientUsername,
+		transfer.Amount,
+		transfer.Note,
+		transfer.Status,
+		transfer.ExpiresAt,
+	).Scan(&transfer.ID, &transfer.CreatedAt)
+
+	if err != nil {
+		log.Error().Err(err).Str("sender", transfer.SenderUsername).Msg("Failed to create wallet transfer")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to create wallet transfer", err)
+	}
+
+	return nil
+}
+
+func (r *walletTransferRepository) FindByIdAndSender(ctx context.Context, id int64, senderUsername string) (*models.WalletTransfer, error) {
+	query := `
+		SELECT id, reference, sender_username, COALESCE(recipient_username, ''), amount, note, status, expires_at, created_at, completed_at
+		FROM wallet_transfer
+		WHERE id = $1 AND sender_username = $2
+	`
+
+	var transfer models.WalletTransfer
+	err := r.db.QueryRow(ctx, query, id, senderUsername).Scan(
+		&transfer.ID,
+		&transfer.Reference,
+		&transfer.SenderUsername,
+		&transfer.RecipientUsername,
+		&transfer.Amount,
+		&transfer.Note,
+		&transfer.Status,
+		&transfer.ExpiresAt,
+		&transfer.CreatedAt,
+		&transfer.CompletedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		log.Error().Err(err).Int64("id", id).Msg("Failed to find wallet transfer")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet transfer", err)
+	}
+
+	return &transfer, nil
+}
+
+func (r *walletTransferRepository) UpdateStatus(ctx context.Context, id int64, status string) error {
+	query := `
+		UPDATE wallet_transfer
+		SET status = $1, completed_at = CASE WHEN $1 = 'COMPLETED' THEN $2 ELSE completed_at END
+		WHERE id = $3
+	`
+
+	_, err := r.db.Exec(ctx, query, status, time.Now(), id)
+	if err != nil {
+		log.Error().Err(err).Int64("id", id).Str("status", status).Msg("Failed to update wallet transfer status")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update wallet transfer", err)
+	}
+
+	return nil
+}
+
+func (r *walletTransferRepository) GetCompletedTotalSince(ctx context.Context, senderUsername string, since time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM wallet_transfer
+		WHERE sender_username = $1 AND status = 'COMPLETED' AND completed_at >= $2
+	`
+
+	var total decimal.Decimal
+	if err := r.db.QueryRow(ctx, query, senderUsername, since).Scan(&total); err != nil {
+		log.Error().Err(err).Str("sender", senderUsername).Msg("Failed to sum completed wallet transfers")
+		return decimal.Zero, utils.NewInternalServerError("DATABASE_ERROR", "Failed to check transfer limits", err)
+	}
+
+	return total, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"time"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
@@ -13,9 +14,10 @@ import (
 type WalletTransactionRepository interface {
 	AddWalletTransaction(ctx context.Context, txn *models.WalletTransaction) error
 	GetWalletTransactionsByWalletId(ctx context.Context, walletId int64) ([]*models.WalletTransaction, error)
-	GetWalletTransactionsForUser(ctx context.Context, username string) ([]*models.WalletTransaction, error)
+	GetWalletTransactionsPage(ctx context.Context, filter models.WalletTransactionFilter) ([]*models.WalletTransaction, error)
 	GetWalletTransactionsForBooking(ctx context.Context, bookingId int64) ([]*models.WalletTransaction, error)
 	GetLatestWalletTransactionForUser(ctx context.Context, username string) (*models.WalletTransaction, error)
+	GetWalletTransactionsForUserBetween(ctx context.Context, username string, from, to time.Time) ([]*models.WalletTransaction, error)
 }
 
 type walletTransactionRepository struct {
@@ -29,20 +31,20 @@ func NewWalletTransactionRepository(db *pgxpool.Pool) WalletTransactionRepositor
 func (r *walletTransactionRepository) AddWalletTransaction(ctx context.Context, txn *models.WalletTransaction) error {
 	query := `
 		INSERT INTO wallet_transaction
-			(wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+			(wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type, counterparty_username, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
 	now := time.Now()
 	txn.Timestamp = now
 	return r.db.QueryRow(ctx, query,
-		txn.WalletID, txn.Username, txn.BookingID, txn.TransactionID, txn.Amount, txn.Timestamp, txn.TransactionType,
+		txn.WalletID, txn.Username, txn.BookingID, txn.TransactionID, txn.Amount, txn.Timestamp, txn.TransactionType, txn.CounterpartyUsername, txn.Note,
 	).Scan(&txn.ID)
 }
 
 func (r *walletTransactionRepository) GetWalletTransactionsByWalletId(ctx context.Context, walletId int64) ([]*models.WalletTransaction, error) {
     query := `
-        SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type
+        SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type, counterparty_username, note
         FROM wallet_transaction
         WHERE wallet_id = $1
         ORDER BY timestamp DESC
@@ -57,7 +59,7 @@ func (r *walletTransactionRepository) GetWalletTransactionsByWalletId(ctx contex
     for rows.Next() {
         var txn models.WalletTransaction
         var bookingID pgtype.Int8
-        err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType)
+        err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType, &txn.CounterpartyUsername, &txn.Note)
         if err != nil {
             return nil, err
         }
@@ -70,40 +72,71 @@ func (r *walletTransactionRepository) GetWalletTransactionsByWalletId(ctx contex
     return transactions, nil
 }
 
-func (r *walletTransactionRepository) GetWalletTransactionsForUser(ctx context.Context, username string) ([]*models.WalletTransaction, error) {
-    query := `
-        SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type
-        FROM wallet_transaction
-        WHERE username = $1
-        ORDER BY timestamp DESC
-    `
-    rows, err := r.db.Query(ctx, query, username)
-    if err != nil {
-        return nil, err
-    }
-    defer rows.Close()
-    
-    var transactions []*models.WalletTransaction
-    for rows.Next() {
-        var txn models.WalletTransaction
-        var bookingID pgtype.Int8
-        err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType)
-        if err != nil {
-            return nil, err
-        }
-        if bookingID.Valid {
-            id := bookingID.Int64
-            txn.BookingID = &id
-        }
-        transactions = append(transactions, &txn)
-    }
-    return transactions, nil
-}
+// GetWalletTransactionsPage returns one page of a customer's wallet transactions, newest
+// first. Transactions linked to a booking carry the booked show's movie ID.
+func (r *walletTransactionRepository) GetWalletTransactionsPage(ctx context.Context, filter models.WalletTransactionFilter) ([]*models.WalletTransaction, error) {
+	query := `
+		SELECT wt.id, wt.wallet_id, wt.username, wt.booking_id, wt.transaction_id, wt.amount, wt.timestamp,
+		       wt.transaction_type, wt.counterparty_username, wt.note, s.movie_id
+		FROM wallet_transaction wt
+		LEFT JOIN booking b ON b.id = wt.booking_id
+		LEFT JOIN show s ON s.id = b.show_id
+		WHERE wt.username = $1
+	`
+
+	args := []interface{}{filter.Username}
+	if len(filter.Types) > 0 {
+		args = append(args, filter.Types)
+		query += fmt.Sprintf(" AND wt.transaction_type::text = ANY($%d)", len(args))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		query += fmt.Sprintf(" AND wt.timestamp >= $%d", len(args))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		query += fmt.Sprintf(" AND wt.timestamp < $%d", len(args))
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.Time, filter.After.ID)
+		query += fmt.Sprintf(" AND (wt.timestamp, wt.id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY wt.timestamp DESC, wt.id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet transactions", err)
+	}
+	defer rows.Close()
+
+	transactions := []*models.WalletTransaction{}
+	for rows.Next() {
+		var txn models.WalletTransaction
+		var bookingID pgtype.Int8
+		err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp,
+			&txn.TransactionType, &txn.CounterpartyUsername, &txn.Note, &txn.MovieID)
+		if err != nil {
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read wallet transactions", err)
+		}
+		if bookingID.Valid {
+			id := bookingID.Int64
+			txn.BookingID = &id
+		}
+		transactions = append(transactions, &txn)
+	}
 
+	if err := rows.Err(); err != nil {
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process wallet transactions", err)
+	}
+
+	return transactions, nil
+}
 
 func (r *walletTransactionRepository) GetWalletTransactionsForBooking(ctx context.Context, bookingId int64) ([]*models.WalletTransaction, error) {
 	query := `
-		SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type
+		SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type, counterparty_username, note
 		FROM wallet_transaction
 		WHERE booking_id = $1
 		ORDER BY timestamp ASC
@@ -117,7 +150,7 @@ func (r *walletTransactionRepository) GetWalletTransactionsForBooking(ctx contex
 	for rows.Next() {
 		var txn models.WalletTransaction
 		var bookingID  pgtype.Int8
-		err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType)
+		err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType, &txn.CounterpartyUsername, &txn.Note)
 		if err != nil {
 			return nil, err
 		}
@@ -132,7 +165,7 @@ func (r *walletTransactionRepository) GetWalletTransactionsForBooking(ctx contex
 
 func (r *walletTransactionRepository) GetLatestWalletTransactionForUser(ctx context.Context, username string) (*models.WalletTransaction, error) {
 	query := `
-		SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type
+		SELECT id, wallet_id, username, booking_id, transaction_id, amount, timestamp, transaction_type, counterparty_username, note
 		FROM wallet_transaction
 		WHERE username = $1
 		ORDER BY timestamp DESC
@@ -141,7 +174,7 @@ func (r *walletTransactionRepository) GetLatestWalletTransactionForUser(ctx cont
 	var txn models.WalletTransaction
 	var bookingID  pgtype.Int8
 	err := r.db.QueryRow(ctx, query, username).Scan(
-		&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType,
+		&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType, &txn.CounterpartyUsername, &txn.Note,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -155,3 +188,43 @@ func (r *walletTransactionRepository) GetLatestWalletTransactionForUser(ctx cont
 	}
 	return &txn, nil
 }
+
+// GetWalletTransactionsForUserBetween returns the transactions whose journal entry was created
+// in [from, to), timestamped with the journal entry, so statement lines use the same clock as
+// the journal balances they are reconciled against.
+func (r *walletTransactionRepository) GetWalletTransactionsForUserBetween(ctx context.Context, username string, from, to time.Time) ([]*models.WalletTransaction, error) {
+	query := `
+		SELECT wt.id, wt.wallet_id, wt.username, wt.booking_id, wt.transaction_id, wt.amount, je.created_at,
+		       wt.transaction_type, wt.counterparty_username, wt.note
+		FROM wallet_transaction wt
+		JOIN ledger_journal_entry je ON je.reference = wt.transaction_id
+		WHERE wt.username = $1 AND je.created_at >= $2 AND je.created_at < $3
+		ORDER BY je.created_at ASC, wt.id ASC
+	`
+	rows, err := r.db.Query(ctx, query, username, from, to)
+	if err != nil {
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet transactions", err)
+	}
+	defer rows.Close()
+
+	var transactions []*models.WalletTransaction
+	for rows.Next() {
+		var txn models.WalletTransaction
+		var bookingID pgtype.Int8
+		err := rows.Scan(&txn.ID, &txn.WalletID, &txn.Username, &bookingID, &txn.TransactionID, &txn.Amount, &txn.Timestamp, &txn.TransactionType, &txn.CounterpartyUsername, &txn.Note)
+		if err != nil {
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to read wallet transaction data", err)
+		}
+		if bookingID.Valid {
+			id := bookingID.Int64
+			txn.BookingID = &id
+		}
+		transactions = append(transactions, &txn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to process wallet transaction data", err)
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,322 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+type WatchlistRepository interface {
+	Add(ctx context.Context, item *models.WatchlistItem) error
+	FindByUsername(ctx context.Context, username string) ([]models.WatchlistItem, error)
+	CountByUsername(ctx context.Context, username string) (int, error)
+	Remove(ctx context.Context, username, movieID string) (bool, error)
+	GetUpcomingShows(ctx context.Context, username string, limit int) ([]models.Show, error)
+	CreateAlertsForShow(ctx context.Context, show *models.Show) (int64, error)
+	// ClaimPendingAlerts returns undelivered alerts that are due, oldest first, and pushes their
+	// next attempt back by lease so no other instance sends them while they are being delivered.
+	ClaimPendingAlerts(ctx context.Context, maxAttempts, limit int, lease time.Duration) ([]models.WatchlistAlert, error)
+	MarkAlertsDelivered(ctx context.Context, alertIDs []int64) error
+	// RescheduleAlerts schedules another delivery attempt, backing off exponentially up to an hour.
+	RescheduleAlerts(ctx context.Context, alertIDs []int64) error
+	GetAlerts(ctx context.Context, username string, limit int) ([]models.WatchlistAlert, error)
+	MarkAlertsRead(ctx context.Context, username string) (int64, error)
+}
+
+type watchlistRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWatchlistRepository(db *pgxpool.Pool) WatchlistRepository {
+	return &watchlistRepository{db: db}
+}
+
+func (r *watchlistRepository) Add(ctx context.Context, item *models.WatchlistItem) error {
+	query := `
+		INSERT INTO watchlist (username, movie_id)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, item.Username, item.MovieId).Scan(&item.ID, &item.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return utils.NewBadRequestError("MOVIE_ALREADY_IN_WATCHLIST", "This movie is already on your watchlist", nil)
+		}
+		log.Error().Err(err).Str("username", item.Username).Str("movieId", item.MovieId).Msg("Failed to add movie to watchlist")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to add movie to watchlist", err)
+	}
+
+	return nil
+}
+
+func (r *watchlistRepository) FindByUsername(ctx context.Context, username string) ([]models.WatchlistItem, error) {
+	query := `
+		SELECT id, username, movie_id, created_at
+		FROM watchlist
+		WHERE username = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to query watchlist")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve watchlist", err)
+	}
+	defer rows.Close()
+
+	items := []models.WatchlistItem{}
+	for rows.Next() {
+		var item models.WatchlistItem
+		if err := rows.Scan(&item.ID, &item.Username, &item.MovieId, &item.CreatedAt); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Error scanning watchlist row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan watchlist data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Error iterating over watchlist rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over watchlist", err)
+	}
+
+	return items, nil
+}
+
+func (r *watchlistRepository) CountByUsername(ctx context.Context, username string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM watchlist WHERE username = $1", username).Scan(&count)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to count watchlist")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to count watchlist", err)
+	}
+	return count, nil
+}
+
+func (r *watchlistRepository) Remove(ctx context.Context, username, movieID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM watchlist WHERE username = $1 AND movie_id = $2", username, movieID)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("movieId", movieID).Msg("Failed to remove movie from watchlist")
+		return false, utils.NewInternalServerError("DATABASE_ERROR", "Failed to remove movie from watchlist", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetUpcomingShows returns shows that have not started yet for movies on the customer's
+// watchlist, soonest first.
+func (r *watchlistRepository) GetUpcomingShows(ctx context.Context, username string, limit int) ([]models.Show, error) {
+	query := `
+		SELECT s.id, s.movie_id, s.date, s.slot_id, s.cost,
+		       sl.id, sl.name, sl.start_time, sl.end_time
+		FROM watchlist w
+		JOIN show s ON s.movie_id = w.movie_id
+		JOIN slot sl ON s.slot_id = sl.id
+		WHERE w.username = $1 AND (s.date + sl.start_time) > LOCALTIMESTAMP
+		ORDER BY s.date, sl.start_time, s.id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, username, limit)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to query watchlist shows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve watchlist shows", err)
+	}
+	defer rows.Close()
+
+	shows := []models.Show{}
+	for rows.Next() {
+		var show models.Show
+		if err := rows.Scan(
+			&show.Id,
+			&show.MovieId,
+			&show.Date,
+			&show.SlotId,
+			&show.Cost,
+			&show.Slot.Id,
+			&show.Slot.Name,
+			&show.Slot.StartTime,
+			&show.Slot.EndTime,
+		); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Error scanning watchlist show row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan show data", err)
+		}
+		shows = append(shows, show)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Error iterating over watchlist show rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over shows", err)
+	}
+
+	return shows, nil
+}
+
+// CreateAlertsForShow records a pending alert for every customer watching the show's movie and
+// returns how many were created. Customers already alerted for the show are skipped.
+func (r *watchlistRepository) CreateAlertsForShow(ctx context.Context, show *models.Show) (int64, error) {
+	query := `
+		INSERT INTO watchlist_alert (username, movie_id, show_id)
+		SELECT username, movie_id, $2
+		FROM watchlist
+		WHERE movie_id = $1
+		ON CONFLICT (username, show_id) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, show.MovieId, show.Id)
+	if err != nil {
+		log.Error().Err(err).Int("showId", show.Id).Msg("Failed to create watchlist alerts")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to create watchlist alerts", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *watchlistRepository) ClaimPendingAlerts(ctx context.Context, maxAttempts, limit int, lease time.Duration) ([]models.WatchlistAlert, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE watchlist_alert
+			SET delivery_attempts = delivery_attempts + 1,
+			    next_attempt_at = CURRENT_TIMESTAMP + $3 * INTERVAL '1 second'
+			WHERE id IN (
+				SELECT id
+				FROM watchlist_alert
+				WHERE delivered_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP AND delivery_attempts < $1
+				ORDER BY next_attempt_at, id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, username, movie_id, show_id, created_at, delivery_attempts
+		)
+		SELECT c.id, c.username, c.movie_id, c.show_id, s.date,
+		       sl.id, sl.name, sl.start_time, sl.end_time,
+		       c.created_at, c.delivery_attempts
+		FROM claimed c
+		JOIN show s ON c.show_id = s.id
+		JOIN slot sl ON s.slot_id = sl.id
+		ORDER BY c.show_id, c.id
+	`
+
+	rows, err := r.db.Query(ctx, query, maxAttempts, limit, lease.Seconds())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim pending watchlist alerts")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to claim pending watchlist alerts", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.WatchlistAlert{}
+	for rows.Next() {
+		var alert models.WatchlistAlert
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.Username,
+			&alert.MovieId,
+			&alert.ShowId,
+			&alert.ShowDate,
+			&alert.Slot.Id,
+			&alert.Slot.Name,
+			&alert.Slot.StartTime,
+			&alert.Slot.EndTime,
+			&alert.CreatedAt,
+			&alert.DeliveryAttempts,
+		); err != nil {
+			log.Error().Err(err).Msg("Error scanning pending watchlist alert row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan watchlist alert data", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over pending watchlist alert rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over watchlist alerts", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *watchlistRepository) MarkAlertsDelivered(ctx context.Context, alertIDs []int64) error {
+	_, err := r.db.Exec(ctx, "UPDATE watchlist_alert SET delivered_at = CURRENT_TIMESTAMP WHERE id = ANY($1)", alertIDs)
+	if err != nil {
+		log.Error().Err(err).Int("alerts", len(alertIDs)).Msg("Failed to mark watchlist alerts delivered")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update watchlist alerts", err)
+	}
+	return nil
+}
+
+func (r *watchlistRepository) RescheduleAlerts(ctx context.Context, alertIDs []int64) error {
+	query := `
+		UPDATE watchlist_alert
+		SET next_attempt_at = CURRENT_TIMESTAMP + LEAST(POWER(2, delivery_attempts - 1), 60) * INTERVAL '1 minute'
+		WHERE id = ANY($1) AND delivered_at IS NULL
+	`
+	if _, err := r.db.Exec(ctx, query, alertIDs); err != nil {
+		log.Error().Err(err).Int("alerts", len(alertIDs)).Msg("Failed to reschedule watchlist alerts")
+		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to update watchlist alerts", err)
+	}
+	return nil
+}
+
+func (r *watchlistRepository) GetAlerts(ctx context.Context, username string, limit int) ([]models.WatchlistAlert, error) {
+	query := `
+		SELECT a.id, a.username, a.movie_id, a.show_id, s.date,
+		       sl.id, sl.name, sl.start_time, sl.end_time,
+		       a.created_at, a.read_at
+		FROM watchlist_alert a
+		JOIN show s ON a.show_id = s.id
+		JOIN slot sl ON s.slot_id = sl.id
+		WHERE a.username = $1
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, username, limit)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to query watchlist alerts")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve watchlist alerts", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.WatchlistAlert{}
+	for rows.Next() {
+		var alert models.WatchlistAlert
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.Username,
+			&alert.MovieId,
+			&alert.ShowId,
+			&alert.ShowDate,
+			&alert.Slot.Id,
+			&alert.Slot.Name,
+			&alert.Slot.StartTime,
+			&alert.Slot.EndTime,
+			&alert.CreatedAt,
+			&alert.ReadAt,
+		); err != nil {
+			log.Error().Err(err).Str("username", username).Msg("Error scanning watchlist alert row")
+			return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to scan watchlist alert data", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Error iterating over watchlist alert rows")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to iterate over watchlist alerts", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *watchlistRepository) MarkAlertsRead(ctx context.Context, username string) (int64, error) {
+	tag, err := r.db.Exec(ctx, "UPDATE watchlist_alert SET read_at = CURRENT_TIMESTAMP WHERE username = $1 AND read_at IS NULL", username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to mark watchlist alerts read")
+		return 0, utils.NewInternalServerError("DATABASE_ERROR", "Failed to update watchlist alerts", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -18,7 +18,8 @@ import (
 )
 
 type AdminBookingService interface {
-	CreateAdminBooking(ctx context.Context, req request.AdminBookingRequest) (*response.BookingResponse, error)
+	CreateAdminBooking(ctx context.Context, adminUsername string, req request.AdminBookingRequest) (*response.BookingResponse, error)
+	RefundBooking(ctx context.Context, adminUsername string, req request.BookingRefundRequest) (*response.BookingRefundResponse, error)
 }
 
 type adminBookingService struct {
@@ -27,6 +28,11 @@ type adminBookingService struct {
 	bookingSeatMappingRepo  repositories.BookingSeatMappingRepository
 	adminBookedCustomerRepo repositories.AdminBookedCustomerRepository
 	slotRepo                repositories.SlotRepository
+	bookingAuditRepo        repositories.BookingAuditRepository
+	seatEventBroker         SeatEventBroker
+	customerWalletRepo      repositories.CustomerWalletRepository
+	walletLedgerRepo        repositories.WalletLedgerRepository
+	loyaltyService          LoyaltyService
 }
 
 func NewAdminBookingService(
@@ -35,6 +41,11 @@ func NewAdminBookingService(
 	bookingSeatMappingRepo repositories.BookingSeatMappingRepository,
 	adminBookedCustomerRepo repositories.AdminBookedCustomerRepository,
 	slotRepo repositories.SlotRepository,
+	bookingAuditRepo repositories.BookingAuditRepository,
+	seatEventBroker SeatEventBroker,
+	customerWalletRepo repositories.CustomerWalletRepository,
+	walletLedgerRepo repositories.WalletLedgerRepository,
+	loyaltyService LoyaltyService,
 ) AdminBookingService {
 	return &adminBookingService{
 		showRepo:                showRepo,
@@ -42,10 +53,15 @@ func NewAdminBookingService(
 		bookingSeatMappingRepo:  bookingSeatMappingRepo,
 		adminBookedCustomerRepo: adminBookedCustomerRepo,
 		slotRepo:                slotRepo,
+		bookingAuditRepo:        bookingAuditRepo,
+		seatEventBroker:         seatEventBroker,
+		customerWalletRepo:      customerWalletRepo,
+		walletLedgerRepo:        walletLedgerRepo,
+		loyaltyService:          loyaltyService,
 	}
 }
 
-func (s *adminBookingService) CreateAdminBooking(ctx context.Context, req request.AdminBookingRequest) (*response.BookingResponse, error) {
+func (s *adminBookingService) CreateAdminBooking(ctx context.Context, adminUsername string, req request.AdminBookingRequest) (*response.BookingResponse, error) {
 	if len(req.SeatNumbers) > constants.MAX_NO_OF_SEATS_PER_BOOKING {
 		return nil, utils.NewBadRequestError("TOO_MANY_SEATS", fmt.Sprintf("Maximum %d seats can be booked per booking", constants.MAX_NO_OF_SEATS_PER_BOOKING), nil)
 	}
@@ -149,6 +165,10 @@ func (s *adminBookingService) CreateAdminBooking(ctx context.Context, req reques
 		return nil, err
 	}
 
+	recordBookingEvent(ctx, s.bookingAuditRepo, booking.Id, models.BookingEventCreated, adminUsername,
+		fmt.Sprintf("Counter booking for seats %s, %s payment of %s", strings.Join(req.SeatNumbers, ", "), booking.PaymentType, booking.AmountPaid.String()))
+	publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateBooked, req.SeatNumbers)
+
 	bookingAmtPaid, _ := booking.AmountPaid.Float64()
 
 	bookingResponse := &response.BookingResponse{
@@ -168,6 +188,76 @@ func (s *adminBookingService) CreateAdminBooking(ctx context.Context, req reques
 	return bookingResponse, nil
 }
 
+// RefundBooking refunds a confirmed booking and releases its seats. Wallet payments are
+// credited back to the wallet and loyalty points are reversed; card and cash payments are
+// returned outside Skyfox. Refunding an already refunded booking retries any step that failed.
+func (s *adminBookingService) RefundBooking(ctx context.Context, adminUsername string, req request.BookingRefundRequest) (*response.BookingRefundResponse, error) {
+	booking, err := s.bookingRepo.GetBookingById(ctx, req.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch booking.Status {
+	case "Refunded":
+	case "Confirmed":
+		refunded, err := s.bookingRepo.MarkBookingRefunded(ctx, booking.Id)
+		if err != nil {
+			return nil, err
+		}
+		if !refunded {
+			return nil, utils.NewBadRequestError("BOOKING_NOT_REFUNDABLE", "Booking changed while it was being refunded, please try again", nil)
+		}
+		booking.Status = "Refunded"
+
+		recordBookingEvent(ctx, s.bookingAuditRepo, booking.Id, models.BookingEventRefunded, adminUsername,
+			fmt.Sprintf("%s payment of %s refunded: %s", booking.PaymentType, booking.AmountPaid.String(), req.Reason))
+		if seats, err := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, booking.Id); err == nil {
+			publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateReleased, seats)
+		}
+	default:
+		return nil, utils.NewBadRequestError("BOOKING_NOT_REFUNDABLE", fmt.Sprintf("A %s booking cannot be refunded", strings.ToLower(booking.Status)), nil)
+	}
+
+	walletRefunded := decimal.Zero
+	if booking.PaymentType == "Wallet" && booking.CustomerUsername != nil {
+		wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, *booking.CustomerUsername)
+		if err != nil {
+			return nil, err
+		}
+		if wallet == nil {
+			return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found", nil)
+		}
+
+		reference := fmt.Sprintf("booking-refund-%d", booking.Id)
+		err = s.walletLedgerRepo.PostEntry(ctx, newBookingRefundEntry(wallet.ID, reference, booking.Id, req.Reason, adminUsername, booking.AmountPaid))
+		if err != nil {
+			// The refund was credited by an earlier attempt.
+			if appErr, ok := err.(*utils.AppError); !ok || appErr.Code != "DUPLICATE_TRANSACTION" {
+				log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to credit booking refund to wallet")
+				return nil, err
+			}
+		}
+		walletRefunded = booking.AmountPaid
+	}
+
+	reversals, err := s.loyaltyService.ReverseRefundedBooking(ctx, adminUsername, booking.Id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	amountPaid, _ := booking.AmountPaid.Float64()
+	walletAmount, _ := walletRefunded.Float64()
+
+	return &response.BookingRefundResponse{
+		BookingID:        booking.Id,
+		Status:           booking.Status,
+		PaymentType:      booking.PaymentType,
+		AmountPaid:       amountPaid,
+		WalletRefunded:   walletAmount,
+		LoyaltyReversals: reversals,
+	}, nil
+}
+
 func (s *adminBookingService) calculateTotalPrice(ctx context.Context, show *models.Show, seatNumbers []string) (decimal.Decimal, error) {
 	seatMap, err := s.showRepo.GetSeatMapForShow(ctx, show.Id)
 	if err != nil {
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// BlobStorage stores files by object key, a slash-separated path such as
+// "profile-images/alice_1715000000.jpg". New drivers implement this interface and are
+// selected in NewBlobStorage.
+type BlobStorage interface {
+	Name() string
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	// PresignedURL returns a URL that reads the object without credentials until it expires.
+	PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]BlobObject, error)
+}
+
+// BlobObject is one entry returned by BlobStorage.List.
+type BlobObject struct {
+	Key          string
+	LastModified time.Time
+}
+
+// SignedFileServer is implemented by drivers whose presigned URLs point back at this
+// server rather than at the storage provider.
+type SignedFileServer interface {
+	OpenSigned(ctx context.Context, key, expires, signature string) ([]byte, string, error)
+}
+
+// NewBlobStorage builds the driver named by cfg.Driver. A driver chosen explicitly must be
+// fully configured, since silently storing files on local disk in production would lose them
+// on redeploy. Only when no driver and no bucket are configured does it fall back to local
+// storage, so a fresh checkout works without AWS credentials.
+func NewBlobStorage(cfg config.StorageConfig) (BlobStorage, error) {
+	switch cfg.Driver {
+	case "":
+		if cfg.Bucket == "" {
+			log.Warn().Msg("STORAGE_DRIVER and S3_BUCKET are not set, using local file storage")
+			return newLocalStorageFromConfig(cfg), nil
+		}
+		return newAWSBlobStorage(cfg)
+	case "s3":
+		return newAWSBlobStorage(cfg)
+	case "minio":
+		if cfg.Endpoint == "" || cfg.Bucket == "" {
+			return nil, fmt.Errorf("STORAGE_DRIVER is minio but S3_ENDPOINT or S3_BUCKET is not set")
+		}
+		region := cfg.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		storage, err := newS3BlobStorage("minio", region, cfg.Bucket, cfg.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("creating S3-compatible session: %w", err)
+		}
+		return storage, nil
+	case "local":
+		return newLocalStorageFromConfig(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q, expected s3, minio or local", cfg.Driver)
+	}
+}
+
+func newAWSBlobStorage(cfg config.StorageConfig) (BlobStorage, error) {
+	if cfg.Region == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("STORAGE_DRIVER is s3 but AWS_REGION or S3_BUCKET is not set")
+	}
+	storage, err := newS3BlobStorage("s3", cfg.Region, cfg.Bucket, "")
+	if err != nil {
+		return nil, fmt.Errorf("creating AWS session: %w", err)
+	}
+	return storage, nil
+}
+
+func newLocalStorageFromConfig(cfg config.StorageConfig) BlobStorage {
+	secret := cfg.SigningSecret
+	if secret == "" {
+		random := make([]byte, 32)
+		if _, err := rand.Read(random); err != nil {
+			log.Fatal().Err(err).Msg("Failed to generate storage signing secret")
+		}
+		secret = hex.EncodeToString(random)
+		log.Warn().Msg("STORAGE_SIGNING_SECRET is not set, file URLs will stop working when the server restarts")
+	}
+	return newLocalBlobStorage(cfg.LocalDir, cfg.PublicURL, secret)
+}
+
+// validateObjectKey rejects keys that could escape the storage root or that no driver
+// can address.
+func validateObjectKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return utils.NewBadRequestError("INVALID_OBJECT_KEY", "Invalid object key", nil)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return utils.NewBadRequestError("INVALID_OBJECT_KEY", "Invalid object key", nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// BookingConsoleService backs the admin and staff booking console used at the counter to
+// look up bookings and settle customer disputes.
+type BookingConsoleService interface {
+	SearchBookings(ctx context.Context, req request.BookingSearchRequest) (*response.BookingSearchResponse, error)
+	GetBookingDetail(ctx context.Context, bookingID int) (*response.BookingDetailResponse, error)
+}
+
+type bookingConsoleService struct {
+	bookingRepo            repositories.BookingRepository
+	paymentTransactionRepo repositories.PaymentTransactionRepository
+	walletTransactionRepo  repositories.WalletTransactionRepository
+	bookingAuditRepo       repositories.BookingAuditRepository
+	movieService           movieservice.MovieService
+}
+
+func NewBookingConsoleService(
+	bookingRepo repositories.BookingRepository,
+	paymentTransactionRepo repositories.PaymentTransactionRepository,
+	walletTransactionRepo repositories.WalletTransactionRepository,
+	bookingAuditRepo repositories.BookingAuditRepository,
+	movieService movieservice.MovieService,
+) BookingConsoleService {
+	return &bookingConsoleService{
+		bookingRepo:            bookingRepo,
+		paymentTransactionRepo: paymentTransactionRepo,
+		walletTransactionRepo:  walletTransactionRepo,
+		bookingAuditRepo:       bookingAuditRepo,
+		movieService:           movieService,
+	}
+}
+
+func (s *bookingConsoleService) SearchBookings(ctx context.Context, req request.BookingSearchRequest) (*response.BookingSearchResponse, error) {
+	page, err := parseHistoryPage(req.Cursor, req.From, req.To, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := parseBookingStatuses(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.BookingSearchFilter{
+		BookingID:   req.BookingID,
+		PhoneNumber: req.Phone,
+		Username:    req.Username,
+		Name:        req.Name,
+		ShowID:      req.ShowID,
+		Statuses:    statuses,
+		PaymentType: req.PaymentType,
+		Since:       page.since,
+		Until:       page.until,
+		After:       page.after,
+		Limit:       page.limit + 1,
+	}
+
+	bookings, err := s.bookingRepo.SearchBookings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.BookingSearchResponse{Bookings: make([]response.BookingSearchResult, 0, len(bookings))}
+	if len(bookings) > page.limit {
+		bookings = bookings[:page.limit]
+		last := bookings[len(bookings)-1]
+		cursor := utils.EncodeCursor(last.BookingTime, int64(last.BookingID))
+		resp.NextCursor = &cursor
+	}
+
+	movies := make(map[string]*models.Movie)
+	for _, booking := range bookings {
+		resp.Bookings = append(resp.Bookings, toBookingSearchResult(ctx, s.movieService, booking, movies))
+	}
+
+	return resp, nil
+}
+
+func (s *bookingConsoleService) GetBookingDetail(ctx context.Context, bookingID int) (*response.BookingDetailResponse, error) {
+	bookings, err := s.bookingRepo.SearchBookings(ctx, models.BookingSearchFilter{BookingID: &bookingID, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) == 0 {
+		return nil, utils.NewNotFoundError("BOOKING_NOT_FOUND", "Booking not found", nil)
+	}
+	booking := bookings[0]
+
+	payments, err := s.paymentTransactionRepo.GetTransactionsByBookingId(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	walletMovements, err := s.walletTransactionRepo.GetWalletTransactionsForBooking(ctx, int64(bookingID))
+	if err != nil {
+		log.Error().Err(err).Int("bookingId", bookingID).Msg("Failed to get wallet movements for booking")
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve wallet transactions", err)
+	}
+
+	events, err := s.bookingAuditRepo.GetEventsByBookingId(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make(map[string]*models.Movie)
+	detail := &response.BookingDetailResponse{
+		Booking:         toBookingSearchResult(ctx, s.movieService, booking, movies),
+		Payments:        make([]response.BookingPaymentInfo, 0, len(payments)),
+		WalletMovements: make([]response.WalletTransactionResponse, 0, len(walletMovements)),
+		CheckIn:         bookingCheckInState(booking, events, time.Now()),
+		AuditTrail:      make([]response.BookingAuditEventResponse, 0, len(events)),
+	}
+	if movie := movies[booking.MovieID]; movie != nil {
+		detail.MoviePoster = movie.MoviePoster
+	}
+
+	for _, p := range payments {
+		amount, _ := p.Amount.Float64()
+		detail.Payments = append(detail.Payments, response.BookingPaymentInfo{
+			TransactionID: p.TransactionId,
+			PaymentMethod: p.PaymentMethod,
+			Amount:        amount,
+			Status:        p.Status,
+			ProcessedAt:   p.ProcessedAt,
+		})
+	}
+
+	for _, t := range walletMovements {
+		amount, _ := t.Amount.Float64()
+		detail.WalletMovements = append(detail.WalletMovements, response.WalletTransactionResponse{
+			ID:                   t.ID,
+			Amount:               amount,
+			TransactionType:      t.TransactionType,
+			BookingID:            t.BookingID,
+			TransactionID:        t.TransactionID,
+			Timestamp:            t.Timestamp.Format(time.RFC3339),
+			CounterpartyUsername: t.CounterpartyUsername,
+			Note:                 t.Note,
+		})
+	}
+
+	for _, e := range events {
+		detail.AuditTrail = append(detail.AuditTrail, response.BookingAuditEventResponse{
+			EventType: e.EventType,
+			Actor:     e.Actor,
+			Details:   e.Details,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return detail, nil
+}
+
+func toBookingSearchResult(ctx context.Context, movieService movieservice.MovieService, booking models.BookingSearchRecord, movies map[string]*models.Movie) response.BookingSearchResult {
+	amountPaid, _ := booking.AmountPaid.Float64()
+
+	result := response.BookingSearchResult{
+		BookingID:        booking.BookingID,
+		ShowID:           booking.ShowID,
+		MovieID:          booking.MovieID,
+		ShowDate:         booking.ShowDate.Format("2006-01-02"),
+		ShowTime:         booking.StartTime,
+		SlotName:         booking.SlotName,
+		CustomerUsername: booking.CustomerUsername,
+		CustomerName:     booking.CustomerName,
+		PhoneNumber:      booking.PhoneNumber,
+		SeatNumbers:      booking.SeatNumbers,
+		AmountPaid:       amountPaid,
+		PaymentType:      booking.PaymentType,
+		BookingTime:      booking.BookingTime,
+		Status:           booking.Status,
+	}
+
+	if movie := lookupMovie(ctx, movieService, booking.MovieID, movies); movie != nil {
+		result.MovieName = movie.Name
+	}
+
+	return result
+}
+
+func bookingCheckInState(booking models.BookingSearchRecord, events []models.BookingAuditEvent, now time.Time) response.BookingCheckInState {
+	state := response.BookingCheckInState{}
+	state.OpensAt, state.ClosesAt = checkInWindow(booking.ShowDate, booking.StartTime, booking.EndTime)
+
+	switch {
+	case booking.Status == "CheckedIn":
+		state.State = "CHECKED_IN"
+		for _, e := range events {
+			if e.EventType == models.BookingEventCheckedIn {
+				checkedInAt := e.CreatedAt
+				state.CheckedInAt = &checkedInAt
+				state.CheckedInBy = e.Actor
+			}
+		}
+	case booking.Status == "Refunded":
+		state.State = "REFUNDED"
+	case booking.Status != "Confirmed":
+		state.State = "NOT_PAID"
+	default:
+		state.State = checkInWindowState(state.OpensAt, state.ClosesAt, now)
+	}
+
+	return state
+}
+
+const maxBookingAuditDetailsLength = 255
+
+// recordBookingEvent appends to a booking's audit trail. The event describes something that
+// has already happened, so a failure is logged rather than failing the caller. An empty actor
+// records a system event.
+func recordBookingEvent(ctx context.Context, auditRepo repositories.BookingAuditRepository, bookingID int, eventType, actor, details string) {
+	event := &models.BookingAuditEvent{
+		BookingID: bookingID,
+		EventType: eventType,
+	}
+	if actor != "" {
+		event.Actor = &actor
+	}
+	if details != "" {
+		// details is a VARCHAR(255); longer text, such as a long admin reason, is cut short
+		// rather than losing the event.
+		if runes := []rune(details); len(runes) > maxBookingAuditDetailsLength {
+			details = string(runes[:maxBookingAuditDetailsLength-3]) + "..."
+		}
+		event.Details = &details
+	}
+
+	if err := auditRepo.RecordEvent(ctx, event); err != nil {
+		log.Error().Err(err).Int("bookingId", bookingID).Str("eventType", eventType).Msg("Failed to record booking audit event")
+	}
+}
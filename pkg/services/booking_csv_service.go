@@ -5,8 +5,12 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 	"github.com/rs/zerolog/log"
@@ -14,132 +18,306 @@ import (
 
 type BookingCSVService interface {
 	WriteBookingsCSV(ctx context.Context, w io.Writer, month, year *int) error
+	WriteBookingsCSVBetween(ctx context.Context, w io.Writer, from, to time.Time) error
+	PrepareExport(req request.BookingExportRequest) (*BookingExport, error)
+	WriteExport(ctx context.Context, w io.Writer, export *BookingExport) error
 }
 
 type bookingCSVService struct {
-	bookingRepo             repositories.BookingRepository
-	showRepo                repositories.ShowRepository
-	adminBookedCustomerRepo repositories.AdminBookedCustomerRepository
-	skyCustomerRepo         repositories.SkyCustomerRepository
+	bookingRepo  repositories.BookingRepository
+	movieService movieservice.MovieService
 }
 
 func NewBookingCSVService(
 	bookingRepo repositories.BookingRepository,
-	showRepo repositories.ShowRepository,
-	adminBookedCustomerRepo repositories.AdminBookedCustomerRepository,
-	skyCustomerRepo repositories.SkyCustomerRepository,
+	movieService movieservice.MovieService,
 ) BookingCSVService {
 	return &bookingCSVService{
-		bookingRepo:             bookingRepo,
-		showRepo:                showRepo,
-		adminBookedCustomerRepo: adminBookedCustomerRepo,
-		skyCustomerRepo:         skyCustomerRepo,
+		bookingRepo:  bookingRepo,
+		movieService: movieService,
 	}
 }
 
+// BookingExport is a validated export request. It is prepared before anything is written
+// so that bad filters are reported as errors instead of a half-written file.
+type BookingExport struct {
+	FileName    string
+	ContentType string
+	filter      models.BookingExportFilter
+	columns     []bookingExportColumn
+	format      string
+}
+
+type bookingExportColumn struct {
+	key     string
+	header  string
+	numeric bool
+}
+
+var bookingExportColumns = []bookingExportColumn{
+	{"booking_id", "Booking ID", true},
+	{"show_id", "Show ID", true},
+	{"show_date", "Show Date", false},
+	{"movie_id", "Movie ID", false},
+	{"movie_name", "Movie Name", false},
+	{"slot", "Slot", false},
+	{"customer_name", "Customer Name", false},
+	{"phone_number", "Phone Number", false},
+	{"customer_username", "Customer Username", false},
+	{"seats", "Number of Seats", true},
+	{"amount_paid", "Amount Paid", true},
+	{"payment_type", "Payment Type", false},
+	{"booking_time", "Booking Time", false},
+	{"status", "Status", false},
+}
+
+// defaultBookingExportColumns keeps the layout of the original booking CSV.
+var defaultBookingExportColumns = []string{
+	"booking_id", "show_id", "show_date", "customer_name", "phone_number",
+	"seats", "amount_paid", "payment_type", "booking_time", "status",
+}
+
+var bookingExportStatuses = map[string]bool{"Pending": true, "Confirmed": true, "CheckedIn": true, "Refunded": true}
+
 func (s *bookingCSVService) WriteBookingsCSV(ctx context.Context, w io.Writer, month, year *int) error {
-	bookings, err := s.bookingRepo.FindBookingsByStatusAndDate(ctx, []string{"Confirmed", "CheckedIn"}, month, year)
+	export, err := s.PrepareExport(request.BookingExportRequest{Month: month, Year: year})
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch bookings for CSV export")
-		return utils.NewInternalServerError("DATABASE_ERROR", "Failed to retrieve bookings", err)
+		return err
 	}
 
-	csvWriter := csv.NewWriter(w)
-	defer csvWriter.Flush()
+	return s.WriteExport(ctx, w, export)
+}
 
-	header := []string{
-		"Booking ID",
-		"Show ID",
-		"Show Date",
-		"Customer Name",
-		"Phone Number",
-		"Number of Seats",
-		"Amount Paid",
-		"Payment Type",
-		"Booking Time",
-		"Status",
+func (s *bookingCSVService) WriteBookingsCSVBetween(ctx context.Context, w io.Writer, from, to time.Time) error {
+	export, err := s.PrepareExport(request.BookingExportRequest{})
+	if err != nil {
+		return err
 	}
+	export.filter.Since = &from
+	export.filter.Until = &to
 
-	if err := csvWriter.Write(header); err != nil {
-		log.Error().Err(err).Msg("Failed to write CSV header")
-		return utils.NewInternalServerError("CSV_ERROR", "Failed to write CSV header", err)
+	return s.WriteExport(ctx, w, export)
+}
+
+func (s *bookingCSVService) PrepareExport(req request.BookingExportRequest) (*BookingExport, error) {
+	export := &BookingExport{
+		format:      "csv",
+		ContentType: "text/csv",
+		filter: models.BookingExportFilter{
+			Statuses: []string{"Confirmed", "CheckedIn"},
+			Month:    req.Month,
+			Year:     req.Year,
+		},
 	}
 
-	if len(bookings) == 0 {
-		return nil
+	if req.Format == "xlsx" {
+		export.format = "xlsx"
+		export.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
 	}
 
-	showCache := make(map[int]*models.Show)
-	adminCustomerCache := make(map[int]*models.AdminBookedCustomer)
-	skyCustomerCache := make(map[string]*models.SkyCustomer)
+	if (req.From != "" || req.To != "") && (req.Month != nil || req.Year != nil) {
+		return nil, utils.NewBadRequestError("INVALID_PARAMS", "Date range cannot be combined with month or year filters", nil)
+	}
 
-	showIDs := make(map[int]bool)
-	customerIDs := make(map[int]bool)
-	customerUsernames := make(map[string]bool)
+	if req.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", req.From, time.Local)
+		if err != nil {
+			return nil, utils.NewBadRequestError("INVALID_DATE", "From must be a date in YYYY-MM-DD format", err)
+		}
+		export.filter.Since = &from
+	}
+	if req.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", req.To, time.Local)
+		if err != nil {
+			return nil, utils.NewBadRequestError("INVALID_DATE", "To must be a date in YYYY-MM-DD format", err)
+		}
+		to = to.AddDate(0, 0, 1)
+		export.filter.Until = &to
+	}
+	if export.filter.Since != nil && export.filter.Until != nil && !export.filter.Since.Before(*export.filter.Until) {
+		return nil, utils.NewBadRequestError("INVALID_DATE", "From must not be after to", nil)
+	}
 
-	for _, booking := range bookings {
-		showIDs[booking.ShowId] = true
+	if req.MovieID != "" {
+		export.filter.MovieID = &req.MovieID
+	}
+	export.filter.SlotID = req.SlotID
+	if req.PaymentType != "" {
+		export.filter.PaymentType = &req.PaymentType
+	}
+	if req.Limit != nil {
+		export.filter.Limit = *req.Limit
+	}
+	if req.Offset != nil {
+		export.filter.Offset = *req.Offset
+	}
 
-		if booking.CustomerId != nil {
-			customerIDs[*booking.CustomerId] = true
-		} else if booking.CustomerUsername != nil {
-			customerUsernames[*booking.CustomerUsername] = true
+	if req.Status != "" {
+		export.filter.Statuses = nil
+		for _, status := range splitExportList(req.Status) {
+			if !bookingExportStatuses[status] {
+				return nil, utils.NewBadRequestError("INVALID_STATUS", fmt.Sprintf("Unknown booking status %q", status), nil)
+			}
+			export.filter.Statuses = append(export.filter.Statuses, status)
 		}
 	}
 
-	for showID := range showIDs {
-		show, err := s.showRepo.FindById(ctx, showID)
-		if err == nil && show != nil {
-			showCache[showID] = show
+	columnKeys := defaultBookingExportColumns
+	if req.Columns != "" {
+		columnKeys = splitExportList(req.Columns)
+	}
+	for _, key := range columnKeys {
+		column, ok := findBookingExportColumn(key)
+		if !ok {
+			return nil, utils.NewBadRequestError("INVALID_COLUMN", fmt.Sprintf("Unknown export column %q", key), nil)
 		}
+		export.columns = append(export.columns, column)
+	}
+	if len(export.columns) == 0 {
+		return nil, utils.NewBadRequestError("INVALID_COLUMN", "At least one export column is required", nil)
 	}
 
-	for customerID := range customerIDs {
-		customer, err := s.adminBookedCustomerRepo.FindById(ctx, customerID)
-		if err == nil && customer != nil {
-			adminCustomerCache[customerID] = customer
-		}
+	export.FileName = bookingExportFileName(req, export.format)
+
+	return export, nil
+}
+
+func (s *bookingCSVService) WriteExport(ctx context.Context, w io.Writer, export *BookingExport) error {
+	header := make([]string, len(export.columns))
+	numeric := make([]bool, len(export.columns))
+	for i, column := range export.columns {
+		header[i] = column.header
+		numeric[i] = column.numeric
 	}
 
-	for username := range customerUsernames {
-		customer, err := s.skyCustomerRepo.FindByUsername(ctx, username)
-		if err == nil && customer != nil {
-			skyCustomerCache[username] = customer
+	var writeRow func(values []string) error
+	var finish func() error
+
+	if export.format == "xlsx" {
+		xlsxWriter, err := utils.NewXLSXWriter(w, "Bookings")
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to start XLSX export")
+			return utils.NewInternalServerError("XLSX_ERROR", "Failed to write XLSX header", err)
+		}
+		if err := xlsxWriter.WriteRow(header, nil); err != nil {
+			return utils.NewInternalServerError("XLSX_ERROR", "Failed to write XLSX header", err)
+		}
+		writeRow = func(values []string) error { return xlsxWriter.WriteRow(values, numeric) }
+		finish = xlsxWriter.Close
+	} else {
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(header); err != nil {
+			log.Error().Err(err).Msg("Failed to write CSV header")
+			return utils.NewInternalServerError("CSV_ERROR", "Failed to write CSV header", err)
+		}
+		writeRow = csvWriter.Write
+		finish = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
 		}
 	}
 
-	for _, booking := range bookings {
-		var customerName, phoneNumber string
-		if booking.CustomerId != nil {
-			if customer, exists := adminCustomerCache[*booking.CustomerId]; exists {
-				customerName = customer.Name
-				phoneNumber = customer.Number
+	if len(export.filter.Statuses) > 0 {
+		movieNames := make(map[string]string)
+		movieName := func(movieID string) string {
+			if movieID == "" {
+				return ""
 			}
-		} else if booking.CustomerUsername != nil {
-			if customer, exists := skyCustomerCache[*booking.CustomerUsername]; exists {
-				customerName = customer.Name
-				phoneNumber = customer.Number
+			return lookupMovieName(ctx, s.movieService, movieID, movieNames)
+		}
+
+		values := make([]string, len(export.columns))
+		err := s.bookingRepo.StreamBookingsForExport(ctx, export.filter, func(row *models.BookingExportRow) error {
+			for i, column := range export.columns {
+				values[i] = bookingExportValue(column.key, row, movieName)
 			}
+			if err := writeRow(values); err != nil {
+				log.Error().Err(err).Int("booking_id", row.BookingID).Msg("Failed to write export row")
+				return utils.NewInternalServerError("EXPORT_ERROR", "Failed to write booking data", err)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to export bookings")
+			return err
 		}
+	}
 
-		row := []string{
-			fmt.Sprintf("%d", booking.Id),
-			fmt.Sprintf("%d", booking.ShowId),
-			booking.Date.Format("2006-01-02"),
-			customerName,
-			phoneNumber,
-			fmt.Sprintf("%d", booking.NoOfSeats),
-			fmt.Sprintf("%.2f", booking.AmountPaid),
-			booking.PaymentType,
-			booking.BookingTime.Format("2006-01-02 15:04:05"),
-			booking.Status,
+	if err := finish(); err != nil {
+		log.Error().Err(err).Msg("Failed to finish booking export")
+		return utils.NewInternalServerError("EXPORT_ERROR", "Failed to write booking data", err)
+	}
+
+	return nil
+}
+
+func bookingExportValue(key string, row *models.BookingExportRow, movieName func(string) string) string {
+	switch key {
+	case "booking_id":
+		return fmt.Sprintf("%d", row.BookingID)
+	case "show_id":
+		return fmt.Sprintf("%d", row.ShowID)
+	case "show_date":
+		return row.ShowDate.Format("2006-01-02")
+	case "movie_id":
+		return row.MovieID
+	case "movie_name":
+		return movieName(row.MovieID)
+	case "slot":
+		return row.SlotName
+	case "customer_name":
+		return row.CustomerName
+	case "phone_number":
+		return row.PhoneNumber
+	case "customer_username":
+		return row.CustomerUsername
+	case "seats":
+		return fmt.Sprintf("%d", row.NoOfSeats)
+	case "amount_paid":
+		return fmt.Sprintf("%.2f", row.AmountPaid)
+	case "payment_type":
+		return row.PaymentType
+	case "booking_time":
+		return row.BookingTime.Format("2006-01-02 15:04:05")
+	case "status":
+		return row.Status
+	default:
+		return ""
+	}
+}
+
+func findBookingExportColumn(key string) (bookingExportColumn, bool) {
+	for _, column := range bookingExportColumns {
+		if column.key == key {
+			return column, true
 		}
+	}
+	return bookingExportColumn{}, false
+}
 
-		if err := csvWriter.Write(row); err != nil {
-			log.Error().Err(err).Int("booking_id", booking.Id).Msg("Failed to write CSV row")
-			return utils.NewInternalServerError("CSV_ERROR", "Failed to write booking data", err)
+func splitExportList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
 		}
 	}
+	return values
+}
 
-	return nil
+func bookingExportFileName(req request.BookingExportRequest, format string) string {
+	name := "bookings"
+	if req.Month != nil {
+		name += "_" + time.Month(*req.Month).String()
+	}
+	if req.Year != nil {
+		name += fmt.Sprintf("_%d", *req.Year)
+	}
+	if req.From != "" {
+		name += "_from_" + req.From
+	}
+	if req.To != "" {
+		name += "_to_" + req.To
+	}
+	return name + "." + format
 }
@@ -169,18 +169,7 @@ func (s *bookingService) GeneratePDF(ctx context.Context, bookingID int) (string
 	pdf.RegisterImageOptionsReader("qrcode", qrImageOptions, qrReader)
 	pdf.AddPage()
 
-	// Header with brand color
-	pdf.SetFillColor(224, 75, 0) // Primary color E04B00 from theme
-	pdf.Rect(0, 0, 210, 40, "F")
-
-	addLogoWithFallback(pdf, 10, 5, 30)
-
-	pdf.SetFont("Poppins", "B", 24)
-	pdf.SetTextColor(255, 255, 255)
-	pdf.SetXY(45, 15)
-	pdf.Cell(0, 10, "SKYFOX CINEMAS")
-
-	pdf.SetTextColor(22, 26, 30) // text.primary from theme
+	addBrandHeader(pdf)
 
 	pdf.SetY(50)
 	pdf.SetFont("Poppins", "B", 18)
@@ -380,22 +369,7 @@ func (s *bookingService) GeneratePDF(ctx context.Context, bookingID int) (string
 	pdf.SetX(75)
 	pdf.MultiCell(60, 4, "Scan this QR code at the theater entrance", "", "C", false)
 
-	// Footer with brand color
-	footerTop := 267.0
-	pdf.SetFillColor(224, 75, 0) // Primary color
-	pdf.Rect(0, footerTop, 210, 30, "F")
-
-	// Thank you message in footer
-	pdf.SetY(footerTop + 5)
-	pdf.SetFont("Poppins", "B", 12)
-	pdf.SetTextColor(255, 255, 255) // White text
-	pdf.CellFormat(0, 10, "Thank you for choosing SKYFOX Cinemas!", "", 0, "C", false, 0, "")
-
-	// Terms text in footer
-	pdf.SetY(footerTop + 15)
-	pdf.SetFont("Poppins", "", 8)
-	pdf.SetTextColor(255, 255, 255) // White text for footer
-	pdf.MultiCell(0, 5, "Please arrive 15 minutes before showtime. Tickets are non-refundable.", "", "C", false)
+	addBrandFooter(pdf, "Please arrive 15 minutes before showtime. Tickets are non-refundable.")
 
 	var buf bytes.Buffer
 	err = pdf.Output(&buf)
@@ -484,6 +458,39 @@ func readFile(path string) []byte {
 	return data
 }
 
+// addBrandHeader draws the SKYFOX header band shared by all generated PDFs
+// and leaves the text colour set to text.primary.
+func addBrandHeader(pdf *gofpdf.Fpdf) {
+	pdf.SetFillColor(224, 75, 0) // Primary color E04B00 from theme
+	pdf.Rect(0, 0, 210, 40, "F")
+
+	addLogoWithFallback(pdf, 10, 5, 30)
+
+	pdf.SetFont("Poppins", "B", 24)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(45, 15)
+	pdf.Cell(0, 10, "SKYFOX CINEMAS")
+
+	pdf.SetTextColor(22, 26, 30) // text.primary from theme
+}
+
+// addBrandFooter draws the SKYFOX footer band with the thank you message and the given terms line.
+func addBrandFooter(pdf *gofpdf.Fpdf, terms string) {
+	footerTop := 267.0
+	pdf.SetFillColor(224, 75, 0) // Primary color
+	pdf.Rect(0, footerTop, 210, 30, "F")
+
+	pdf.SetY(footerTop + 5)
+	pdf.SetFont("Poppins", "B", 12)
+	pdf.SetTextColor(255, 255, 255) // White text
+	pdf.CellFormat(0, 10, "Thank you for choosing SKYFOX Cinemas!", "", 0, "C", false, 0, "")
+
+	pdf.SetY(footerTop + 15)
+	pdf.SetFont("Poppins", "", 8)
+	pdf.SetTextColor(255, 255, 255) // White text for footer
+	pdf.MultiCell(0, 5, terms, "", "C", false)
+}
+
 func addLogoWithFallback(pdf *gofpdf.Fpdf, x, y, width float64) {
 	logoPath := "assets/images/logo.png"
 	if _, err := os.Stat(logoPath); os.IsNotExist(err) {
@@ -6,40 +6,143 @@ import (
 	"strings"
 	"time"
 
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 )
 
 type CheckInService interface {
-	FindConfirmedBookings(ctx context.Context) ([]*models.Booking, error)
-	MarkBookingsCheckedIn(ctx context.Context, bookingIDs []int) (checkedIn []int, alreadyDone []int, invalid []int, err error)
+	FindCheckInBookings(ctx context.Context, req request.CheckInBookingsRequest) (*response.BookingSearchResponse, error)
+	GetCheckInSummary(ctx context.Context, scope request.CheckInScope) (*response.CheckInSummaryResponse, error)
+	MarkBookingsCheckedIn(ctx context.Context, staffUsername string, bookingIDs []int) (checkedIn []int, alreadyDone []int, invalid []int, err error)
 }
 
 type checkInService struct {
-	bookingRepo repositories.BookingRepository
-	showRepo    repositories.ShowRepository
+	bookingRepo      repositories.BookingRepository
+	showRepo         repositories.ShowRepository
+	bookingAuditRepo repositories.BookingAuditRepository
+	movieService     movieservice.MovieService
+	seatMappingRepo  repositories.BookingSeatMappingRepository
+	seatEventBroker  SeatEventBroker
 }
 
 func NewCheckInService(
 	bookingRepo repositories.BookingRepository,
 	showRepo repositories.ShowRepository,
+	bookingAuditRepo repositories.BookingAuditRepository,
+	movieService movieservice.MovieService,
+	seatMappingRepo repositories.BookingSeatMappingRepository,
+	seatEventBroker SeatEventBroker,
 ) CheckInService {
 	return &checkInService{
-		bookingRepo: bookingRepo,
-		showRepo:    showRepo,
+		bookingRepo:      bookingRepo,
+		showRepo:         showRepo,
+		bookingAuditRepo: bookingAuditRepo,
+		movieService:     movieService,
+		seatMappingRepo:  seatMappingRepo,
+		seatEventBroker:  seatEventBroker,
 	}
 }
 
-func (s *checkInService) FindConfirmedBookings(ctx context.Context) ([]*models.Booking, error) {
-	bookings, err := s.bookingRepo.FindConfirmedBookings(ctx)
+func (s *checkInService) FindCheckInBookings(ctx context.Context, req request.CheckInBookingsRequest) (*response.BookingSearchResponse, error) {
+	scope := defaultCheckInScope(req.CheckInScope)
+	page, err := parseHistoryPage(req.Cursor, scope.Date, scope.Date, req.Limit)
 	if err != nil {
 		return nil, err
 	}
-	return bookings, nil
+
+	filter := models.BookingSearchFilter{
+		ShowID:         scope.ShowID,
+		Statuses:       []string{"Confirmed"},
+		Since:          page.since,
+		Until:          page.until,
+		StartingWithin: scope.Window,
+		After:          page.after,
+		Limit:          page.limit + 1,
+	}
+
+	bookings, err := s.bookingRepo.SearchBookings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.BookingSearchResponse{Bookings: make([]response.BookingSearchResult, 0, len(bookings))}
+	if len(bookings) > page.limit {
+		bookings = bookings[:page.limit]
+		last := bookings[len(bookings)-1]
+		cursor := utils.EncodeCursor(last.BookingTime, int64(last.BookingID))
+		resp.NextCursor = &cursor
+	}
+
+	movies := make(map[string]*models.Movie)
+	for _, booking := range bookings {
+		resp.Bookings = append(resp.Bookings, toBookingSearchResult(ctx, s.movieService, booking, movies))
+	}
+
+	return resp, nil
+}
+
+func (s *checkInService) GetCheckInSummary(ctx context.Context, scope request.CheckInScope) (*response.CheckInSummaryResponse, error) {
+	scope = defaultCheckInScope(scope)
+	page, err := parseHistoryPage("", scope.Date, scope.Date, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.bookingRepo.GetShowCheckInSummaries(ctx, models.ShowCheckInFilter{
+		ShowID:         scope.ShowID,
+		Since:          page.since,
+		Until:          page.until,
+		StartingWithin: scope.Window,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := &response.CheckInSummaryResponse{
+		GeneratedAt: now,
+		Shows:       make([]response.ShowCheckInSummary, 0, len(summaries)),
+	}
+
+	movies := make(map[string]*models.Movie)
+	for _, summary := range summaries {
+		opensAt, closesAt := checkInWindow(summary.ShowDate, summary.StartTime, summary.EndTime)
+		show := response.ShowCheckInSummary{
+			ShowID:            summary.ShowID,
+			MovieID:           summary.MovieID,
+			ShowDate:          summary.ShowDate.Format("2006-01-02"),
+			ShowTime:          summary.StartTime,
+			SlotName:          summary.SlotName,
+			CheckInState:      checkInWindowState(opensAt, closesAt, now),
+			ExpectedBookings:  summary.ExpectedBookings,
+			ExpectedSeats:     summary.ExpectedSeats,
+			AdmittedBookings:  summary.AdmittedBookings,
+			AdmittedSeats:     summary.AdmittedSeats,
+			RemainingBookings: summary.ExpectedBookings - summary.AdmittedBookings,
+			RemainingSeats:    summary.ExpectedSeats - summary.AdmittedSeats,
+		}
+		if movie := lookupMovie(ctx, s.movieService, summary.MovieID, movies); movie != nil {
+			show.MovieName = movie.Name
+		}
+		resp.Shows = append(resp.Shows, show)
+	}
+
+	return resp, nil
 }
 
-func (s *checkInService) MarkBookingsCheckedIn(ctx context.Context, bookingIDs []int) ([]int, []int, []int, error) {
+// defaultCheckInScope limits an unscoped request to today's shows.
+func defaultCheckInScope(scope request.CheckInScope) request.CheckInScope {
+	if scope.ShowID == nil && scope.Date == "" && scope.Window == nil {
+		scope.Date = time.Now().Format("2006-01-02")
+	}
+	return scope
+}
+
+func (s *checkInService) MarkBookingsCheckedIn(ctx context.Context, staffUsername string, bookingIDs []int) ([]int, []int, []int, error) {
 	if len(bookingIDs) == 0 {
 		return nil, nil, nil, nil
 	}
@@ -93,6 +196,10 @@ func (s *checkInService) MarkBookingsCheckedIn(ctx context.Context, bookingIDs [
 			continue
 		}
 		if ok {
+			recordBookingEvent(ctx, s.bookingAuditRepo, id, models.BookingEventCheckedIn, staffUsername, "")
+			if seats, err := s.seatMappingRepo.GetSeatsByBookingId(ctx, id); err == nil {
+				publishSeatEvent(ctx, s.seatEventBroker, b.ShowId, models.SeatStateCheckedIn, seats)
+			}
 			checkedIn = append(checkedIn, id)
 		} else {
 			alreadyDone = append(alreadyDone, id)
@@ -101,6 +208,31 @@ func (s *checkInService) MarkBookingsCheckedIn(ctx context.Context, bookingIDs [
 	return checkedIn, alreadyDone, invalid, nil
 }
 
+// checkInWindow returns when check-in for a show opens, an hour before it starts, and closes,
+// when it ends. Either is nil if the slot time cannot be parsed.
+func checkInWindow(showDate time.Time, startTime, endTime string) (*time.Time, *time.Time) {
+	var opensAt, closesAt *time.Time
+	if start, err := parseShowStartTime(showDate, startTime); err == nil {
+		opens := start.Add(-1 * time.Hour)
+		opensAt = &opens
+	}
+	if end, err := parseShowEndTime(showDate, endTime); err == nil {
+		closesAt = &end
+	}
+	return opensAt, closesAt
+}
+
+func checkInWindowState(opensAt, closesAt *time.Time, now time.Time) string {
+	switch {
+	case opensAt != nil && now.Before(*opensAt):
+		return "NOT_YET_OPEN"
+	case closesAt != nil && now.After(*closesAt):
+		return "CLOSED"
+	default:
+		return "OPEN"
+	}
+}
+
 func isWithinCheckInWindow(now time.Time, showDate time.Time, startTime string) bool {
 	startTimeParsed, err := parseShowStartTime(showDate, startTime)
 	if err != nil {
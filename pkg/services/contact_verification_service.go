@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// Actions that can be configured to need a verified contact.
+const (
+	verifiedContactActionBooking     = "booking"
+	verifiedContactActionWalletTopUp = "wallet_top_up"
+)
+
+type ContactVerificationService interface {
+	RequestCode(ctx context.Context, username, channel string) (*response.VerificationCodeResponse, error)
+	ConfirmCode(ctx context.Context, username, channel, code string) (*response.ContactVerificationResponse, error)
+	CheckVerifiedContact(ctx context.Context, username, action string) error
+}
+
+type contactVerificationService struct {
+	skyCustomerRepo         repositories.SkyCustomerRepository
+	contactVerificationRepo repositories.ContactVerificationRepository
+	sender                  VerificationSender
+	config                  config.VerificationConfig
+}
+
+func NewContactVerificationService(
+	skyCustomerRepo repositories.SkyCustomerRepository,
+	contactVerificationRepo repositories.ContactVerificationRepository,
+	sender VerificationSender,
+	config config.VerificationConfig,
+) ContactVerificationService {
+	return &contactVerificationService{
+		skyCustomerRepo:         skyCustomerRepo,
+		contactVerificationRepo: contactVerificationRepo,
+		sender:                  sender,
+		config:                  config,
+	}
+}
+
+// RequestCode sends a one-time code to the customer's pending contact for the channel, or to
+// the current one if nothing is pending.
+func (s *contactVerificationService) RequestCode(ctx context.Context, username, channel string) (*response.VerificationCodeResponse, error) {
+	customer, err := s.findCustomer(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, verified := verificationTarget(customer, channel)
+	if verified {
+		return nil, utils.NewBadRequestError("ALREADY_VERIFIED", fmt.Sprintf("Your %s is already verified", channelLabel(channel)), nil)
+	}
+
+	latest, err := s.contactVerificationRepo.FindLatestCode(ctx, username, channel)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Destination == destination && time.Since(latest.CreatedAt) < constants.VERIFICATION_CODE_RESEND_INTERVAL {
+		return nil, utils.NewBadRequestError("VERIFICATION_CODE_RECENTLY_SENT", "A code was sent recently, please wait before requesting another", nil)
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, utils.NewInternalServerError("CODE_GENERATION_FAILED", "Failed to generate verification code", err)
+	}
+	codeHash, err := utils.HashPassword(code)
+	if err != nil {
+		return nil, utils.NewInternalServerError("CODE_HASH_ERROR", "Failed to hash verification code", err)
+	}
+
+	record := &models.ContactVerificationCode{
+		Username:    username,
+		Channel:     channel,
+		Destination: destination,
+		CodeHash:    codeHash,
+		ExpiresAt:   time.Now().Add(constants.VERIFICATION_CODE_TTL),
+	}
+	if err := s.contactVerificationRepo.CreateCode(ctx, record); err != nil {
+		return nil, err
+	}
+
+	err = s.sender.Send(ctx, VerificationMessage{
+		Channel:     channel,
+		Destination: destination,
+		Username:    username,
+		Code:        code,
+		ExpiresAt:   record.ExpiresAt,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("channel", channel).Str("sender", s.sender.Name()).Msg("Failed to send verification code")
+		return nil, err
+	}
+
+	return &response.VerificationCodeResponse{
+		Channel:     channel,
+		Destination: maskDestination(channel, destination),
+		ExpiresAt:   record.ExpiresAt,
+	}, nil
+}
+
+func (s *contactVerificationService) ConfirmCode(ctx context.Context, username, channel, code string) (*response.ContactVerificationResponse, error) {
+	latest, err := s.contactVerificationRepo.FindLatestCode(ctx, username, channel)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || latest.ConsumedAt != nil || time.Now().After(latest.ExpiresAt) {
+		return nil, utils.NewBadRequestError("VERIFICATION_CODE_EXPIRED", "The verification code has expired or was already used, please request a new one", nil)
+	}
+
+	// The attempt is counted before the code is compared, so parallel guesses cannot all get in
+	// under the limit.
+	codeHash, err := s.contactVerificationRepo.ReserveAttempt(ctx, latest.ID, constants.VERIFICATION_CODE_MAX_ATTEMPTS)
+	if err != nil {
+		return nil, err
+	}
+	if codeHash == nil {
+		return nil, utils.NewBadRequestError("TOO_MANY_ATTEMPTS", "Too many incorrect attempts, please request a new code", nil)
+	}
+
+	if !utils.CheckPasswordHash(code, *codeHash) {
+		return nil, utils.NewBadRequestError("INVALID_VERIFICATION_CODE", "The verification code is incorrect", nil)
+	}
+
+	// Phone numbers are not unique in the database, so check that no one else took this
+	// number while it was pending. Emails are protected by the unique constraint.
+	if channel == models.VerificationChannelPhone {
+		customer, err := s.findCustomer(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if customer.Number != latest.Destination {
+			exists, err := s.skyCustomerRepo.ExistsByMobileNumber(ctx, latest.Destination)
+			if err != nil {
+				return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error checking customer data", err)
+			}
+			if exists {
+				return nil, utils.NewBadRequestError("NUMBER_EXISTS", "Phone Number already exists", nil)
+			}
+		}
+	}
+
+	verifiedAt, err := s.contactVerificationRepo.ConfirmCode(ctx, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("username", username).Str("channel", channel).Msg("Contact verified")
+	return &response.ContactVerificationResponse{
+		Channel:     channel,
+		Destination: latest.Destination,
+		VerifiedAt:  verifiedAt,
+	}, nil
+}
+
+// CheckVerifiedContact returns an error if the action is configured to need a verified contact
+// and the customer has verified neither their email nor their phone number.
+func (s *contactVerificationService) CheckVerifiedContact(ctx context.Context, username, action string) error {
+	var required bool
+	switch action {
+	case verifiedContactActionBooking:
+		required = s.config.RequireForBooking
+	case verifiedContactActionWalletTopUp:
+		required = s.config.RequireForWalletTopUp
+	}
+	if !required {
+		return nil
+	}
+
+	customer, err := s.findCustomer(ctx, username)
+	if err != nil {
+		return err
+	}
+	if !customer.HasVerifiedContact() {
+		return utils.NewForbiddenError("CONTACT_NOT_VERIFIED", "Please verify your email or phone number first", nil)
+	}
+
+	return nil
+}
+
+func (s *contactVerificationService) findCustomer(ctx context.Context, username string) (*models.SkyCustomer, error) {
+	customer, err := s.skyCustomerRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No customer found with username: %s", username), nil)
+	}
+	return customer, nil
+}
+
+// verificationTarget returns where a code for the channel should go and whether there is
+// nothing left to verify.
+func verificationTarget(customer *models.SkyCustomer, channel string) (string, bool) {
+	if channel == models.VerificationChannelEmail {
+		if customer.PendingEmail != nil {
+			return *customer.PendingEmail, false
+		}
+		return customer.Email, customer.EmailVerifiedAt != nil
+	}
+	if customer.PendingNumber != nil {
+		return *customer.PendingNumber, false
+	}
+	return customer.Number, customer.NumberVerifiedAt != nil
+}
+
+func generateVerificationCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < constants.VERIFICATION_CODE_LENGTH; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", constants.VERIFICATION_CODE_LENGTH, n), nil
+}
+
+func channelLabel(channel string) string {
+	if channel == models.VerificationChannelEmail {
+		return "email"
+	}
+	return "phone number"
+}
+
+// maskDestination keeps just enough of an email or phone number for the customer to recognise it.
+func maskDestination(channel, destination string) string {
+	if channel == models.VerificationChannelEmail {
+		local, domain, found := strings.Cut(destination, "@")
+		if !found || local == "" {
+			return destination
+		}
+		return local[:1] + strings.Repeat("*", len(local)-1) + "@" + domain
+	}
+	if len(destination) <= 4 {
+		return destination
+	}
+	return strings.Repeat("*", len(destination)-4) + destination[len(destination)-4:]
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
 	paymentservice "github.com/iamsuteerth/skyfox-backend/pkg/payment-service"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
@@ -23,7 +24,7 @@ type CustomerBookingService interface {
 	InitializeBooking(ctx context.Context, username string, req request.InitializeBookingRequest) (*response.InitializeBookingResponse, error)
 	ProcessPayment(ctx context.Context, username string, req request.ProcessPaymentRequest) (*response.BookingResponse, error)
 	CancelPendingBooking(ctx context.Context, username string, bookingID int) error
-	GetBookingsForCustomer(ctx context.Context, username string) ([]response.CustomerBookingInfo, error)
+	GetBookingsForCustomer(ctx context.Context, username string, req request.CustomerBookingsRequest) (*response.CustomerBookingsResponse, error)
 	GetLatestBookingForCustomer(ctx context.Context, username string) (*response.CustomerBookingInfo, error)
 }
 
@@ -36,8 +37,14 @@ type customerBookingService struct {
 	slotRepo               repositories.SlotRepository
 	skyCustomerRepo        repositories.SkyCustomerRepository
 	customerWalletRepo     repositories.CustomerWalletRepository
-	walletTxdRepo          repositories.WalletTransactionRepository
+	walletLedgerRepo       repositories.WalletLedgerRepository
+	savedCardRepo          repositories.SavedCardRepository
 	paymentService         paymentservice.PaymentService
+	loyaltyService         LoyaltyService
+	movieService           movieservice.MovieService
+	bookingAuditRepo       repositories.BookingAuditRepository
+	seatEventBroker        SeatEventBroker
+	contactVerification    ContactVerificationService
 }
 
 func NewCustomerBookingService(
@@ -49,8 +56,14 @@ func NewCustomerBookingService(
 	slotRepo repositories.SlotRepository,
 	skyCustomerRepo repositories.SkyCustomerRepository,
 	customerWalletRepo repositories.CustomerWalletRepository,
-	walletTxdRepo repositories.WalletTransactionRepository,
+	walletLedgerRepo repositories.WalletLedgerRepository,
+	savedCardRepo repositories.SavedCardRepository,
 	paymentService paymentservice.PaymentService,
+	loyaltyService LoyaltyService,
+	movieService movieservice.MovieService,
+	bookingAuditRepo repositories.BookingAuditRepository,
+	seatEventBroker SeatEventBroker,
+	contactVerification ContactVerificationService,
 ) CustomerBookingService {
 	return &customerBookingService{
 		showRepo:               showRepo,
@@ -61,12 +74,22 @@ func NewCustomerBookingService(
 		slotRepo:               slotRepo,
 		skyCustomerRepo:        skyCustomerRepo,
 		customerWalletRepo:     customerWalletRepo,
-		walletTxdRepo:          walletTxdRepo,
+		walletLedgerRepo:       walletLedgerRepo,
+		savedCardRepo:          savedCardRepo,
 		paymentService:         paymentService,
+		loyaltyService:         loyaltyService,
+		movieService:           movieService,
+		bookingAuditRepo:       bookingAuditRepo,
+		seatEventBroker:        seatEventBroker,
+		contactVerification:    contactVerification,
 	}
 }
 
 func (s *customerBookingService) InitializeBooking(ctx context.Context, username string, req request.InitializeBookingRequest) (*response.InitializeBookingResponse, error) {
+	if err := s.contactVerification.CheckVerifiedContact(ctx, username, verifiedContactActionBooking); err != nil {
+		return nil, err
+	}
+
 	if len(req.SeatNumbers) > constants.MAX_NO_OF_SEATS_PER_BOOKING {
 		return nil, utils.NewBadRequestError("TOO_MANY_SEATS", fmt.Sprintf("Maximum %d seats can be booked per booking", constants.MAX_NO_OF_SEATS_PER_BOOKING), nil)
 	}
@@ -172,6 +195,10 @@ func (s *customerBookingService) InitializeBooking(ctx context.Context, username
 		return nil, err
 	}
 
+	recordBookingEvent(ctx, s.bookingAuditRepo, booking.Id, models.BookingEventCreated, username,
+		fmt.Sprintf("Pending booking for seats %s", strings.Join(req.SeatNumbers, ", ")))
+	publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateHeld, req.SeatNumbers)
+
 	go s.monitorBookingExpiration(booking.Id, expirationTime)
 
 	totalPriceFloat, _ := totalPrice.Float64()
@@ -219,7 +246,10 @@ func (s *customerBookingService) ProcessPayment(ctx context.Context, username st
 	}
 
 	if expirationTime == nil || time.Now().After(*expirationTime) {
+		heldSeats, _ := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, req.BookingID)
 		_ = s.bookingRepo.DeleteBookingsByIds(ctx, []int{req.BookingID})
+		recordBookingEvent(ctx, s.bookingAuditRepo, req.BookingID, models.BookingEventExpired, "", "Payment attempted after the booking expired")
+		publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateReleased, heldSeats)
 		return nil, utils.NewBadRequestError("BOOKING_EXPIRED", "This booking has expired. Please make a new booking", nil)
 	}
 
@@ -236,6 +266,7 @@ func (s *customerBookingService) ProcessPayment(ctx context.Context, username st
 	}
 
 	var transactionID string
+	var pointsUsed int
 
 	if req.PaymentMethod == "Wallet" {
 		wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, username)
@@ -250,160 +281,55 @@ func (s *customerBookingService) ProcessPayment(ctx context.Context, username st
 		walletBalance := wallet.Balance
 		bookingAmount := booking.AmountPaid
 
-		switch {
-		case walletBalance.Cmp(bookingAmount) != -1:
-			deductTxnID := uuid.New().String()
-			if err := s.customerWalletRepo.DeductFromWalletBalance(ctx, username, bookingAmount); err != nil {
-				return nil, err
-			}
-
-			walletTxn := &models.WalletTransaction{
-				WalletID:        wallet.ID,
-				Username:        username,
-				BookingID:       toPtr(int64(booking.Id)),
-				TransactionID:   deductTxnID,
-				Amount:          bookingAmount,
-				TransactionType: "DEDUCT",
-			}
-
-			if err := s.walletTxdRepo.AddWalletTransaction(ctx, walletTxn); err != nil {
-				if rollbackErr := s.customerWalletRepo.AddToWalletBalance(ctx, username, bookingAmount); rollbackErr != nil {
-					log.Error().Err(rollbackErr).Str("username", username).Msg("Failed to rollback wallet deduction")
-				}
-				return nil, err
-			}
-
-			payTxn := &models.PaymentTransaction{
-				BookingId:     booking.Id,
-				TransactionId: deductTxnID,
-				PaymentMethod: "Wallet",
-				Amount:        bookingAmount,
-				Status:        "Completed",
-			}
-
-			if err := s.paymentTransactionRepo.CreateTransaction(ctx, payTxn); err != nil {
-				log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to create wallet payment transaction")
-				return nil, err
-			}
-
-			transactionID = deductTxnID
-			booking.PaymentType = "Wallet"
-
-			if err := s.bookingRepo.UpdateBookingPaymentType(ctx, booking.Id, booking.PaymentType); err != nil {
-				log.Error().Err(err).Int("bookingId", booking.Id).Str("paymentType", booking.PaymentType).
-					Msg("Failed to update booking payment type after processing payment")
-				return nil, err
-			}
-
-		case walletBalance.Cmp(bookingAmount) == -1:
-			if req.CardNumber == "" || req.CVV == "" || req.ExpiryMonth == "" || req.ExpiryYear == "" || req.CardholderName == "" {
+		if walletBalance.Cmp(bookingAmount) == -1 {
+			if req.SavedCardID == nil && !hasCardDetails(req) {
 				return nil, utils.NewBadRequestError("INSUFFICIENT_BALANCE", "Not enough wallet balance and no card details provided", nil)
 			}
 			requiredTopUp, _ := bookingAmount.Sub(walletBalance)
 
-			expiry := fmt.Sprintf("%s/%s", req.ExpiryMonth, req.ExpiryYear)
-			addFundsTxnID, err := s.paymentService.ProcessPayment(
-				ctx,
-				req.CardNumber,
-				req.CVV,
-				expiry,
-				req.CardholderName,
-				requiredTopUp,
-			)
-
+			addFundsTxnID, err := s.chargeCard(ctx, username, req, requiredTopUp)
 			if err != nil {
 				log.Error().Err(err).Int("bookingID", req.BookingID).Msg("Partial payment card processing failed")
 				return nil, err
 			}
 
-			if err := s.customerWalletRepo.AddToWalletBalance(ctx, username, requiredTopUp); err != nil {
-				return nil, err
-			}
-
-			addFundsTxn := &models.WalletTransaction{
-				WalletID:        wallet.ID,
-				Username:        username,
-				BookingID:       nil,
-				TransactionID:   addFundsTxnID,
-				Amount:          requiredTopUp,
-				TransactionType: "ADD",
-			}
-
-			if err := s.walletTxdRepo.AddWalletTransaction(ctx, addFundsTxn); err != nil {
-				if rollbackErr := s.customerWalletRepo.DeductFromWalletBalance(ctx, username, requiredTopUp); rollbackErr != nil {
-					log.Error().Err(rollbackErr).Str("username", username).Msg("Failed to rollback wallet addition")
-				}
+			if err := s.walletLedgerRepo.PostEntry(ctx, newTopUpEntry(wallet.ID, addFundsTxnID, requiredTopUp)); err != nil {
+				log.Error().Err(err).Str("username", username).Str("transactionID", addFundsTxnID).
+					Msg("Failed to credit wallet top-up after card was charged")
 				return nil, err
 			}
+		}
 
-			deductTxnID := uuid.New().String()
-			if err := s.customerWalletRepo.DeductFromWalletBalance(ctx, username, bookingAmount); err != nil {
-				rollbackErr := s.customerWalletRepo.DeductFromWalletBalance(ctx, username, requiredTopUp)
-				if rollbackErr != nil {
-					log.Error().Err(rollbackErr).Str("username", username).
-						Str("operation", "rollback_addition").
-						Str("amount", requiredTopUp.String()).
-						Msg("Failed to rollback wallet addition after deduction failure")
-				} else {
-					log.Info().Str("username", username).
-						Str("amount", requiredTopUp.String()).
-						Msg("Successfully rolled back wallet addition after deduction failure")
-				}
-				return nil, err
-			}
+		deductTxnID := uuid.New().String()
+		if err := s.walletLedgerRepo.PostEntry(ctx, newBookingPaymentEntry(wallet.ID, deductTxnID, booking.Id, bookingAmount)); err != nil {
+			log.Error().Err(err).Str("username", username).Int("bookingId", booking.Id).Msg("Failed to deduct booking amount from wallet")
+			return nil, err
+		}
 
-			walletTxn := &models.WalletTransaction{
-				WalletID:        wallet.ID,
-				Username:        username,
-				BookingID:       toPtr(int64(booking.Id)),
-				TransactionID:   deductTxnID,
-				Amount:          bookingAmount,
-				TransactionType: "DEDUCT",
-			}
-			if err := s.walletTxdRepo.AddWalletTransaction(ctx, walletTxn); err != nil {
-				if rollbackErr := s.customerWalletRepo.AddToWalletBalance(ctx, username, bookingAmount); rollbackErr != nil {
-					log.Error().Err(rollbackErr).Str("username", username).Msg("Failed to rollback wallet deduction")
-				}
-				if rollbackErr := s.customerWalletRepo.DeductFromWalletBalance(ctx, username, requiredTopUp); rollbackErr != nil {
-					log.Error().Err(rollbackErr).Str("username", username).Msg("Failed to rollback wallet addition")
-				}
-				return nil, err
-			}
+		payTxn := &models.PaymentTransaction{
+			BookingId:     booking.Id,
+			TransactionId: deductTxnID,
+			PaymentMethod: "Wallet",
+			Amount:        bookingAmount,
+			Status:        "Completed",
+		}
 
-			payTxn := &models.PaymentTransaction{
-				BookingId:     booking.Id,
-				TransactionId: deductTxnID,
-				PaymentMethod: "Wallet",
-				Amount:        bookingAmount,
-				Status:        "Completed",
-			}
-			if err := s.paymentTransactionRepo.CreateTransaction(ctx, payTxn); err != nil {
-				log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to create partial wallet payment transaction")
-				return nil, err
-			}
-			transactionID = deductTxnID
-			booking.PaymentType = "Wallet"
+		if err := s.paymentTransactionRepo.CreateTransaction(ctx, payTxn); err != nil {
+			log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to create wallet payment transaction")
+			return nil, err
+		}
 
-			if err := s.bookingRepo.UpdateBookingPaymentType(ctx, booking.Id, booking.PaymentType); err != nil {
-				log.Error().Err(err).Int("bookingId", booking.Id).Str("paymentType", booking.PaymentType).
-					Msg("Failed to update booking payment type after processing payment")
-				return nil, err
-			}
+		transactionID = deductTxnID
+		booking.PaymentType = "Wallet"
 
-		default:
-			return nil, utils.NewBadRequestError("INSUFFICIENT_BALANCE", "Not enough wallet balance and no card details provided", nil)
+		if err := s.bookingRepo.UpdateBookingPaymentType(ctx, booking.Id, booking.PaymentType); err != nil {
+			log.Error().Err(err).Int("bookingId", booking.Id).Str("paymentType", booking.PaymentType).
+				Msg("Failed to update booking payment type after processing payment")
+			return nil, err
 		}
 
 	} else if req.PaymentMethod == "Card" {
-		expiry := fmt.Sprintf("%s/%s", req.ExpiryMonth, req.ExpiryYear)
-		transactionID, err = s.paymentService.ProcessPayment(
-			ctx,
-			req.CardNumber,
-			req.CVV,
-			expiry,
-			req.CardholderName,
-			booking.AmountPaid,
-		)
+		transactionID, err = s.chargeCard(ctx, username, req, booking.AmountPaid)
 		if err != nil {
 			log.Error().Err(err).Int("bookingID", req.BookingID).Msg("Card payment processing failed")
 			return nil, err
@@ -421,23 +347,68 @@ func (s *customerBookingService) ProcessPayment(ctx context.Context, username st
 			return nil, err
 		}
 		booking.PaymentType = "Card"
+	} else if req.PaymentMethod == "Points" {
+		pointsUsed, err = s.loyaltyService.RedeemPoints(ctx, username, booking.Id, booking.AmountPaid)
+		if err != nil {
+			log.Error().Err(err).Int("bookingID", req.BookingID).Msg("Loyalty points redemption failed")
+			return nil, err
+		}
+
+		transactionID = uuid.New().String()
+		payTxn := &models.PaymentTransaction{
+			BookingId:     booking.Id,
+			TransactionId: transactionID,
+			PaymentMethod: "Points",
+			Amount:        booking.AmountPaid,
+			Status:        "Completed",
+		}
+		if err := s.paymentTransactionRepo.CreateTransaction(ctx, payTxn); err != nil {
+			log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to create points payment transaction")
+			s.returnRedeemedPoints(ctx, booking.Id)
+			return nil, err
+		}
+
+		booking.PaymentType = "Points"
+		if err := s.bookingRepo.UpdateBookingPaymentType(ctx, booking.Id, booking.PaymentType); err != nil {
+			log.Error().Err(err).Int("bookingId", booking.Id).Str("paymentType", booking.PaymentType).
+				Msg("Failed to update booking payment type after processing payment")
+			s.returnRedeemedPoints(ctx, booking.Id)
+			return nil, err
+		}
 	} else {
 		return nil, utils.NewBadRequestError("INVALID_PAYMENT_METHOD", "Invalid payment method specified", nil)
 	}
 
 	if err := s.bookingRepo.UpdateBookingStatus(ctx, booking.Id, "Confirmed"); err != nil {
 		log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to update booking status")
+		if req.PaymentMethod == "Points" {
+			s.returnRedeemedPoints(ctx, booking.Id)
+		}
 		return nil, err
 	}
 	if err := s.pendingBookingRepo.RemoveTracker(ctx, booking.Id); err != nil {
 		log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to remove pending tracker")
 	}
 
+	recordBookingEvent(ctx, s.bookingAuditRepo, booking.Id, models.BookingEventPaymentReceived, username,
+		fmt.Sprintf("%s payment of %s, transaction %s", booking.PaymentType, booking.AmountPaid.String(), transactionID))
+
+	// Bookings paid with points don't earn points. A failed accrual is logged
+	// rather than failing a booking the customer has already paid for.
+	var pointsEarned int
+	if req.PaymentMethod != "Points" {
+		pointsEarned, err = s.loyaltyService.AccruePoints(ctx, username, booking.Id, booking.AmountPaid)
+		if err != nil {
+			log.Error().Err(err).Int("bookingId", booking.Id).Str("username", username).Msg("Failed to accrue loyalty points")
+		}
+	}
+
 	seatNumbers, err := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, booking.Id)
 	if err != nil {
 		log.Error().Err(err).Int("bookingId", booking.Id).Msg("Failed to get seat numbers")
 		return nil, err
 	}
+	publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateBooked, seatNumbers)
 
 	bookingAmtPaid, _ := booking.AmountPaid.Float64()
 
@@ -454,12 +425,46 @@ func (s *customerBookingService) ProcessPayment(ctx context.Context, username st
 		BookingTime:   booking.BookingTime,
 		Status:        "Confirmed",
 		TransactionID: transactionID,
+		PointsEarned:  pointsEarned,
+		PointsUsed:    pointsUsed,
 	}
 	return response, nil
 }
 
+func (s *customerBookingService) chargeCard(ctx context.Context, username string, req request.ProcessPaymentRequest, amount decimal.Decimal) (string, error) {
+	if req.SavedCardID != nil {
+		return chargeSavedCard(ctx, s.savedCardRepo, s.paymentService, username, *req.SavedCardID, amount)
+	}
+
+	if !hasCardDetails(req) {
+		return "", utils.NewBadRequestError("CARD_DETAILS_REQUIRED", "Card details or a saved card are required for card payments", nil)
+	}
+
+	expiry := fmt.Sprintf("%s/%s", req.ExpiryMonth, req.ExpiryYear)
+	return s.paymentService.ProcessPayment(
+		ctx,
+		req.CardNumber,
+		req.CVV,
+		expiry,
+		req.CardholderName,
+		amount,
+	)
+}
+
+func hasCardDetails(req request.ProcessPaymentRequest) bool {
+	return req.CardNumber != "" && req.CVV != "" && req.ExpiryMonth != "" && req.ExpiryYear != "" && req.CardholderName != ""
+}
+
 func toPtr[T any](v T) *T { return &v }
 
+// returnRedeemedPoints undoes a points redemption when the booking could not be confirmed
+// afterwards, so the customer is not charged points for an unconfirmed booking.
+func (s *customerBookingService) returnRedeemedPoints(ctx context.Context, bookingID int) {
+	if err := s.loyaltyService.CancelRedemption(ctx, bookingID); err != nil {
+		log.Error().Err(err).Int("bookingId", bookingID).Msg("Failed to return redeemed loyalty points after payment failed")
+	}
+}
+
 func (s *customerBookingService) CancelPendingBooking(ctx context.Context, username string, bookingID int) error {
 	booking, err := s.bookingRepo.GetBookingById(ctx, bookingID)
 	if err != nil {
@@ -480,6 +485,11 @@ func (s *customerBookingService) CancelPendingBooking(ctx context.Context, usern
 		return utils.NewBadRequestError("INVALID_BOOKING_STATUS", "Only pending bookings can be cancelled", nil)
 	}
 
+	heldSeats, err := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, bookingID)
+	if err != nil {
+		log.Warn().Err(err).Int("bookingID", bookingID).Msg("Failed to get held seats during cancellation")
+	}
+
 	if err := s.bookingRepo.DeleteBookingsByIds(ctx, []int{bookingID}); err != nil {
 		log.Error().Err(err).Int("bookingID", bookingID).Msg("Failed to delete booking during cancellation")
 		return err
@@ -489,6 +499,9 @@ func (s *customerBookingService) CancelPendingBooking(ctx context.Context, usern
 		log.Warn().Err(err).Int("bookingID", bookingID).Msg("Failed to remove pending tracker during cancellation")
 	}
 
+	recordBookingEvent(ctx, s.bookingAuditRepo, bookingID, models.BookingEventCancelled, username, "Cancelled by customer before payment")
+	publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateReleased, heldSeats)
+
 	log.Info().Int("bookingID", bookingID).Str("username", username).Msg("Booking successfully cancelled")
 	return nil
 }
@@ -524,90 +537,97 @@ func (s *customerBookingService) monitorBookingExpiration(bookingId int, expirat
 	}
 
 	if booking.Status == "Pending" {
+		heldSeats, _ := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, bookingId)
 		if err := s.bookingRepo.DeleteBookingsByIds(ctx, []int{bookingId}); err != nil {
 			log.Error().Err(err).Int("bookingId", bookingId).Msg("Failed to delete expired booking")
 		} else {
+			recordBookingEvent(ctx, s.bookingAuditRepo, bookingId, models.BookingEventExpired, "", "Payment window elapsed")
+			publishSeatEvent(ctx, s.seatEventBroker, booking.ShowId, models.SeatStateReleased, heldSeats)
 			log.Info().Int("bookingId", bookingId).Msg("Successfully deleted expired booking")
 		}
 	}
 }
 
-func (s *customerBookingService) GetBookingsForCustomer(ctx context.Context, username string) ([]response.CustomerBookingInfo, error) {
-	bookings, err := s.bookingRepo.FindByCustomerUsername(ctx, username)
+func (s *customerBookingService) GetBookingsForCustomer(ctx context.Context, username string, req request.CustomerBookingsRequest) (*response.CustomerBookingsResponse, error) {
+	page, err := parseHistoryPage(req.Cursor, req.From, req.To, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := parseBookingStatuses(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.CustomerBookingFilter{
+		Username: username,
+		Statuses: statuses,
+		Since:    page.since,
+		Until:    page.until,
+		After:    page.after,
+		Limit:    page.limit + 1,
+	}
+
+	if req.When != "" {
+		upcoming := req.When == "upcoming"
+		filter.Upcoming = &upcoming
+	}
+
+	bookings, err := s.bookingRepo.FindCustomerBookings(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]response.CustomerBookingInfo, 0, len(bookings))
+	resp := &response.CustomerBookingsResponse{Bookings: make([]response.CustomerBookingInfo, 0, len(bookings))}
+	if len(bookings) > page.limit {
+		bookings = bookings[:page.limit]
+		last := bookings[len(bookings)-1]
+		cursor := utils.EncodeCursor(last.BookingTime, int64(last.BookingID))
+		resp.NextCursor = &cursor
+	}
+
+	movies := make(map[string]*models.Movie)
 	for _, booking := range bookings {
-		show, err := s.showRepo.FindById(ctx, booking.ShowId)
-		if err != nil || show == nil {
-			log.Warn().Int("booking_id", booking.Id).Msg("Skipping booking due to missing show data")
-			continue
-		}
-		slot, err := s.slotRepo.GetSlotById(ctx, show.SlotId)
-		if err != nil || slot == nil {
-			log.Warn().Int("booking_id", booking.Id).Msg("Skipping booking due to missing slot data")
-			continue
-		}
-		seats, err := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, booking.Id)
-		if err != nil {
-			log.Warn().Int("booking_id", booking.Id).Msg("Failed to fetch seats, using empty list")
-			seats = []string{}
-		}
+		resp.Bookings = append(resp.Bookings, toCustomerBookingInfo(ctx, s.movieService, booking, movies))
+	}
 
-		bookingAmtPaid, _ := booking.AmountPaid.Float64()
-
-		result = append(result, response.CustomerBookingInfo{
-			BookingID:   booking.Id,
-			ShowID:      booking.ShowId,
-			ShowDate:    show.Date.Format("2006-01-02"),
-			ShowTime:    slot.StartTime,
-			SeatNumbers: seats,
-			AmountPaid:  bookingAmtPaid,
-			PaymentType: booking.PaymentType,
-			BookingTime: booking.BookingTime,
-			Status:      booking.Status,
-		})
-	}
-	return result, nil
+	return resp, nil
 }
 
 func (s *customerBookingService) GetLatestBookingForCustomer(ctx context.Context, username string) (*response.CustomerBookingInfo, error) {
-	booking, err := s.bookingRepo.FindLatestByCustomerUsername(ctx, username)
+	bookings, err := s.bookingRepo.FindCustomerBookings(ctx, models.CustomerBookingFilter{Username: username, Limit: 1})
 	if err != nil {
 		return nil, err
 	}
-	if booking == nil {
+	if len(bookings) == 0 {
 		return nil, nil
 	}
-	show, err := s.showRepo.FindById(ctx, booking.ShowId)
-	if err != nil || show == nil {
-		log.Warn().Int("booking_id", booking.Id).Msg("No show found for booking, returning nil")
-		return nil, nil
-	}
-	slot, err := s.slotRepo.GetSlotById(ctx, show.SlotId)
-	if err != nil || slot == nil {
-		log.Warn().Int("booking_id", booking.Id).Msg("No slot found for booking, returning nil")
-		return nil, nil
-	}
-	seats, err := s.bookingSeatMappingRepo.GetSeatsByBookingId(ctx, booking.Id)
-	if err != nil {
-		log.Warn().Int("booking_id", booking.Id).Msg("Failed to fetch seats, using empty list")
-		seats = []string{}
-	}
 
+	info := toCustomerBookingInfo(ctx, s.movieService, bookings[0], make(map[string]*models.Movie))
+	return &info, nil
+}
+
+func toCustomerBookingInfo(ctx context.Context, movieService movieservice.MovieService, booking models.CustomerBookingRecord, movies map[string]*models.Movie) response.CustomerBookingInfo {
 	bookingAmtPaid, _ := booking.AmountPaid.Float64()
 
-	return &response.CustomerBookingInfo{
-		BookingID:   booking.Id,
-		ShowID:      booking.ShowId,
-		ShowDate:    show.Date.Format("2006-01-02"),
-		ShowTime:    slot.StartTime,
-		SeatNumbers: seats,
+	info := response.CustomerBookingInfo{
+		BookingID:   booking.BookingID,
+		ShowID:      booking.ShowID,
+		MovieID:     booking.MovieID,
+		ShowDate:    booking.ShowDate.Format("2006-01-02"),
+		ShowTime:    booking.StartTime,
+		SlotName:    booking.SlotName,
+		SeatNumbers: booking.SeatNumbers,
 		AmountPaid:  bookingAmtPaid,
 		PaymentType: booking.PaymentType,
 		BookingTime: booking.BookingTime,
 		Status:      booking.Status,
-	}, nil
+	}
+
+	if movie := lookupMovie(ctx, movieService, booking.MovieID, movies); movie != nil {
+		info.MovieName = movie.Name
+		info.MoviePoster = movie.MoviePoster
+	}
+
+	return info
 }
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	customerDataExportFileName   = "customer_data.json"
+	customerDataExportImageName  = "profile_image"
+	customerDataExportTimeFormat = "20060102_150405"
+)
+
+type CustomerPrivacyService interface {
+	ExportCustomerData(ctx context.Context, username, format string) (*CustomerDataExportFile, error)
+	DeleteCustomerAccount(ctx context.Context, username, password string) (*response.DeleteAccountResponse, error)
+}
+
+// CustomerDataExportFile is a prepared data export, ready to be written with Write.
+type CustomerDataExportFile struct {
+	FileName    string
+	ContentType string
+	data        *response.CustomerDataExport
+	image       []byte
+	imageName   string
+	zipped      bool
+}
+
+type customerPrivacyService struct {
+	skyCustomerRepo repositories.SkyCustomerRepository
+	userRepo        repositories.UserRepository
+	walletRepo      repositories.CustomerWalletRepository
+	walletTxdRepo   repositories.WalletTransactionRepository
+	bookingRepo     repositories.BookingRepository
+	storageService  StorageService
+	movieService    movieservice.MovieService
+	watchlistRepo   repositories.WatchlistRepository
+	reviewRepo      repositories.MovieReviewRepository
+}
+
+func NewCustomerPrivacyService(
+	skyCustomerRepo repositories.SkyCustomerRepository,
+	userRepo repositories.UserRepository,
+	walletRepo repositories.CustomerWalletRepository,
+	walletTxdRepo repositories.WalletTransactionRepository,
+	bookingRepo repositories.BookingRepository,
+	storageService StorageService,
+	movieService movieservice.MovieService,
+	watchlistRepo repositories.WatchlistRepository,
+	reviewRepo repositories.MovieReviewRepository,
+) CustomerPrivacyService {
+	return &customerPrivacyService{
+		skyCustomerRepo: skyCustomerRepo,
+		userRepo:        userRepo,
+		walletRepo:      walletRepo,
+		walletTxdRepo:   walletTxdRepo,
+		bookingRepo:     bookingRepo,
+		storageService:  storageService,
+		movieService:    movieService,
+		watchlistRepo:   watchlistRepo,
+		reviewRepo:      reviewRepo,
+	}
+}
+
+func (s *customerPrivacyService) ExportCustomerData(ctx context.Context, username, format string) (*CustomerDataExportFile, error) {
+	customer, err := s.skyCustomerRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No customer found with username: %s", username), nil)
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching user details", err)
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No user found with username: %s", username), nil)
+	}
+
+	now := time.Now()
+	data := &response.CustomerDataExport{
+		GeneratedAt: now.Format(time.RFC3339),
+		Profile: response.CustomerDataExportProfile{
+			Username:           customer.Username,
+			Name:               customer.Name,
+			Email:              customer.Email,
+			PhoneNumber:        customer.Number,
+			SecurityQuestionID: customer.SecurityQuestionID,
+			CreatedAt:          user.CreatedAt.Format(time.RFC3339),
+		},
+		Bookings:  []response.CustomerBookingInfo{},
+		Wallet:    response.CustomerDataExportWallet{Transactions: []response.WalletTransactionResponse{}},
+		Watchlist: []response.WatchlistMovieResponse{},
+		Reviews:   []response.MovieReviewResponse{},
+	}
+
+	movies := make(map[string]*models.Movie)
+
+	bookingFilter := models.CustomerBookingFilter{Username: username, Limit: constants.DATA_EXPORT_PAGE_SIZE}
+	for {
+		bookings, err := s.bookingRepo.FindCustomerBookings(ctx, bookingFilter)
+		if err != nil {
+			return nil, err
+		}
+		for _, booking := range bookings {
+			data.Bookings = append(data.Bookings, toCustomerBookingInfo(ctx, s.movieService, booking, movies))
+		}
+		if len(bookings) < bookingFilter.Limit {
+			break
+		}
+		last := bookings[len(bookings)-1]
+		bookingFilter.After = &models.PageCursor{Time: last.BookingTime, ID: int64(last.BookingID)}
+	}
+
+	wallet, err := s.walletRepo.GetWalletByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if wallet != nil {
+		data.Wallet.Balance, _ = wallet.Balance.Float64()
+
+		txnFilter := models.WalletTransactionFilter{Username: username, Limit: constants.DATA_EXPORT_PAGE_SIZE}
+		for {
+			transactions, err := s.walletTxdRepo.GetWalletTransactionsPage(ctx, txnFilter)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range transactions {
+				data.Wallet.Transactions = append(data.Wallet.Transactions, toWalletTransactionResponse(ctx, s.movieService, t, movies))
+			}
+			if len(transactions) < txnFilter.Limit {
+				break
+			}
+			last := transactions[len(transactions)-1]
+			txnFilter.After = &models.PageCursor{Time: last.Timestamp, ID: last.ID}
+		}
+	}
+
+	watchlist, err := s.watchlistRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range watchlist {
+		data.Watchlist = append(data.Watchlist, response.WatchlistMovieResponse{
+			MovieId: item.MovieId,
+			AddedAt: item.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	reviews, err := s.reviewRepo.Find(ctx, models.MovieReviewFilter{Username: username})
+	if err != nil {
+		return nil, err
+	}
+	data.Reviews = toMovieReviewListResponse(reviews, toMovieReviewResponse).Reviews
+
+	export := &CustomerDataExportFile{
+		FileName:    fmt.Sprintf("skyfox_data_%s_%s.%s", username, now.Format(customerDataExportTimeFormat), format),
+		ContentType: "application/json",
+		data:        data,
+		zipped:      format == "zip",
+	}
+	if export.zipped {
+		export.ContentType = "application/zip"
+	}
+
+	if customer.ProfileImg != "" {
+		image, contentType, err := s.storageService.GetProfileImage(ctx, customer.ProfileImg, constants.PROFILE_IMAGE_DEFAULT_VARIANT)
+		if err != nil {
+			return nil, err
+		}
+
+		data.ProfileImage = &response.CustomerDataExportImage{ContentType: contentType}
+		if export.zipped {
+			export.imageName = customerDataExportImageName + ".jpg"
+			if contentType == "image/webp" {
+				export.imageName = customerDataExportImageName + ".webp"
+			}
+			data.ProfileImage.FileName = export.imageName
+			export.image = image
+		} else {
+			data.ProfileImage.Data = base64.StdEncoding.EncodeToString(image)
+		}
+	}
+
+	return export, nil
+}
+
+// Write writes the export as a JSON document or, for ZIP exports, as an archive holding the
+// JSON document and the profile image.
+func (e *CustomerDataExportFile) Write(w io.Writer) error {
+	if !e.zipped {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(e.data)
+	}
+
+	archive := zip.NewWriter(w)
+
+	dataFile, err := archive.Create(customerDataExportFileName)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(dataFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(e.data); err != nil {
+		return err
+	}
+
+	if len(e.image) > 0 {
+		imageFile, err := archive.Create(e.imageName)
+		if err != nil {
+			return err
+		}
+		if _, err := imageFile.Write(e.image); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// DeleteCustomerAccount deletes a customer after checking their password. The wallet must be
+// empty and no booking may be awaiting payment. Bookings are anonymized rather than deleted so
+// revenue figures do not change, and ledger entries are left untouched.
+func (s *customerPrivacyService) DeleteCustomerAccount(ctx context.Context, username, password string) (*response.DeleteAccountResponse, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, utils.NewInternalServerError("DATABASE_ERROR", "Error fetching user details", err)
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No user found with username: %s", username), nil)
+	}
+
+	if !utils.CheckPasswordHash(password, user.Password) {
+		return nil, utils.NewBadRequestError("INCORRECT_PASSWORD", "Password is incorrect", nil)
+	}
+
+	customer, err := s.skyCustomerRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No customer found with username: %s", username), nil)
+	}
+
+	wallet, err := s.walletRepo.GetWalletByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if wallet != nil && !wallet.Balance.IsZero() {
+		return nil, utils.NewBadRequestError(
+			"WALLET_BALANCE_NOT_ZERO",
+			fmt.Sprintf("Wallet balance of %s must be used or refunded before the account can be deleted", wallet.Balance.String()),
+			nil,
+		)
+	}
+
+	pending, err := s.bookingRepo.FindCustomerBookings(ctx, models.CustomerBookingFilter{
+		Username: username,
+		Statuses: []string{"Pending"},
+		Limit:    1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, utils.NewBadRequestError("PENDING_BOOKING_EXISTS", "Complete or cancel the pending booking before deleting the account", nil)
+	}
+
+	anonymized, err := s.skyCustomerRepo.DeleteCustomerAccount(ctx, username, constants.DELETED_CUSTOMER_NAME, constants.DELETED_CUSTOMER_NUMBER)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer.ProfileImg != "" {
+		if err := s.storageService.DeleteProfileImage(ctx, customer.ProfileImg); err != nil {
+			log.Warn().Err(err).Str("username", username).Str("profileImg", customer.ProfileImg).Msg("Account deleted but profile image could not be removed")
+		}
+	}
+
+	log.Info().Str("username", username).Int("anonymizedBookings", anonymized).Msg("Customer account deleted")
+
+	return &response.DeleteAccountResponse{
+		Username:           username,
+		AnonymizedBookings: anonymized,
+	}, nil
+}
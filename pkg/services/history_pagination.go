@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// historyPage holds the paging parameters shared by the customer history endpoints.
+type historyPage struct {
+	since *time.Time
+	until *time.Time
+	after *models.PageCursor
+	limit int
+}
+
+// parseHistoryPage validates the cursor, page size and inclusive from/to dates of a
+// history request. until is returned exclusive.
+func parseHistoryPage(cursor, from, to string, limit *int) (*historyPage, error) {
+	page := &historyPage{limit: constants.DEFAULT_HISTORY_PAGE_SIZE}
+	if limit != nil {
+		page.limit = *limit
+	}
+
+	if cursor != "" {
+		cursorTime, cursorID, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, utils.NewBadRequestError("INVALID_CURSOR", "Cursor is invalid", err)
+		}
+		page.after = &models.PageCursor{Time: cursorTime, ID: cursorID}
+	}
+
+	if from != "" {
+		since, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			return nil, utils.NewBadRequestError("INVALID_DATE", "From must be a date in YYYY-MM-DD format", err)
+		}
+		page.since = &since
+	}
+	if to != "" {
+		until, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			return nil, utils.NewBadRequestError("INVALID_DATE", "To must be a date in YYYY-MM-DD format", err)
+		}
+		until = until.AddDate(0, 0, 1)
+		page.until = &until
+	}
+	if page.since != nil && page.until != nil && !page.since.Before(*page.until) {
+		return nil, utils.NewBadRequestError("INVALID_DATE", "From must not be after to", nil)
+	}
+
+	return page, nil
+}
+
+// parseBookingStatuses validates a comma-separated list of booking statuses. An empty list
+// returns nil, meaning any status.
+func parseBookingStatuses(list string) ([]string, error) {
+	var statuses []string
+	for _, status := range strings.Split(list, ",") {
+		if status = strings.TrimSpace(status); status == "" {
+			continue
+		}
+		if status != "Pending" && status != "Confirmed" && status != "CheckedIn" && status != "Refunded" {
+			return nil, utils.NewBadRequestError("INVALID_STATUS", fmt.Sprintf("Unknown booking status %q", status), nil)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// lookupMovie fetches a movie once per page. A missing movie only costs its name and
+// poster, so failures are logged and cached as nil.
+func lookupMovie(ctx context.Context, movieService movieservice.MovieService, movieID string, movies map[string]*models.Movie) *models.Movie {
+	if movie, exists := movies[movieID]; exists {
+		return movie
+	}
+
+	movie, err := movieService.GetMovieById(ctx, movieID)
+	if err != nil {
+		log.Warn().Err(err).Str("movieId", movieID).Msg("Movie not found for history entry")
+		movie = nil
+	}
+
+	movies[movieID] = movie
+	return movie
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"image"
+)
+
+// ProfileImageReview is an uploaded profile image after decoding and orientation, before any
+// variant is stored.
+type ProfileImageReview struct {
+	Username    string
+	ContentType string
+	Image       image.Image
+}
+
+type ModerationDecision struct {
+	Approved bool
+	Reason   string
+}
+
+// ImageModerator decides whether a profile image may be stored. A rejected image is reported
+// to the customer with the decision's reason; an error fails the upload. New moderators
+// implement this interface and are passed to NewStorageService.
+type ImageModerator interface {
+	Name() string
+	Moderate(ctx context.Context, review ProfileImageReview) (ModerationDecision, error)
+}
+
+// noopImageModerator approves every image. It is the default.
+type noopImageModerator struct{}
+
+func NewNoopImageModerator() ImageModerator {
+	return &noopImageModerator{}
+}
+
+func (m *noopImageModerator) Name() string {
+	return "none"
+}
+
+func (m *noopImageModerator) Moderate(ctx context.Context, review ProfileImageReview) (ModerationDecision, error) {
+	return ModerationDecision{Approved: true}, nil
+}
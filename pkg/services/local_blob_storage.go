@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// localBlobStorage keeps objects in a directory on disk. Its presigned URLs point at the
+// Skyfox file route and carry an expiry and an HMAC signature over the key and expiry, so
+// they can be shared the same way as S3 presigned URLs. It is meant for local development
+// and single-instance deployments.
+type localBlobStorage struct {
+	root      string
+	publicURL string
+	secret    []byte
+}
+
+func newLocalBlobStorage(root, publicURL, secret string) *localBlobStorage {
+	return &localBlobStorage{
+		root:      root,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		secret:    []byte(secret),
+	}
+}
+
+func (s *localBlobStorage) Name() string {
+	return "local"
+}
+
+func (s *localBlobStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to create storage directory")
+		return utils.NewInternalServerError("STORAGE_UPLOAD_FAILED", "Failed to upload file", err)
+	}
+
+	// Write to a temporary file first so readers never see a partly written object.
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to create temporary file")
+		return utils.NewInternalServerError("STORAGE_UPLOAD_FAILED", "Failed to upload file", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to write file")
+		return utils.NewInternalServerError("STORAGE_UPLOAD_FAILED", "Failed to upload file", err)
+	}
+	if err := tmp.Close(); err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to write file")
+		return utils.NewInternalServerError("STORAGE_UPLOAD_FAILED", "Failed to upload file", err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to move file into place")
+		return utils.NewInternalServerError("STORAGE_UPLOAD_FAILED", "Failed to upload file", err)
+	}
+
+	return nil
+}
+
+func (s *localBlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, utils.NewNotFoundError("FILE_NOT_FOUND", "File not found", err)
+		}
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to read file")
+		return nil, utils.NewInternalServerError("STORAGE_DOWNLOAD_FAILED", "Failed to download file", err)
+	}
+
+	return data, nil
+}
+
+func (s *localBlobStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to delete file")
+		return utils.NewInternalServerError("STORAGE_DELETE_FAILED", "Failed to delete file", err)
+	}
+
+	return nil
+}
+
+func (s *localBlobStorage) List(ctx context.Context, prefix string) ([]BlobObject, error) {
+	var objects []BlobObject
+	err := filepath.WalkDir(s.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		// Skip directories and the temporary files of uploads still being written.
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		relative, err := filepath.Rel(s.root, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relative)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, BlobObject{Key: key, LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("prefix", prefix).Msg("Failed to list files")
+		return nil, utils.NewInternalServerError("STORAGE_LIST_FAILED", "Failed to list files", err)
+	}
+
+	return objects, nil
+}
+
+func (s *localBlobStorage) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validateObjectKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+
+	return fmt.Sprintf("%s%s/%s?expires=%s&signature=%s",
+		s.publicURL,
+		constants.FilesEndPoint,
+		strings.Join(parts, "/"),
+		expires,
+		s.sign(key, expires),
+	), nil
+}
+
+// OpenSigned returns the object and its content type if the signature matches and the URL
+// has not expired.
+func (s *localBlobStorage) OpenSigned(ctx context.Context, key, expires, signature string) ([]byte, string, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return nil, "", utils.NewForbiddenError("INVALID_SIGNATURE", "The file URL is invalid", err)
+	}
+	if time.Now().Unix() > expiresAt {
+		return nil, "", utils.NewForbiddenError("URL_EXPIRED", "The file URL has expired", nil)
+	}
+
+	data, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return data, contentType, nil
+}
+
+func (s *localBlobStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *localBlobStorage) filePath(key string) (string, error) {
+	if err := validateObjectKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
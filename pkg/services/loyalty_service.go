@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const loyaltyHistoryLimit = 50
+
+type LoyaltyService interface {
+	GetLoyaltySummary(ctx context.Context, username string) (*response.LoyaltyResponse, error)
+	AccruePoints(ctx context.Context, username string, bookingID int, amount decimal.Decimal) (int, error)
+	RedeemPoints(ctx context.Context, username string, bookingID int, amount decimal.Decimal) (int, error)
+	CancelRedemption(ctx context.Context, bookingID int) error
+	// ReverseRefundedBooking takes back points earned on a refunded booking and returns points
+	// redeemed for it. It is idempotent and returns no reversals once the booking is reversed.
+	ReverseRefundedBooking(ctx context.Context, actor string, bookingID int, reason string) ([]response.LoyaltyTransactionResponse, error)
+	ReverseBookingPoints(ctx context.Context, adminUsername string, req request.LoyaltyReversalRequest) (*response.LoyaltyReversalResponse, error)
+}
+
+type loyaltyService struct {
+	loyaltyRepo      repositories.LoyaltyRepository
+	bookingAuditRepo repositories.BookingAuditRepository
+	config           config.LoyaltyConfig
+	bookingRepo      repositories.BookingRepository
+}
+
+func NewLoyaltyService(loyaltyRepo repositories.LoyaltyRepository, bookingAuditRepo repositories.BookingAuditRepository, loyaltyConfig config.LoyaltyConfig, bookingRepo repositories.BookingRepository) LoyaltyService {
+	return &loyaltyService{
+		loyaltyRepo:      loyaltyRepo,
+		bookingAuditRepo: bookingAuditRepo,
+		config:           loyaltyConfig,
+		bookingRepo:      bookingRepo,
+	}
+}
+
+func (s *loyaltyService) GetLoyaltySummary(ctx context.Context, username string) (*response.LoyaltyResponse, error) {
+	account, err := s.loyaltyRepo.GetAccount(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		account = &models.LoyaltyAccount{Username: username}
+	}
+
+	transactions, err := s.loyaltyRepo.GetTransactions(ctx, username, loyaltyHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]response.LoyaltyTransactionResponse, 0, len(transactions))
+	for _, t := range transactions {
+		history = append(history, toLoyaltyTransactionResponse(t))
+	}
+
+	tier, next := s.tierFor(account.LifetimePoints)
+
+	resp := &response.LoyaltyResponse{
+		PointsBalance:   account.PointsBalance,
+		LifetimePoints:  account.LifetimePoints,
+		Tier:            tier.Name,
+		RedeemableValue: float64(max(account.PointsBalance, 0)) * s.config.RupeesPerPoint,
+		History:         history,
+	}
+	if next != nil {
+		resp.NextTier = next.Name
+		resp.PointsToNextTier = next.MinPoints - account.LifetimePoints
+	}
+
+	return resp, nil
+}
+
+// AccruePoints credits points for a confirmed booking and returns how many were earned.
+func (s *loyaltyService) AccruePoints(ctx context.Context, username string, bookingID int, amount decimal.Decimal) (int, error) {
+	rate, err := decimal.NewFromFloat64(s.config.PointsPerRupee)
+	if err != nil {
+		return 0, utils.NewInternalServerError("LOYALTY_CONFIG_ERROR", "Invalid loyalty earn rate", err)
+	}
+
+	earned, err := amount.Mul(rate)
+	if err != nil {
+		return 0, utils.NewInternalServerError("LOYALTY_CALCULATION_ERROR", "Failed to calculate loyalty points", err)
+	}
+
+	points, _, ok := earned.Floor(0).Int64(0)
+	if !ok || points <= 0 {
+		return 0, nil
+	}
+
+	description := fmt.Sprintf("Earned on booking #%d", bookingID)
+	credited, err := s.loyaltyRepo.EarnPoints(ctx, username, int64(bookingID), int(points), description)
+	if err != nil {
+		return 0, err
+	}
+	if !credited {
+		log.Info().Int("bookingId", bookingID).Msg("Loyalty points already earned for booking")
+		return 0, nil
+	}
+
+	return int(points), nil
+}
+
+// RedeemPoints pays the full booking amount with points and returns how many were used.
+func (s *loyaltyService) RedeemPoints(ctx context.Context, username string, bookingID int, amount decimal.Decimal) (int, error) {
+	value, err := decimal.NewFromFloat64(s.config.RupeesPerPoint)
+	if err != nil {
+		return 0, utils.NewInternalServerError("LOYALTY_CONFIG_ERROR", "Invalid loyalty point value", err)
+	}
+
+	required, err := amount.Quo(value)
+	if err != nil {
+		return 0, utils.NewInternalServerError("LOYALTY_CALCULATION_ERROR", "Failed to calculate loyalty points", err)
+	}
+
+	points, _, ok := required.Ceil(0).Int64(0)
+	if !ok || points <= 0 {
+		return 0, utils.NewBadRequestError("INVALID_AMOUNT", "Booking amount cannot be paid with points", nil)
+	}
+
+	description := fmt.Sprintf("Redeemed for booking #%d", bookingID)
+	if err := s.loyaltyRepo.RedeemPoints(ctx, username, int64(bookingID), int(points), description); err != nil {
+		return 0, err
+	}
+
+	return int(points), nil
+}
+
+// CancelRedemption gives back the points redeemed for a booking whose payment failed after
+// the redemption went through.
+func (s *loyaltyService) CancelRedemption(ctx context.Context, bookingID int) error {
+	return s.loyaltyRepo.CancelRedemption(ctx, int64(bookingID))
+}
+
+func (s *loyaltyService) ReverseRefundedBooking(ctx context.Context, actor string, bookingID int, reason string) ([]response.LoyaltyTransactionResponse, error) {
+	reversals, err := s.loyaltyRepo.ReverseBookingPoints(ctx, int64(bookingID), reason, actor)
+	if err != nil {
+		log.Error().Err(err).Int("bookingId", bookingID).Str("actor", actor).Msg("Failed to reverse loyalty points")
+		return nil, err
+	}
+
+	resp := make([]response.LoyaltyTransactionResponse, 0, len(reversals))
+	for _, r := range reversals {
+		resp = append(resp, toLoyaltyTransactionResponse(r))
+	}
+	if len(reversals) > 0 {
+		recordBookingEvent(ctx, s.bookingAuditRepo, bookingID, models.BookingEventLoyaltyReversed, actor, reason)
+	}
+
+	return resp, nil
+}
+
+// ReverseBookingPoints lets an admin retry the loyalty reversal of a refunded booking. Bookings
+// are refunded through the booking refund endpoint, which reverses points itself.
+func (s *loyaltyService) ReverseBookingPoints(ctx context.Context, adminUsername string, req request.LoyaltyReversalRequest) (*response.LoyaltyReversalResponse, error) {
+	booking, err := s.bookingRepo.GetBookingById(ctx, req.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != "Refunded" {
+		return nil, utils.NewBadRequestError("BOOKING_NOT_REFUNDED", "Loyalty points can only be reversed for a refunded booking", nil)
+	}
+
+	reversals, err := s.ReverseRefundedBooking(ctx, adminUsername, req.BookingID, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if len(reversals) == 0 {
+		return nil, utils.NewBadRequestError("NOTHING_TO_REVERSE", "Booking has no loyalty points left to reverse", nil)
+	}
+
+	return &response.LoyaltyReversalResponse{
+		BookingID: req.BookingID,
+		Reversals: reversals,
+	}, nil
+}
+
+// tierFor returns the highest tier reached with the given lifetime points and the tier after it, if any.
+func (s *loyaltyService) tierFor(lifetimePoints int) (config.LoyaltyTier, *config.LoyaltyTier) {
+	current := s.config.Tiers[0]
+	var next *config.LoyaltyTier
+
+	for i := range s.config.Tiers {
+		tier := s.config.Tiers[i]
+		if lifetimePoints >= tier.MinPoints {
+			if tier.MinPoints >= current.MinPoints {
+				current = tier
+			}
+		} else if next == nil || tier.MinPoints < next.MinPoints {
+			next = &tier
+		}
+	}
+
+	return current, next
+}
+
+func toLoyaltyTransactionResponse(t models.LoyaltyTransaction) response.LoyaltyTransactionResponse {
+	resp := response.LoyaltyTransactionResponse{
+		ID:              t.ID,
+		TransactionType: t.TransactionType,
+		Points:          t.Points,
+		BookingID:       t.BookingID,
+		Description:     t.Description,
+	}
+	if !t.CreatedAt.IsZero() {
+		resp.Timestamp = t.CreatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
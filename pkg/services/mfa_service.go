@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Recovery codes avoid characters that are easy to misread, such as 0/o and 1/l/i.
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// MFAService manages TOTP multi-factor authentication for admin and staff accounts.
+type MFAService interface {
+	GetStatus(ctx context.Context, username, role string) (*response.MFAStatusResponse, error)
+	BeginEnrollment(ctx context.Context, username string) (*response.MFAEnrollmentResponse, error)
+	ConfirmEnrollment(ctx context.Context, username, role, code string) (*response.MFAEnrollmentConfirmResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, username, code string) (*response.MFARecoveryCodesResponse, error)
+	Disable(ctx context.Context, username, role, password, code string) error
+	VerifyCode(ctx context.Context, username, code string) error
+	LoginRequirement(ctx context.Context, user *models.User) (enrolled bool, required bool, err error)
+	GetRolePolicies(ctx context.Context) ([]response.MFARolePolicyResponse, error)
+	UpdateRolePolicy(ctx context.Context, adminUsername, role string, required bool) (*response.MFARolePolicyResponse, error)
+}
+
+type mfaService struct {
+	mfaRepo      repositories.MFARepository
+	userRepo     repositories.UserRepository
+	secretCipher *utils.SecretCipher
+}
+
+func NewMFAService(mfaRepo repositories.MFARepository, userRepo repositories.UserRepository, secretCipher *utils.SecretCipher) MFAService {
+	return &mfaService{
+		mfaRepo:      mfaRepo,
+		userRepo:     userRepo,
+		secretCipher: secretCipher,
+	}
+}
+
+func (s *mfaService) GetStatus(ctx context.Context, username, role string) (*response.MFAStatusResponse, error) {
+	mfa, err := s.mfaRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.mfaRepo.IsRequiredForRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &response.MFAStatusResponse{
+		Enabled:         mfa.Enabled(),
+		RequiredForRole: required,
+	}
+	if mfa.Enabled() {
+		status.EnabledAt = mfa.EnabledAt
+		codes, err := s.mfaRepo.GetUnusedRecoveryCodes(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		status.RecoveryCodesRemaining = len(codes)
+	}
+
+	return status, nil
+}
+
+// BeginEnrollment creates a new secret. It is not used for login until ConfirmEnrollment sees a
+// valid code from it, so an abandoned enrollment changes nothing.
+func (s *mfaService) BeginEnrollment(ctx context.Context, username string) (*response.MFAEnrollmentResponse, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, utils.NewInternalServerError("MFA_SECRET_GENERATION_FAILED", "Failed to generate MFA secret", err)
+	}
+
+	encrypted, err := s.secretCipher.Encrypt(secret)
+	if err != nil {
+		return nil, utils.NewInternalServerError("MFA_SECRET_ENCRYPTION_FAILED", "Failed to protect MFA secret", err)
+	}
+
+	if err := s.mfaRepo.SavePendingSecret(ctx, username, encrypted); err != nil {
+		return nil, err
+	}
+
+	uri := utils.TOTPProvisioningURI(constants.MFA_ISSUER, username, secret)
+	qr, err := qrcode.Encode(uri, qrcode.Medium, 256)
+	if err != nil {
+		return nil, utils.NewInternalServerError("QR_GENERATION_FAILED", "Failed to generate QR code", err)
+	}
+
+	return &response.MFAEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: uri,
+		QRCode:          base64.StdEncoding.EncodeToString(qr),
+	}, nil
+}
+
+func (s *mfaService) ConfirmEnrollment(ctx context.Context, username, role, code string) (*response.MFAEnrollmentConfirmResponse, error) {
+	mfa, err := s.mfaRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, utils.NewBadRequestError("MFA_ENROLLMENT_NOT_STARTED", "Start MFA enrollment before confirming it", nil)
+	}
+	if mfa.Enabled() {
+		return nil, utils.NewBadRequestError("MFA_ALREADY_ENABLED", "MFA is already enabled for this account", nil)
+	}
+
+	secret, err := s.totpSecret(ctx, mfa)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := utils.ValidateTOTP(secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, utils.NewBadRequestError("INVALID_MFA_CODE", "The authenticator code is incorrect", nil)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, utils.NewInternalServerError("RECOVERY_CODE_GENERATION_FAILED", "Failed to generate recovery codes", err)
+	}
+
+	if err := s.mfaRepo.Enable(ctx, username, step, hashes); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken(&models.User{Username: username, Role: role})
+	if err != nil {
+		return nil, utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+	}
+
+	log.Info().Str("username", username).Msg("MFA enabled")
+	return &response.MFAEnrollmentConfirmResponse{RecoveryCodes: codes, Token: token}, nil
+}
+
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, username, code string) (*response.MFARecoveryCodesResponse, error) {
+	if err := s.VerifyCode(ctx, username, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, utils.NewInternalServerError("RECOVERY_CODE_GENERATION_FAILED", "Failed to generate recovery codes", err)
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, username, hashes); err != nil {
+		return nil, err
+	}
+
+	return &response.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, username, role, password, code string) error {
+	required, err := s.mfaRepo.IsRequiredForRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return utils.NewForbiddenError("MFA_REQUIRED_BY_POLICY", fmt.Sprintf("MFA is required for %s accounts and cannot be disabled", role), nil)
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil || !utils.CheckPasswordHash(password, user.Password) {
+		return utils.NewBadRequestError("INCORRECT_PASSWORD", "The password provided is incorrect", nil)
+	}
+
+	if err := s.VerifyCode(ctx, username, code); err != nil {
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, username); err != nil {
+		return err
+	}
+
+	log.Info().Str("username", username).Msg("MFA disabled")
+	return nil
+}
+
+// VerifyCode accepts either the current authenticator code or an unused recovery code. Each
+// authenticator code works once, recovery codes are single use, and repeated failures lock
+// verification for a while. The attempt is counted before the code is checked, so parallel
+// requests cannot get around the lockout.
+func (s *mfaService) VerifyCode(ctx context.Context, username, code string) error {
+	mfa, err := s.mfaRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled() {
+		return utils.NewBadRequestError("MFA_NOT_ENABLED", "MFA is not enabled for this account", nil)
+	}
+
+	allowed, err := s.mfaRepo.ReserveAttempt(ctx, username, constants.MFA_MAX_FAILED_ATTEMPTS, constants.MFA_LOCKOUT_DURATION)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return utils.NewForbiddenError("MFA_LOCKED", "Too many incorrect codes, please try again later", nil)
+	}
+
+	secret, err := s.totpSecret(ctx, mfa)
+	if err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+
+	if step, ok := utils.ValidateTOTP(secret, code, time.Now()); ok {
+		fresh, err := s.mfaRepo.RecordCodeUsed(ctx, username, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return utils.NewUnauthorizedError("MFA_CODE_ALREADY_USED", "This code has already been used, wait for the next one", nil)
+		}
+		return nil
+	}
+
+	recoveryCodes, err := s.mfaRepo.GetUnusedRecoveryCodes(ctx, username)
+	if err != nil {
+		return err
+	}
+	hash := hashRecoveryCode(code)
+	for _, recoveryCode := range recoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(recoveryCode.CodeHash)) != 1 {
+			continue
+		}
+		used, err := s.mfaRepo.UseRecoveryCode(ctx, recoveryCode.ID)
+		if err != nil {
+			return err
+		}
+		if used {
+			if err := s.mfaRepo.ResetFailedAttempts(ctx, username); err != nil {
+				return err
+			}
+			log.Warn().Str("username", username).Int("remaining", len(recoveryCodes)-1).Msg("MFA recovery code used")
+			return nil
+		}
+	}
+
+	return utils.NewUnauthorizedError("INVALID_MFA_CODE", "The authenticator or recovery code is incorrect", nil)
+}
+
+// totpSecret decrypts the stored secret. Secrets stored before encryption was introduced are
+// encrypted in place the first time they are read.
+func (s *mfaService) totpSecret(ctx context.Context, mfa *models.UserMFA) (string, error) {
+	secret, encrypted, err := s.secretCipher.Decrypt(mfa.Secret)
+	if err != nil {
+		log.Error().Err(err).Str("username", mfa.Username).Msg("Failed to decrypt MFA secret")
+		return "", utils.NewInternalServerError("MFA_SECRET_DECRYPTION_FAILED", "Failed to read MFA secret", err)
+	}
+	if encrypted {
+		return secret, nil
+	}
+
+	if sealed, err := s.secretCipher.Encrypt(secret); err != nil {
+		log.Error().Err(err).Str("username", mfa.Username).Msg("Failed to encrypt legacy MFA secret")
+	} else if err := s.mfaRepo.UpdateSecret(ctx, mfa.Username, sealed); err == nil {
+		mfa.Secret = sealed
+	}
+	return secret, nil
+}
+
+// LoginRequirement reports whether the user has MFA enabled and whether their role requires it.
+// Customers are never asked for MFA.
+func (s *mfaService) LoginRequirement(ctx context.Context, user *models.User) (bool, bool, error) {
+	if user.Role != "admin" && user.Role != "staff" {
+		return false, false, nil
+	}
+
+	mfa, err := s.mfaRepo.FindByUsername(ctx, user.Username)
+	if err != nil {
+		return false, false, err
+	}
+	if mfa.Enabled() {
+		return true, true, nil
+	}
+
+	required, err := s.mfaRepo.IsRequiredForRole(ctx, user.Role)
+	if err != nil {
+		return false, false, err
+	}
+	return false, required, nil
+}
+
+func (s *mfaService) GetRolePolicies(ctx context.Context) ([]response.MFARolePolicyResponse, error) {
+	policies, err := s.mfaRepo.GetRolePolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.MFARolePolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, toMFARolePolicyResponse(policy))
+	}
+	return result, nil
+}
+
+func (s *mfaService) UpdateRolePolicy(ctx context.Context, adminUsername, role string, required bool) (*response.MFARolePolicyResponse, error) {
+	policy, err := s.mfaRepo.UpdateRolePolicy(ctx, role, required, adminUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("role", role).Bool("required", required).Str("admin", adminUsername).Msg("MFA policy updated")
+	result := toMFARolePolicyResponse(*policy)
+	return &result, nil
+}
+
+func toMFARolePolicyResponse(policy models.MFARolePolicy) response.MFARolePolicyResponse {
+	return response.MFARolePolicyResponse{
+		Role:      policy.Role,
+		Required:  policy.Required,
+		UpdatedBy: policy.UpdatedBy,
+		UpdatedAt: policy.UpdatedAt,
+	}
+}
+
+// generateRecoveryCodes returns codes formatted for display and their hashes for storage.
+// The codes are random enough that a fast hash is safe, unlike passwords.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, constants.MFA_RECOVERY_CODE_COUNT)
+	hashes := make([]string, 0, constants.MFA_RECOVERY_CODE_COUNT)
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := 0; i < constants.MFA_RECOVERY_CODE_COUNT; i++ {
+		var code strings.Builder
+		for j := 0; j < 10; j++ {
+			if j == 5 {
+				code.WriteByte('-')
+			}
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			code.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+		codes = append(codes, code.String())
+		hashes = append(hashes, hashRecoveryCode(code.String()))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieReviewService interface {
+	SubmitReview(ctx context.Context, username string, req request.SubmitMovieReviewRequest) (*response.MovieReviewResponse, error)
+	UpdateReview(ctx context.Context, username, movieID string, req request.UpdateMovieReviewRequest) (*response.MovieReviewResponse, error)
+	GetCustomerReviews(ctx context.Context, username string) (*response.MovieReviewListResponse, error)
+	// GetMovieReviews lists the visible reviews of a movie with its Skyfox rating.
+	GetMovieReviews(ctx context.Context, movieID string, req request.MovieReviewListRequest) (*response.MovieReviewsResponse, error)
+	// ListReviews lists reviews for moderation, hidden ones included.
+	ListReviews(ctx context.Context, req request.AdminMovieReviewListRequest) (*response.MovieReviewListResponse, error)
+	HideReview(ctx context.Context, reviewID int64, adminUsername, reason string) (*response.MovieReviewResponse, error)
+	UnhideReview(ctx context.Context, reviewID int64, adminUsername string) (*response.MovieReviewResponse, error)
+	// ApplyRatings fills in SkyfoxRating and ReviewCount on each movie.
+	ApplyRatings(ctx context.Context, movies []*models.Movie) error
+}
+
+type movieReviewService struct {
+	reviewRepo repositories.MovieReviewRepository
+}
+
+func NewMovieReviewService(reviewRepo repositories.MovieReviewRepository) MovieReviewService {
+	return &movieReviewService{
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (s *movieReviewService) SubmitReview(ctx context.Context, username string, req request.SubmitMovieReviewRequest) (*response.MovieReviewResponse, error) {
+	text, err := reviewText(req.Review)
+	if err != nil {
+		return nil, err
+	}
+
+	attended, err := s.reviewRepo.HasCheckedInBooking(ctx, username, req.MovieId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !attended {
+		return nil, utils.NewForbiddenError("REVIEW_NOT_ALLOWED", "Only customers who checked in to a show of this movie can review it", nil)
+	}
+
+	review := &models.MovieReview{
+		Username: username,
+		MovieId:  req.MovieId,
+		Rating:   req.Rating,
+		Review:   text,
+	}
+
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("username", username).Str("movieId", req.MovieId).Int("rating", req.Rating).Msg("Movie review submitted")
+	return toMovieReviewResponse(*review), nil
+}
+
+func (s *movieReviewService) UpdateReview(ctx context.Context, username, movieID string, req request.UpdateMovieReviewRequest) (*response.MovieReviewResponse, error) {
+	text, err := reviewText(req.Review)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &models.MovieReview{
+		Username: username,
+		MovieId:  movieID,
+		Rating:   req.Rating,
+		Review:   text,
+	}
+
+	found, err := s.reviewRepo.Update(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, utils.NewNotFoundError("REVIEW_NOT_FOUND", "You have not reviewed this movie", nil)
+	}
+
+	return toMovieReviewResponse(*review), nil
+}
+
+func (s *movieReviewService) GetCustomerReviews(ctx context.Context, username string) (*response.MovieReviewListResponse, error) {
+	reviews, err := s.reviewRepo.Find(ctx, models.MovieReviewFilter{Username: username})
+	if err != nil {
+		return nil, err
+	}
+	return toMovieReviewListResponse(reviews, toMovieReviewResponse), nil
+}
+
+func (s *movieReviewService) GetMovieReviews(ctx context.Context, movieID string, req request.MovieReviewListRequest) (*response.MovieReviewsResponse, error) {
+	visible := false
+	filter := models.MovieReviewFilter{
+		MovieID: movieID,
+		Hidden:  &visible,
+		Limit:   constants.DEFAULT_REVIEW_PAGE_SIZE,
+	}
+	if req.Limit != nil {
+		filter.Limit = *req.Limit
+	}
+	if req.Offset != nil {
+		filter.Offset = *req.Offset
+	}
+
+	reviews, err := s.reviewRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.reviewRepo.GetRatingSummaries(ctx, []string{movieID})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summaries[movieID]
+	return &response.MovieReviewsResponse{
+		MovieId:      movieID,
+		SkyfoxRating: summary.AverageRating,
+		ReviewCount:  summary.ReviewCount,
+		Reviews:      toMovieReviewListResponse(reviews, toMovieReviewResponse).Reviews,
+	}, nil
+}
+
+func (s *movieReviewService) ListReviews(ctx context.Context, req request.AdminMovieReviewListRequest) (*response.MovieReviewListResponse, error) {
+	filter := models.MovieReviewFilter{
+		MovieID:  req.MovieID,
+		Username: req.Username,
+		Limit:    constants.DEFAULT_REVIEW_PAGE_SIZE,
+	}
+	if req.Status != "" {
+		hidden := req.Status == "hidden"
+		filter.Hidden = &hidden
+	}
+	if req.Limit != nil {
+		filter.Limit = *req.Limit
+	}
+	if req.Offset != nil {
+		filter.Offset = *req.Offset
+	}
+
+	reviews, err := s.reviewRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return toMovieReviewListResponse(reviews, toAdminMovieReviewResponse), nil
+}
+
+func (s *movieReviewService) HideReview(ctx context.Context, reviewID int64, adminUsername, reason string) (*response.MovieReviewResponse, error) {
+	var hiddenReason *string
+	if reason = strings.TrimSpace(reason); reason != "" {
+		hiddenReason = &reason
+	}
+
+	review, err := s.reviewRepo.SetHidden(ctx, reviewID, true, adminUsername, hiddenReason)
+	if err != nil {
+		return nil, err
+	}
+
+	if review == nil {
+		return nil, utils.NewNotFoundError("REVIEW_NOT_FOUND", "Review not found", nil)
+	}
+
+	log.Info().Int64("reviewId", reviewID).Str("admin", adminUsername).Msg("Movie review hidden")
+	return toAdminMovieReviewResponse(*review), nil
+}
+
+func (s *movieReviewService) UnhideReview(ctx context.Context, reviewID int64, adminUsername string) (*response.MovieReviewResponse, error) {
+	review, err := s.reviewRepo.SetHidden(ctx, reviewID, false, adminUsername, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if review == nil {
+		return nil, utils.NewNotFoundError("REVIEW_NOT_FOUND", "Review not found", nil)
+	}
+
+	log.Info().Int64("reviewId", reviewID).Str("admin", adminUsername).Msg("Movie review unhidden")
+	return toAdminMovieReviewResponse(*review), nil
+}
+
+func (s *movieReviewService) ApplyRatings(ctx context.Context, movies []*models.Movie) error {
+	movieIDs := make([]string, 0, len(movies))
+	for _, movie := range movies {
+		if movie != nil {
+			movieIDs = append(movieIDs, movie.MovieId)
+		}
+	}
+
+	summaries, err := s.reviewRepo.GetRatingSummaries(ctx, movieIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		if movie == nil {
+			continue
+		}
+		summary := summaries[movie.MovieId]
+		movie.SkyfoxRating = summary.AverageRating
+		movie.ReviewCount = summary.ReviewCount
+	}
+	return nil
+}
+
+func reviewText(review string) (string, error) {
+	text := strings.TrimSpace(review)
+	if text == "" {
+		return "", utils.NewBadRequestError("INVALID_REVIEW", "Review text cannot be empty", nil)
+	}
+	return text, nil
+}
+
+// toMovieReviewResponse is the customer-facing shape: it says whether a review is hidden but
+// not who hid it, when or why.
+func toMovieReviewResponse(review models.MovieReview) *response.MovieReviewResponse {
+	return &response.MovieReviewResponse{
+		ID:        review.ID,
+		MovieId:   review.MovieId,
+		Username:  review.Username,
+		Rating:    review.Rating,
+		Review:    review.Review,
+		CreatedAt: review.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: review.UpdatedAt.Format(time.RFC3339),
+		Hidden:    review.Hidden,
+	}
+}
+
+// toAdminMovieReviewResponse adds the moderation details for the admin endpoints.
+func toAdminMovieReviewResponse(review models.MovieReview) *response.MovieReviewResponse {
+	resp := toMovieReviewResponse(review)
+	resp.HiddenBy = review.HiddenBy
+	resp.HiddenReason = review.HiddenReason
+	if review.HiddenAt != nil {
+		hiddenAt := review.HiddenAt.Format(time.RFC3339)
+		resp.HiddenAt = &hiddenAt
+	}
+	return resp
+}
+
+func toMovieReviewListResponse(reviews []models.MovieReview, toResponse func(models.MovieReview) *response.MovieReviewResponse) *response.MovieReviewListResponse {
+	list := &response.MovieReviewListResponse{Reviews: make([]response.MovieReviewResponse, 0, len(reviews))}
+	for _, review := range reviews {
+		list.Reviews = append(list.Reviews, *toResponse(review))
+	}
+	return list
+}
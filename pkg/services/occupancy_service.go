@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/rs/zerolog/log"
+)
+
+type OccupancyService interface {
+	GetOccupancy(ctx context.Context, req request.RevenueDashboardRequest) (*response.OccupancyDashboardResponse, error)
+}
+
+type occupancyService struct {
+	reportingRepo repositories.ReportingRepository
+	movieService  movieservice.MovieService
+}
+
+func NewOccupancyService(
+	reportingRepo repositories.ReportingRepository,
+	movieService movieservice.MovieService,
+) OccupancyService {
+	return &occupancyService{
+		reportingRepo: reportingRepo,
+		movieService:  movieService,
+	}
+}
+
+func (s *occupancyService) GetOccupancy(ctx context.Context, req request.RevenueDashboardRequest) (*response.OccupancyDashboardResponse, error) {
+	if req.Timeframe == "all" {
+		req.Timeframe = ""
+	}
+
+	movieNames := make(map[string]string)
+
+	filter, err := buildReportFilter(ctx, s.movieService, req, movieNames)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.OccupancyDashboardResponse{}
+	sections := []struct {
+		dimension models.OccupancyDimension
+		target    *[]response.OccupancyStats
+	}{
+		{models.OccupancyByBucket, &resp.Series},
+		{models.OccupancyByShow, &resp.ByShow},
+		{models.OccupancyByMovie, &resp.ByMovie},
+		{models.OccupancyBySlot, &resp.BySlot},
+		{models.OccupancyByWeekday, &resp.ByWeekday},
+	}
+
+	summary, err := s.reportingRepo.GetOccupancy(ctx, filter, models.OccupancyOverall, req.Timeframe)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch occupancy summary")
+		return nil, err
+	}
+	if len(summary) > 0 {
+		resp.Summary = toOccupancyStats(summary[0])
+	} else {
+		resp.Summary = toOccupancyStats(models.OccupancyAggregate{Label: "All"})
+	}
+
+	for _, section := range sections {
+		aggregates, err := s.reportingRepo.GetOccupancy(ctx, filter, section.dimension, req.Timeframe)
+		if err != nil {
+			log.Error().Err(err).Str("dimension", string(section.dimension)).Msg("Failed to fetch occupancy breakdown")
+			return nil, err
+		}
+
+		stats := make([]response.OccupancyStats, 0, len(aggregates))
+		for _, aggregate := range aggregates {
+			if section.dimension == models.OccupancyByMovie {
+				if name := lookupMovieName(ctx, s.movieService, aggregate.Key, movieNames); name != "" {
+					aggregate.Label = name
+				}
+			}
+			stats = append(stats, toOccupancyStats(aggregate))
+		}
+		*section.target = stats
+	}
+
+	sort.SliceStable(resp.ByMovie, func(i, j int) bool {
+		return resp.ByMovie[i].Label < resp.ByMovie[j].Label
+	})
+
+	return resp, nil
+}
+
+func toOccupancyStats(a models.OccupancyAggregate) response.OccupancyStats {
+	standardSold := a.SeatsSold - a.DeluxeSeatsSold
+	standardCapacity := a.Capacity - a.DeluxeCapacity
+
+	return response.OccupancyStats{
+		Key:           a.Key,
+		Label:         a.Label,
+		Shows:         a.Shows,
+		SeatsSold:     a.SeatsSold,
+		Capacity:      a.Capacity,
+		OccupancyRate: percentage(a.SeatsSold, a.Capacity),
+		Deluxe: response.SeatTypeUtilization{
+			SeatsSold:       a.DeluxeSeatsSold,
+			Capacity:        a.DeluxeCapacity,
+			UtilizationRate: percentage(a.DeluxeSeatsSold, a.DeluxeCapacity),
+		},
+		Standard: response.SeatTypeUtilization{
+			SeatsSold:       standardSold,
+			Capacity:        standardCapacity,
+			UtilizationRate: percentage(standardSold, standardCapacity),
+		},
+		AverageLeadTimeHours: math.Round(a.AverageLeadTimeHours*100) / 100,
+	}
+}
+
+// percentage returns part as a percentage of whole, rounded to two decimal places.
+func percentage(part, whole int) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(whole)) / 100
+}
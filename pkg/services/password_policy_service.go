@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+)
+
+// bcrypt only uses the first 72 bytes of a password and refuses longer input.
+const maxPasswordBytes = 72
+
+// PasswordPolicyService applies the per-role password rules from config.PasswordPolicyConfig.
+type PasswordPolicyService interface {
+	// ValidateNewPassword checks password against the role's rules and the user's recent
+	// passwords. field names the request field in the validation errors.
+	ValidateNewPassword(ctx context.Context, username, role, field, password string) error
+	// UpdatePassword validates and stores a new password, keeping as much history as the
+	// role's policy needs.
+	UpdatePassword(ctx context.Context, username, role, field, password string) error
+	// IsExpired reports whether the user's password is older than their role allows.
+	IsExpired(user *models.User) bool
+}
+
+type passwordPolicyService struct {
+	userRepo repositories.UserRepository
+	config   config.PasswordPolicyConfig
+}
+
+func NewPasswordPolicyService(userRepo repositories.UserRepository, config config.PasswordPolicyConfig) PasswordPolicyService {
+	return &passwordPolicyService{
+		userRepo: userRepo,
+		config:   config,
+	}
+}
+
+func (s *passwordPolicyService) ValidateNewPassword(ctx context.Context, username, role, field, password string) error {
+	policy := s.config.ForRole(role)
+
+	if violations := passwordRuleViolations(policy, field, password); len(violations) > 0 {
+		return &utils.AppError{
+			HTTPCode:         http.StatusBadRequest,
+			Code:             "PASSWORD_POLICY_VIOLATION",
+			Message:          "Password does not meet the password policy",
+			ValidationErrors: violations,
+		}
+	}
+
+	history, err := s.userRepo.GetPasswordHistory(ctx, username, policy.HistoryDepth)
+	if err != nil {
+		return err
+	}
+	for _, previous := range history {
+		if utils.CheckPasswordHash(password, previous.PasswordHash) {
+			return utils.NewBadRequestError("PASSWORD_REUSE", reuseMessage(policy.HistoryDepth), nil)
+		}
+	}
+
+	return nil
+}
+
+func (s *passwordPolicyService) UpdatePassword(ctx context.Context, username, role, field, password string) error {
+	if err := s.ValidateNewPassword(ctx, username, role, field, password); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return utils.NewInternalServerError("PASSWORD_HASH_ERROR", "Failed to hash password", err)
+	}
+
+	return s.userRepo.UpdatePassword(ctx, username, hashedPassword, s.config.ForRole(role).HistoryDepth)
+}
+
+func (s *passwordPolicyService) IsExpired(user *models.User) bool {
+	maxAge := s.config.ForRole(user.Role).MaxAge
+	return maxAge > 0 && time.Since(user.PasswordChangedAt) > maxAge
+}
+
+// passwordRuleViolations lists every rule the password breaks, so the user can fix them all
+// at once.
+func passwordRuleViolations(policy config.PasswordPolicy, field, password string) []utils.ValidationError {
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	length := 0
+	for _, c := range password {
+		length++
+		switch {
+		case unicode.IsUpper(c):
+			hasUpper = true
+		case unicode.IsLower(c):
+			hasLower = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c):
+			hasSpecial = true
+		}
+	}
+
+	var messages []string
+	if length < policy.MinLength {
+		messages = append(messages, fmt.Sprintf("Password must be at least %d characters long", policy.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		messages = append(messages, fmt.Sprintf("Password must be at most %d bytes long", maxPasswordBytes))
+	}
+	if policy.RequireUppercase && !hasUpper {
+		messages = append(messages, "Password must contain an uppercase letter")
+	}
+	if policy.RequireLowercase && !hasLower {
+		messages = append(messages, "Password must contain a lowercase letter")
+	}
+	if policy.RequireDigit && !hasDigit {
+		messages = append(messages, "Password must contain a digit")
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		messages = append(messages, "Password must contain a special character such as ! @ # or $")
+	}
+	if policy.RejectBreached && utils.IsBreachedPassword(password) {
+		messages = append(messages, "Password appears in a list of breached passwords, choose a different one")
+	}
+
+	violations := make([]utils.ValidationError, 0, len(messages))
+	for _, message := range messages {
+		violations = append(violations, utils.ValidationError{Field: field, Message: message})
+	}
+	return violations
+}
+
+func reuseMessage(historyDepth int) string {
+	if historyDepth == 1 {
+		return "New password cannot be the same as your current password"
+	}
+	return fmt.Sprintf("New password cannot match any of your last %d passwords", historyDepth)
+}
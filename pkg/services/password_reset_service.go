@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 )
@@ -18,13 +17,15 @@ type passwordResetService struct {
 	resetTokenRepo  repositories.ResetTokenRepository
 	skyCustomerRepo repositories.SkyCustomerRepository
 	userRepo        repositories.UserRepository
+	passwordPolicy  PasswordPolicyService
 }
 
-func NewPasswordResetService(resetTokenRepo repositories.ResetTokenRepository, skyCustomerRepo repositories.SkyCustomerRepository, userRepo repositories.UserRepository) PasswordResetService {
+func NewPasswordResetService(resetTokenRepo repositories.ResetTokenRepository, skyCustomerRepo repositories.SkyCustomerRepository, userRepo repositories.UserRepository, passwordPolicy PasswordPolicyService) PasswordResetService {
 	return &passwordResetService{
 		resetTokenRepo:  resetTokenRepo,
 		skyCustomerRepo: skyCustomerRepo,
 		userRepo:        userRepo,
+		passwordPolicy:  passwordPolicy,
 	}
 }
 
@@ -48,44 +49,7 @@ func (s *passwordResetService) ForgotPassword(ctx context.Context, email, token,
 		return utils.NewBadRequestError("INVALID_RESET_TOKEN", "The reset token is invalid, expired, or has already been used", nil)
 	}
 
-	passwordHistory, err := s.userRepo.FindByUsernameinPasswordHistory(ctx, customer.Username)
-	if err != nil {
-		return err
-	}
-
-	if passwordHistory != nil {
-		if passwordHistory.PreviousPassword1 != "" && utils.CheckPasswordHash(newPassword, passwordHistory.PreviousPassword1) {
-			return utils.NewBadRequestError("PASSWORD_REUSE", "New password cannot match any of your previous passwords", nil)
-		}
-		if passwordHistory.PreviousPassword2 != "" && utils.CheckPasswordHash(newPassword, passwordHistory.PreviousPassword2) {
-			return utils.NewBadRequestError("PASSWORD_REUSE", "New password cannot match any of your previous passwords", nil)
-		}
-		if passwordHistory.PreviousPassword3 != "" && utils.CheckPasswordHash(newPassword, passwordHistory.PreviousPassword3) {
-			return utils.NewBadRequestError("PASSWORD_REUSE", "New password cannot match any of your previous passwords", nil)
-		}
-	}
-
-	hashedPassword, err := utils.HashPassword(newPassword)
-	if err != nil {
-		return utils.NewInternalServerError("PASSWORD_HASH_ERROR", "Failed to hash password", err)
-	}
-
-	if passwordHistory == nil {
-		passwordHistory = &models.PasswordHistory{
-			Username:          customer.Username,
-			PreviousPassword1: hashedPassword,
-		}
-	} else {
-		passwordHistory.PreviousPassword3 = passwordHistory.PreviousPassword2
-		passwordHistory.PreviousPassword2 = passwordHistory.PreviousPassword1
-		passwordHistory.PreviousPassword1 = hashedPassword
-	}
-
-	if err := s.userRepo.SavePassword(ctx, customer.Username, hashedPassword); err != nil {
-		return err
-	}
-
-	if err := s.userRepo.SavePasswordHistory(ctx, passwordHistory); err != nil {
+	if err := s.passwordPolicy.UpdatePassword(ctx, customer.Username, "customer", "NewPassword", newPassword); err != nil {
 		return err
 	}
 
@@ -105,41 +69,9 @@ func (s *passwordResetService) ChangePassword(ctx context.Context, username, cur
 		return utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No customer found with username: %s", username), nil)
 	}
 
-	passwordHistory, err := s.userRepo.FindByUsernameinPasswordHistory(ctx, user.Username)
-	if err != nil {
-		return err
-	}
-
 	if !utils.CheckPasswordHash(currentPassword, user.Password) {
 		return utils.NewBadRequestError("INCORRECT PASSWORD", "Current password doesn't match user's password", nil)
 	}
 
-	if passwordHistory.PreviousPassword1 != "" && utils.CheckPasswordHash(newPassword, passwordHistory.PreviousPassword1) {
-		return utils.NewBadRequestError("PASSWORD_REUSE", "New password cannot match any of your previous passwords", nil)
-	}
-	if passwordHistory.PreviousPassword2 != "" && utils.CheckPasswordHash(newPassword, passwordHistory.PreviousPassword2) {
-		return utils.NewBadRequestError("PASSWORD_REUSE", "New password cannot match any of your previous passwords", nil)
-	}
-	if passwordHistory.PreviousPassword3 != "" && utils.CheckPasswordHash(newPassword, passwordHistory.PreviousPassword3) {
-		return utils.NewBadRequestError("PASSWORD_REUSE", "New password cannot match any of your previous passwords", nil)
-	}
-
-	hashedNewPassword, err := utils.HashPassword(newPassword)
-	if err != nil {
-		return utils.NewInternalServerError("PASSWORD_HASH_ERROR", "Failed to hash new password", err)
-	}
-
-	passwordHistory.PreviousPassword3 = passwordHistory.PreviousPassword2
-	passwordHistory.PreviousPassword2 = passwordHistory.PreviousPassword1
-	passwordHistory.PreviousPassword1 = hashedNewPassword
-
-	if err := s.userRepo.SavePassword(ctx, user.Username, hashedNewPassword); err != nil {
-		return err
-	}
-
-	if err := s.userRepo.SavePasswordHistory(ctx, passwordHistory); err != nil {
-		return err
-	}
-
-	return nil
+	return s.passwordPolicy.UpdatePassword(ctx, user.Username, user.Role, "NewPassword", newPassword)
 }
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// profileImageKeyPrefix is the object key prefix shared by every profile image.
+const profileImageKeyPrefix = "profile-images/"
+
+// profileImageVariant is one stored size of a profile image. Every variant is a square WebP
+// stored at <base key>/<name>.webp.
+type profileImageVariant struct {
+	name string
+	size int
+}
+
+var profileImageVariants = []profileImageVariant{
+	{name: "thumbnail", size: constants.PROFILE_IMAGE_THUMBNAIL_SIZE},
+	{name: "medium", size: constants.PROFILE_IMAGE_MEDIUM_SIZE},
+}
+
+var profileImageContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+func isProfileImageVariant(name string) bool {
+	for _, variant := range profileImageVariants {
+		if variant.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// profileImageVariantKey returns the object key of a variant. Images uploaded before variants
+// existed were stored as a single JPEG with an extension in the key; every variant of such an
+// image resolves to that one object.
+func profileImageVariantKey(baseKey, variant string) string {
+	if path.Ext(baseKey) != "" {
+		return baseKey
+	}
+	return baseKey + "/" + variant + ".webp"
+}
+
+// profileImageBaseKey is the inverse of profileImageVariantKey: it returns the base key an
+// object belongs to, which for a legacy single-file image is the object key itself.
+func profileImageBaseKey(objectKey string) string {
+	if !strings.Contains(strings.TrimPrefix(objectKey, profileImageKeyPrefix), "/") {
+		return objectKey
+	}
+	return path.Dir(objectKey)
+}
+
+// decodeProfileImage checks the size and sniffed type of an upload, decodes it and applies
+// its EXIF orientation. Decoding drops all metadata, so nothing from the original file
+// reaches storage.
+func decodeProfileImage(data []byte) (image.Image, string, error) {
+	if len(data) == 0 {
+		return nil, "", utils.NewBadRequestError("INVALID_IMAGE", "Image is empty", nil)
+	}
+	if len(data) > constants.PROFILE_IMAGE_MAX_BYTES {
+		return nil, "", utils.NewBadRequestError("FILE_TOO_LARGE", fmt.Sprintf("Image exceeds the %dMB limit", constants.PROFILE_IMAGE_MAX_BYTES>>20), nil)
+	}
+
+	contentType := http.DetectContentType(data)
+	if !profileImageContentTypes[contentType] {
+		return nil, "", utils.NewBadRequestError("UNSUPPORTED_IMAGE_TYPE", "Image must be a JPEG, PNG or WebP", nil)
+	}
+
+	// Read the header first so an oversized image is rejected before it is decompressed.
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", utils.NewBadRequestError("IMAGE_DECODE_FAILED", "Unable to decode image", err)
+	}
+	if config.Width > constants.PROFILE_IMAGE_MAX_DIMENSION || config.Height > constants.PROFILE_IMAGE_MAX_DIMENSION {
+		return nil, "", utils.NewBadRequestError("IMAGE_TOO_LARGE", fmt.Sprintf("Image must be at most %d pixels on each side", constants.PROFILE_IMAGE_MAX_DIMENSION), nil)
+	}
+	if config.Width < constants.PROFILE_IMAGE_MIN_DIMENSION || config.Height < constants.PROFILE_IMAGE_MIN_DIMENSION {
+		return nil, "", utils.NewBadRequestError("IMAGE_TOO_SMALL", fmt.Sprintf("Image must be at least %d pixels on each side", constants.PROFILE_IMAGE_MIN_DIMENSION), nil)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", utils.NewBadRequestError("IMAGE_DECODE_FAILED", "Unable to decode image", err)
+	}
+
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	return img, contentType, nil
+}
+
+// encodeProfileImageVariant center-crops img to a square and scales it to the variant size.
+func encodeProfileImageVariant(img image.Image, variant profileImageVariant) ([]byte, error) {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	scaled := image.NewNRGBA(image.Rect(0, 0, variant.size, variant.size))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, image.Rect(x0, y0, x0+side, y0+side), draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, scaled, nil); err != nil {
+		return nil, utils.NewInternalServerError("WEBP_ENCODE_FAILED", "Failed to encode image to WebP", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// jpegOrientation returns the EXIF orientation (1-8) of a JPEG, or 1 when it has none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			// Start of scan or end of image: metadata segments come before these.
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		i += 2 + size
+	}
+
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8 : entry+10])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+
+	return 1
+}
+
+// applyOrientation returns img turned upright according to an EXIF orientation value.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // needs a 90 degree clockwise turn
+				dx, dy = h-1-y, x
+			case 7: // transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // needs a 90 degree counter-clockwise turn
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// PublicListingService backs the anonymous read API. It only covers the days customers can
+// book and only exposes seat counts, never who booked them.
+type PublicListingService interface {
+	GetShows(ctx context.Context, req request.PublicShowListRequest) (*response.PublicShowListResponse, error)
+	// GetMovies lists the movies with at least one upcoming show, soonest first.
+	GetMovies(ctx context.Context, req request.PublicMovieListRequest) (*response.PublicMovieListResponse, error)
+	GetMovie(ctx context.Context, movieID string) (*response.PublicMovieDetailResponse, error)
+}
+
+type publicListingService struct {
+	showRepo           repositories.ShowRepository
+	movieService       movieservice.MovieService
+	movieReviewService MovieReviewService
+}
+
+func NewPublicListingService(showRepo repositories.ShowRepository, movieService movieservice.MovieService, movieReviewService MovieReviewService) PublicListingService {
+	return &publicListingService{
+		showRepo:           showRepo,
+		movieService:       movieService,
+		movieReviewService: movieReviewService,
+	}
+}
+
+func (s *publicListingService) GetShows(ctx context.Context, req request.PublicShowListRequest) (*response.PublicShowListResponse, error) {
+	from, until, err := publicListingWindow(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := s.catalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.UpcomingShowFilter{From: &from, Until: &until}
+	if req.MovieID != "" {
+		filter.MovieIDs = []string{req.MovieID}
+	}
+	if req.Genre != "" {
+		filter.MovieIDs = moviesInGenre(movies, req.Genre, filter.MovieIDs)
+	}
+
+	shows, err := s.showRepo.GetUpcomingShows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.PublicShowListResponse{
+		From:  from.Format("2006-01-02"),
+		To:    until.Format("2006-01-02"),
+		Shows: make([]response.PublicShowResponse, 0, len(shows)),
+	}
+	for _, show := range shows {
+		entry := toPublicShowResponse(show)
+		if movie, ok := movies[show.MovieId]; ok {
+			publicMovie := toPublicMovieResponse(movie)
+			entry.Movie = &publicMovie
+		}
+		result.Shows = append(result.Shows, entry)
+	}
+
+	return result, nil
+}
+
+func (s *publicListingService) GetMovies(ctx context.Context, req request.PublicMovieListRequest) (*response.PublicMovieListResponse, error) {
+	from, until, err := publicListingWindow("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := s.catalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.UpcomingShowFilter{From: &from, Until: &until}
+	if req.Genre != "" {
+		filter.MovieIDs = moviesInGenre(movies, req.Genre, nil)
+	}
+
+	shows, err := s.showRepo.GetUpcomingShows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.PublicMovieListResponse{Movies: []response.PublicMovieSummaryResponse{}}
+	positions := make(map[string]int)
+	for _, show := range shows {
+		if index, seen := positions[show.MovieId]; seen {
+			result.Movies[index].UpcomingShows++
+			continue
+		}
+
+		movie, ok := movies[show.MovieId]
+		if !ok {
+			continue
+		}
+
+		positions[show.MovieId] = len(result.Movies)
+		result.Movies = append(result.Movies, response.PublicMovieSummaryResponse{
+			PublicMovieResponse: toPublicMovieResponse(movie),
+			UpcomingShows:       1,
+			NextShowDate:        show.Date.Format("2006-01-02"),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *publicListingService) GetMovie(ctx context.Context, movieID string) (*response.PublicMovieDetailResponse, error) {
+	from, until, err := publicListingWindow("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := s.catalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	movie, ok := movies[movieID]
+	if !ok {
+		return nil, utils.NewNotFoundError("MOVIE_NOT_FOUND", "Movie not found", nil)
+	}
+
+	shows, err := s.showRepo.GetUpcomingShows(ctx, models.UpcomingShowFilter{
+		From:     &from,
+		Until:    &until,
+		MovieIDs: []string{movieID},
+		Limit:    constants.PUBLIC_MOVIE_SHOWS_LIMIT,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &response.PublicMovieDetailResponse{
+		Movie: toPublicMovieResponse(movie),
+		Shows: make([]response.PublicShowResponse, 0, len(shows)),
+	}
+	for _, show := range shows {
+		detail.Shows = append(detail.Shows, toPublicShowResponse(show))
+	}
+
+	return detail, nil
+}
+
+// catalog returns the movie service's movies by ID, with their Skyfox ratings.
+func (s *publicListingService) catalog(ctx context.Context) (map[string]*models.Movie, error) {
+	movies, err := s.movieService.GetAllMovies(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get movies for public listing")
+		return nil, err
+	}
+
+	if err := s.movieReviewService.ApplyRatings(ctx, movies); err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]*models.Movie, len(movies))
+	for _, movie := range movies {
+		if movie != nil {
+			catalog[movie.MovieId] = movie
+		}
+	}
+	return catalog, nil
+}
+
+// publicListingWindow parses the requested dates, defaulting to the whole bookable window of
+// today and the next PUBLIC_LISTING_DAYS-1 days, and rejects dates outside it.
+func publicListingWindow(fromStr, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	lastDay := today.AddDate(0, 0, constants.PUBLIC_LISTING_DAYS-1)
+
+	from, until := today, lastDay
+	var err error
+	if fromStr != "" {
+		if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+			return time.Time{}, time.Time{}, utils.NewBadRequestError("INVALID_DATE", "Invalid date format. Use YYYY-MM-DD", err)
+		}
+	}
+	if toStr != "" {
+		if until, err = time.Parse("2006-01-02", toStr); err != nil {
+			return time.Time{}, time.Time{}, utils.NewBadRequestError("INVALID_DATE", "Invalid date format. Use YYYY-MM-DD", err)
+		}
+	}
+
+	if from.Before(today) || until.After(lastDay) {
+		return time.Time{}, time.Time{}, utils.NewBadRequestError("DATE_OUT_OF_RANGE", fmt.Sprintf("Shows can only be listed from today to the next %d days", constants.PUBLIC_LISTING_DAYS-1), nil)
+	}
+	if until.Before(from) {
+		return time.Time{}, time.Time{}, utils.NewBadRequestError("INVALID_DATE_RANGE", "The to date must not be before the from date", nil)
+	}
+
+	return from, until, nil
+}
+
+// moviesInGenre returns the IDs of movies listing genre among their comma-separated genres,
+// limited to within when it is non-nil.
+func moviesInGenre(movies map[string]*models.Movie, genre string, within []string) []string {
+	ids := []string{}
+	for id, movie := range movies {
+		if within != nil && !containsString(within, id) {
+			continue
+		}
+		for _, movieGenre := range strings.Split(movie.Genre, ",") {
+			if strings.EqualFold(strings.TrimSpace(movieGenre), strings.TrimSpace(genre)) {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func toPublicMovieResponse(movie *models.Movie) response.PublicMovieResponse {
+	return response.PublicMovieResponse{
+		MovieId:      movie.MovieId,
+		Name:         movie.Name,
+		Duration:     movie.Duration,
+		Plot:         movie.Plot,
+		Genre:        movie.Genre,
+		Poster:       movie.MoviePoster,
+		ImdbRating:   movie.ImdbRating,
+		SkyfoxRating: movie.SkyfoxRating,
+		ReviewCount:  movie.ReviewCount,
+	}
+}
+
+func toPublicShowResponse(show models.ShowAvailability) response.PublicShowResponse {
+	cost, _ := show.Cost.Float64()
+	availableSeats := constants.TOTAL_NO_OF_SEATS - show.BookedSeats
+	if availableSeats < 0 {
+		availableSeats = 0
+	}
+
+	return response.PublicShowResponse{
+		ShowId:         show.Id,
+		MovieId:        show.MovieId,
+		Date:           show.Date.Format("2006-01-02"),
+		Slot:           show.Slot,
+		Cost:           cost,
+		AvailableSeats: availableSeats,
+	}
+}
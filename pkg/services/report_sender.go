@@ -0,0 +1,106 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// ReportDelivery describes a finished report. Reports are delivered as a time-limited
+// download link rather than as an attachment.
+type ReportDelivery struct {
+	RunID        int64     `json:"run_id"`
+	ScheduleName string    `json:"schedule_name"`
+	ReportType   string    `json:"report_type"`
+	Format       string    `json:"format"`
+	PeriodStart  time.Time `json:"period_start"`
+	PeriodEnd    time.Time `json:"period_end"`
+	FileName     string    `json:"file_name"`
+	DownloadURL  string    `json:"download_url"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Recipients   []string  `json:"recipients"`
+}
+
+// ReportSender delivers finished reports. New channels (email, chat) only need to
+// implement this interface and be selected in NewReportSender.
+type ReportSender interface {
+	Name() string
+	Send(ctx context.Context, delivery ReportDelivery) error
+}
+
+func NewReportSender(cfg config.ReportConfig) ReportSender {
+	switch cfg.Sender {
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			log.Warn().Msg("REPORT_WEBHOOK_URL is not set, falling back to logging report deliveries")
+			return &logReportSender{}
+		}
+		return &webhookReportSender{url: cfg.WebhookURL, client: &http.Client{Timeout: 10 * time.Second}}
+	case "log", "":
+		return &logReportSender{}
+	default:
+		log.Warn().Str("sender", cfg.Sender).Msg("Unknown REPORT_SENDER, falling back to logging report deliveries")
+		return &logReportSender{}
+	}
+}
+
+// logReportSender writes deliveries to the application log. It is the default and is
+// meant for local development.
+type logReportSender struct{}
+
+func (s *logReportSender) Name() string {
+	return "log"
+}
+
+func (s *logReportSender) Send(ctx context.Context, delivery ReportDelivery) error {
+	log.Info().
+		Int64("runId", delivery.RunID).
+		Str("schedule", delivery.ScheduleName).
+		Str("file", delivery.FileName).
+		Strs("recipients", delivery.Recipients).
+		Time("expiresAt", delivery.ExpiresAt).
+		Msg("Report ready for delivery")
+	return nil
+}
+
+// webhookReportSender posts the delivery as JSON, e.g. to a mailer or chat integration.
+type webhookReportSender struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookReportSender) Name() string {
+	return "webhook"
+}
+
+func (s *webhookReportSender) Send(ctx context.Context, delivery ReportDelivery) error {
+	payload, err := json.Marshal(delivery)
+	if err != nil {
+		return utils.NewInternalServerError("REQUEST_PREPARATION_FAILED", "Failed to prepare report delivery", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return utils.NewInternalServerError("REQUEST_CREATION_FAILED", "Failed to create report delivery request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return utils.NewInternalServerError("REPORT_DELIVERY_FAILED", "Failed to reach report webhook", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return utils.NewInternalServerError("REPORT_DELIVERY_FAILED", fmt.Sprintf("Report webhook responded with status %d", resp.StatusCode), nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,699 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/rs/zerolog/log"
+)
+
+type ReportService interface {
+	CreateSchedule(ctx context.Context, adminUsername string, req request.CreateReportScheduleRequest) (*response.ReportScheduleResponse, error)
+	GetSchedules(ctx context.Context) ([]response.ReportScheduleResponse, error)
+	DeleteSchedule(ctx context.Context, id int64) error
+	RunSchedule(ctx context.Context, adminUsername string, id int64) (*response.ReportRunResponse, error)
+	GetRuns(ctx context.Context, scheduleID *int64, limit int) ([]response.ReportRunResponse, error)
+	GetRunDownload(ctx context.Context, runID int64) (*response.ReportDownloadResponse, error)
+	RunDueSchedules(ctx context.Context) (int, error)
+}
+
+type reportService struct {
+	reportRepo        repositories.ReportRepository
+	reportingRepo     repositories.ReportingRepository
+	bookingRepo       repositories.BookingRepository
+	bookingCSVService BookingCSVService
+	movieService      movieservice.MovieService
+	storageService    StorageService
+	sender            ReportSender
+}
+
+func NewReportService(
+	reportRepo repositories.ReportRepository,
+	reportingRepo repositories.ReportingRepository,
+	bookingRepo repositories.BookingRepository,
+	bookingCSVService BookingCSVService,
+	movieService movieservice.MovieService,
+	storageService StorageService,
+	sender ReportSender,
+) ReportService {
+	return &reportService{
+		reportRepo:        reportRepo,
+		reportingRepo:     reportingRepo,
+		bookingRepo:       bookingRepo,
+		bookingCSVService: bookingCSVService,
+		movieService:      movieService,
+		storageService:    storageService,
+		sender:            sender,
+	}
+}
+
+// reportTable is one titled table of a generated report, shared by the CSV and PDF writers.
+type reportTable struct {
+	title   string
+	headers []string
+	widths  []float64
+	rows    [][]string
+}
+
+type reportContent struct {
+	title   string
+	summary [][2]string
+	tables  []reportTable
+}
+
+func (s *reportService) CreateSchedule(ctx context.Context, adminUsername string, req request.CreateReportScheduleRequest) (*response.ReportScheduleResponse, error) {
+	cron, err := utils.ParseCronSchedule(req.CronExpression)
+	if err != nil {
+		return nil, utils.NewBadRequestError("INVALID_CRON_EXPRESSION", err.Error(), err)
+	}
+
+	nextRun := cron.Next(time.Now())
+	if nextRun.IsZero() {
+		return nil, utils.NewBadRequestError("INVALID_CRON_EXPRESSION", "Cron expression never matches a date", nil)
+	}
+
+	recipients := req.Recipients
+	if recipients == nil {
+		recipients = []string{}
+	}
+
+	schedule := &models.ReportSchedule{
+		Name:           req.Name,
+		ReportType:     req.ReportType,
+		Format:         req.Format,
+		Period:         req.Period,
+		CronExpression: req.CronExpression,
+		Recipients:     recipients,
+		Enabled:        true,
+		NextRunAt:      nextRun,
+		CreatedBy:      adminUsername,
+	}
+
+	if err := s.reportRepo.CreateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	resp := toReportScheduleResponse(*schedule)
+	return &resp, nil
+}
+
+func (s *reportService) GetSchedules(ctx context.Context) ([]response.ReportScheduleResponse, error) {
+	schedules, err := s.reportRepo.GetSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]response.ReportScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		resp = append(resp, toReportScheduleResponse(schedule))
+	}
+	return resp, nil
+}
+
+func (s *reportService) DeleteSchedule(ctx context.Context, id int64) error {
+	deleted, err := s.reportRepo.DeleteSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return utils.NewNotFoundError("SCHEDULE_NOT_FOUND", "Report schedule not found", nil)
+	}
+	return nil
+}
+
+// RunSchedule generates and delivers a schedule's report immediately, covering the most
+// recent complete period. The schedule's next run is left unchanged.
+func (s *reportService) RunSchedule(ctx context.Context, adminUsername string, id int64) (*response.ReportRunResponse, error) {
+	schedule, err := s.reportRepo.GetScheduleById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, utils.NewNotFoundError("SCHEDULE_NOT_FOUND", "Report schedule not found", nil)
+	}
+
+	from, to := reportPeriod(schedule.Period, time.Now())
+	run, err := s.execute(ctx, schedule, from, to, &adminUsername, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toReportRunResponse(*run)
+	return &resp, nil
+}
+
+func (s *reportService) GetRuns(ctx context.Context, scheduleID *int64, limit int) ([]response.ReportRunResponse, error) {
+	runs, err := s.reportRepo.GetRuns(ctx, scheduleID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]response.ReportRunResponse, 0, len(runs))
+	for _, run := range runs {
+		resp = append(resp, toReportRunResponse(run))
+	}
+	return resp, nil
+}
+
+func (s *reportService) GetRunDownload(ctx context.Context, runID int64) (*response.ReportDownloadResponse, error) {
+	run, err := s.reportRepo.GetRunById(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, utils.NewNotFoundError("REPORT_RUN_NOT_FOUND", "Report run not found", nil)
+	}
+	if run.Status != "COMPLETED" || run.ObjectKey == nil {
+		return nil, utils.NewBadRequestError("REPORT_NOT_AVAILABLE", "Report run did not produce a file", nil)
+	}
+
+	url, err := s.storageService.GeneratePresignedURL(ctx, *run.ObjectKey, constants.REPORT_DOWNLOAD_URL_EXPIRY)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ReportDownloadResponse{
+		RunID:       run.ID,
+		FileName:    stringOrEmpty(run.FileName),
+		DownloadURL: url,
+		ExpiresAt:   time.Now().Add(constants.REPORT_DOWNLOAD_URL_EXPIRY).Format(time.RFC3339),
+	}, nil
+}
+
+// RunDueSchedules runs every enabled schedule whose next run has passed, then retries failed
+// scheduled runs whose retry is due, and returns how many runs it started. Missed runs are not
+// replayed: each schedule moves on to its next future slot.
+func (s *reportService) RunDueSchedules(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	schedules, err := s.reportRepo.FindDueSchedules(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for i := range schedules {
+		schedule := &schedules[i]
+
+		cron, err := utils.ParseCronSchedule(schedule.CronExpression)
+		if err != nil {
+			log.Error().Err(err).Int64("scheduleId", schedule.ID).Msg("Skipping report schedule with invalid cron expression")
+			continue
+		}
+
+		claimed, err := s.reportRepo.ClaimSchedule(ctx, schedule.ID, schedule.NextRunAt, cron.Next(now))
+		if err != nil {
+			return ran, err
+		}
+		if !claimed {
+			continue
+		}
+
+		from, to := reportPeriod(schedule.Period, schedule.NextRunAt.In(now.Location()))
+		if _, err := s.execute(ctx, schedule, from, to, nil, 1); err != nil {
+			log.Error().Err(err).Int64("scheduleId", schedule.ID).Msg("Scheduled report run failed")
+			continue
+		}
+		ran++
+	}
+
+	retried, err := s.retryFailedRuns(ctx, now)
+	return ran + retried, err
+}
+
+// retryFailedRuns runs each failed scheduled run whose retry is due again as a new run for
+// the same period. Retries for schedules that were deleted or disabled are dropped.
+func (s *reportService) retryFailedRuns(ctx context.Context, now time.Time) (int, error) {
+	runs, err := s.reportRepo.FindRetryableRuns(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, failed := range runs {
+		claimed, err := s.reportRepo.ClaimRetry(ctx, failed.ID, *failed.RetryAt)
+		if err != nil {
+			return ran, err
+		}
+		if !claimed || failed.ScheduleID == nil {
+			continue
+		}
+
+		schedule, err := s.reportRepo.GetScheduleById(ctx, *failed.ScheduleID)
+		if err != nil {
+			return ran, err
+		}
+		if schedule == nil || !schedule.Enabled {
+			continue
+		}
+
+		if _, err := s.execute(ctx, schedule, failed.PeriodStart, failed.PeriodEnd, nil, failed.Attempt+1); err != nil {
+			log.Error().Err(err).Int64("runId", failed.ID).Msg("Report run retry failed")
+			continue
+		}
+		ran++
+	}
+
+	return ran, nil
+}
+
+// execute records a run for the period from to to, generates the report, stores it and
+// hands it to the sender. Failures to generate or deliver are recorded on the run; only
+// failures to record the run itself are returned. A failed scheduled run is given a retry
+// time, doubling the delay with each attempt, until REPORT_RUN_MAX_ATTEMPTS is reached.
+func (s *reportService) execute(ctx context.Context, schedule *models.ReportSchedule, from, to time.Time, triggeredBy *string, attempt int) (*models.ReportRun, error) {
+	run := &models.ReportRun{
+		ScheduleID:  &schedule.ID,
+		ReportType:  schedule.ReportType,
+		Format:      schedule.Format,
+		PeriodStart: from,
+		PeriodEnd:   to,
+		TriggeredBy: triggeredBy,
+		Attempt:     attempt,
+	}
+	if err := s.reportRepo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	skipped := "SKIPPED"
+	run.Status = "FAILED"
+	run.DeliveryStatus = &skipped
+
+	data, fileName, contentType, err := s.generate(ctx, run.ReportType, run.Format, from, to)
+	if err == nil {
+		objectKey := fmt.Sprintf("reports/%d/%d_%s", schedule.ID, run.ID, fileName)
+		if err = s.storageService.UploadReport(ctx, data, objectKey, contentType); err == nil {
+			size := int64(len(data))
+			run.Status = "COMPLETED"
+			run.ObjectKey = &objectKey
+			run.FileName = &fileName
+			run.SizeBytes = &size
+		}
+	}
+
+	if err != nil {
+		message := err.Error()
+		run.Error = &message
+		log.Error().Err(err).Int64("runId", run.ID).Msg("Failed to generate report")
+	} else {
+		deliveryStatus := s.deliver(ctx, schedule, run)
+		run.DeliveryStatus = &deliveryStatus
+	}
+
+	failed := run.Status == "FAILED" || *run.DeliveryStatus == "FAILED"
+	if failed && triggeredBy == nil && attempt < constants.REPORT_RUN_MAX_ATTEMPTS {
+		retryAt := time.Now().Add(constants.REPORT_RUN_RETRY_DELAY << (attempt - 1))
+		run.RetryAt = &retryAt
+	}
+
+	if err := s.reportRepo.CompleteRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (s *reportService) deliver(ctx context.Context, schedule *models.ReportSchedule, run *models.ReportRun) string {
+	url, err := s.storageService.GeneratePresignedURL(ctx, *run.ObjectKey, constants.REPORT_DELIVERY_URL_EXPIRY)
+	if err != nil {
+		log.Error().Err(err).Int64("runId", run.ID).Msg("Failed to sign report link for delivery")
+		return "FAILED"
+	}
+
+	delivery := ReportDelivery{
+		RunID:        run.ID,
+		ScheduleName: schedule.Name,
+		ReportType:   run.ReportType,
+		Format:       run.Format,
+		PeriodStart:  run.PeriodStart,
+		PeriodEnd:    run.PeriodEnd,
+		FileName:     *run.FileName,
+		DownloadURL:  url,
+		ExpiresAt:    time.Now().Add(constants.REPORT_DELIVERY_URL_EXPIRY),
+		Recipients:   schedule.Recipients,
+	}
+
+	if err := s.sender.Send(ctx, delivery); err != nil {
+		log.Error().Err(err).Int64("runId", run.ID).Str("sender", s.sender.Name()).Msg("Failed to deliver report")
+		return "FAILED"
+	}
+	return "SENT"
+}
+
+func (s *reportService) generate(ctx context.Context, reportType, format string, from, to time.Time) ([]byte, string, string, error) {
+	fileName := fmt.Sprintf("%s_%s_to_%s.%s",
+		strings.ToLower(reportType),
+		from.Format("2006-01-02"),
+		to.AddDate(0, 0, -1).Format("2006-01-02"),
+		strings.ToLower(format),
+	)
+
+	if reportType == "BOOKINGS" && format == "CSV" {
+		var buf bytes.Buffer
+		if err := s.bookingCSVService.WriteBookingsCSVBetween(ctx, &buf, from, to); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), fileName, "text/csv", nil
+	}
+
+	var content *reportContent
+	var err error
+	if reportType == "BOOKINGS" {
+		content, err = s.bookingsContent(ctx, from, to)
+	} else {
+		content, err = s.revenueContent(ctx, from, to)
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if format == "CSV" {
+		data, err := writeReportCSV(content)
+		return data, fileName, "text/csv", err
+	}
+
+	data, err := renderReportPDF(content)
+	return data, fileName, "application/pdf", err
+}
+
+func (s *reportService) revenueContent(ctx context.Context, from, to time.Time) (*reportContent, error) {
+	filter := models.RevenueFilter{Statuses: []string{"Confirmed", "CheckedIn"}, Since: &from, Until: &to}
+
+	summary, err := s.reportingRepo.GetRevenueSummary(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &reportContent{
+		title: "REVENUE REPORT",
+		summary: [][2]string{
+			{"Period:", formatReportPeriod(from, to)},
+			{"Total Revenue:", fmt.Sprintf("₹%.2f", summary.TotalRevenue)},
+			{"Mean / Median:", fmt.Sprintf("₹%.2f / ₹%.2f", summary.MeanRevenue, summary.MedianRevenue)},
+			{"Bookings / Seats:", fmt.Sprintf("%d / %d", summary.TotalBookings, summary.TotalSeats)},
+		},
+	}
+
+	movieNames := make(map[string]string)
+	sections := []struct {
+		title string
+		key   models.RevenueGroupKey
+	}{
+		{"By Day", models.RevenueGroupByBucket},
+		{"By Movie", models.RevenueGroupByMovie},
+		{"By Slot", models.RevenueGroupBySlot},
+	}
+
+	for _, section := range sections {
+		groups, err := s.reportingRepo.GetRevenueGroups(ctx, filter, []models.RevenueGroupKey{section.key}, "daily")
+		if err != nil {
+			return nil, err
+		}
+
+		table := reportTable{
+			title:   section.title,
+			headers: []string{"Label", "Revenue", "Mean", "Median", "Bookings", "Seats"},
+			widths:  []float64{70, 30, 25, 25, 20, 20},
+		}
+		for _, group := range groups {
+			label := group.Bucket
+			switch section.key {
+			case models.RevenueGroupByMovie:
+				if label = lookupMovieName(ctx, s.movieService, group.MovieID, movieNames); label == "" {
+					label = group.MovieID
+				}
+			case models.RevenueGroupBySlot:
+				label = group.SlotName
+			}
+
+			table.rows = append(table.rows, []string{
+				label,
+				fmt.Sprintf("%.2f", group.TotalRevenue),
+				fmt.Sprintf("%.2f", group.MeanRevenue),
+				fmt.Sprintf("%.2f", group.MedianRevenue),
+				fmt.Sprintf("%d", group.TotalBookings),
+				fmt.Sprintf("%d", group.TotalSeats),
+			})
+		}
+		sort.SliceStable(table.rows, func(i, j int) bool { return table.rows[i][0] < table.rows[j][0] })
+
+		content.tables = append(content.tables, table)
+	}
+
+	return content, nil
+}
+
+func (s *reportService) bookingsContent(ctx context.Context, from, to time.Time) (*reportContent, error) {
+	bookings, err := s.bookingRepo.FindBookingsByStatusBetween(ctx, []string{"Confirmed", "CheckedIn"}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	table := reportTable{
+		title:   "Bookings",
+		headers: []string{"Booking ID", "Show ID", "Show Date", "Seats", "Amount", "Payment", "Booked At", "Status"},
+		widths:  []float64{20, 17, 25, 13, 25, 22, 34, 24},
+	}
+
+	seats := 0
+	for _, booking := range bookings {
+		seats += booking.NoOfSeats
+		table.rows = append(table.rows, []string{
+			fmt.Sprintf("%d", booking.Id),
+			fmt.Sprintf("%d", booking.ShowId),
+			booking.Date.Format("2006-01-02"),
+			fmt.Sprintf("%d", booking.NoOfSeats),
+			fmt.Sprintf("%.2f", booking.AmountPaid),
+			booking.PaymentType,
+			booking.BookingTime.Format("2006-01-02 15:04"),
+			booking.Status,
+		})
+	}
+
+	return &reportContent{
+		title: "BOOKINGS REPORT",
+		summary: [][2]string{
+			{"Period:", formatReportPeriod(from, to)},
+			{"Bookings:", fmt.Sprintf("%d", len(bookings))},
+			{"Seats:", fmt.Sprintf("%d", seats)},
+		},
+		tables: []reportTable{table},
+	}, nil
+}
+
+// writeReportCSV writes the summary followed by each table, one section per block.
+func writeReportCSV(content *reportContent) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := make([][]string, 0, len(content.summary))
+	for _, row := range content.summary {
+		records = append(records, []string{strings.TrimSuffix(row[0], ":"), row[1]})
+	}
+
+	for _, table := range content.tables {
+		records = append(records, []string{}, append([]string{"Section"}, table.headers...))
+		for _, row := range table.rows {
+			records = append(records, append([]string{table.title}, row...))
+		}
+	}
+
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return nil, utils.NewInternalServerError("CSV_ERROR", "Failed to write report", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, utils.NewInternalServerError("CSV_ERROR", "Failed to write report", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderReportPDF(content *reportContent) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+
+	addFontsWithFallback(pdf)
+
+	generatedAt := time.Now().Format("2006-01-02 15:04")
+	pdf.SetMargins(10, 50, 10)
+	pdf.SetAutoPageBreak(true, 35)
+	pdf.SetHeaderFuncMode(func() {
+		addBrandHeader(pdf)
+	}, true)
+	pdf.SetFooterFunc(func() {
+		addBrandFooter(pdf, fmt.Sprintf("Report generated on %s. Page %d", generatedAt, pdf.PageNo()))
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Poppins", "B", 18)
+	pdf.Cell(0, 10, content.title)
+	pdf.Ln(12)
+
+	lineHeight := 7.0
+
+	summaryTop := pdf.GetY()
+	pdf.SetFillColor(240, 240, 245) // background.secondary from theme
+	pdf.Rect(5, summaryTop-3, 200, float64(len(content.summary))*lineHeight+6, "F")
+
+	for _, row := range content.summary {
+		pdf.SetFont("Poppins", "B", 11)
+		pdf.SetTextColor(22, 26, 30) // text.primary
+		pdf.Cell(45, lineHeight, row[0])
+		pdf.SetFont("Poppins", "", 11)
+		pdf.SetTextColor(64, 67, 72) // text.secondary
+		pdf.Cell(0, lineHeight, row[1])
+		pdf.Ln(lineHeight)
+	}
+
+	for _, table := range content.tables {
+		pdf.Ln(8)
+		if pdf.GetY()+3*lineHeight > 262 {
+			pdf.AddPage()
+		}
+
+		pdf.SetFont("Poppins", "B", 12)
+		pdf.SetTextColor(224, 75, 0) // primary color from theme
+		pdf.Cell(0, lineHeight, table.title)
+		pdf.Ln(lineHeight + 1)
+
+		writeTableHeader := func() {
+			pdf.SetFont("Poppins", "B", 9)
+			pdf.SetFillColor(255, 177, 153) // secondary color
+			pdf.SetTextColor(22, 26, 30)
+			for i, header := range table.headers {
+				pdf.CellFormat(table.widths[i], lineHeight, header, "", 0, "L", true, 0, "")
+			}
+			pdf.Ln(lineHeight)
+			pdf.SetFont("Poppins", "", 9)
+			pdf.SetTextColor(64, 67, 72)
+		}
+		writeTableHeader()
+
+		for _, row := range table.rows {
+			if pdf.GetY()+lineHeight > 262 {
+				pdf.AddPage()
+				writeTableHeader()
+			}
+			for i, cell := range row {
+				pdf.CellFormat(table.widths[i], lineHeight, cell, "B", 0, "L", false, 0, "")
+			}
+			pdf.Ln(lineHeight)
+		}
+
+		if len(table.rows) == 0 {
+			pdf.SetFont("Poppins", "I", 10)
+			pdf.SetTextColor(142, 144, 145) // text.quaternary
+			pdf.CellFormat(0, lineHeight*2, "No data in this period", "", 1, "C", false, 0, "")
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		log.Error().Err(err).Msg("Failed to render report PDF")
+		return nil, utils.NewInternalServerError("PDF_GENERATION_FAILED", "Failed to generate report PDF", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// reportPeriod returns the last complete day, week (Monday to Sunday) or month before at.
+func reportPeriod(period string, at time.Time) (time.Time, time.Time) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+
+	switch period {
+	case "WEEK":
+		end := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return end.AddDate(0, 0, -7), end
+	case "MONTH":
+		end := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location())
+		return end.AddDate(0, -1, 0), end
+	default:
+		return day.AddDate(0, 0, -1), day
+	}
+}
+
+func formatReportPeriod(from, to time.Time) string {
+	return from.Format("2006-01-02") + " to " + to.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+// StartReportSchedulerWorker checks for due report schedules on every tick until ctx is done.
+func StartReportSchedulerWorker(ctx context.Context, reportService ReportService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := reportService.RunDueSchedules(ctx); err != nil {
+					log.Error().Err(err).Msg("Report scheduler sweep failed")
+				}
+			}
+		}
+	}()
+}
+
+func toReportScheduleResponse(s models.ReportSchedule) response.ReportScheduleResponse {
+	resp := response.ReportScheduleResponse{
+		ID:             s.ID,
+		Name:           s.Name,
+		ReportType:     s.ReportType,
+		Format:         s.Format,
+		Period:         s.Period,
+		CronExpression: s.CronExpression,
+		Recipients:     s.Recipients,
+		Enabled:        s.Enabled,
+		NextRunAt:      s.NextRunAt.Format(time.RFC3339),
+		CreatedBy:      s.CreatedBy,
+		CreatedAt:      s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.LastRunAt != nil {
+		resp.LastRunAt = s.LastRunAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func toReportRunResponse(r models.ReportRun) response.ReportRunResponse {
+	resp := response.ReportRunResponse{
+		ID:             r.ID,
+		ScheduleID:     r.ScheduleID,
+		ReportType:     r.ReportType,
+		Format:         r.Format,
+		PeriodStart:    r.PeriodStart.Format(time.RFC3339),
+		PeriodEnd:      r.PeriodEnd.Format(time.RFC3339),
+		Status:         r.Status,
+		FileName:       stringOrEmpty(r.FileName),
+		DeliveryStatus: stringOrEmpty(r.DeliveryStatus),
+		Error:          stringOrEmpty(r.Error),
+		TriggeredBy:    stringOrEmpty(r.TriggeredBy),
+		Attempt:        r.Attempt,
+		StartedAt:      r.StartedAt.Format(time.RFC3339),
+	}
+	if r.RetryAt != nil {
+		resp.NextRetryAt = r.RetryAt.Format(time.RFC3339)
+	}
+	if r.SizeBytes != nil {
+		resp.SizeBytes = *r.SizeBytes
+	}
+	if r.CompletedAt != nil {
+		resp.CompletedAt = r.CompletedAt.Format(time.RFC3339)
+	}
+	return resp
+}
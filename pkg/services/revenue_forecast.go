@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// forecastConfidenceZ is the z-score of the two-sided 90% band around each projection.
+	forecastConfidenceZ     = 1.645
+	forecastConfidenceLevel = 0.9
+	// forecastPacePrior damps the pace adjustment so a handful of early seats cannot swing a projection.
+	forecastPacePrior = 5.0
+)
+
+// Similarity weights for historical shows. Every show contributes the base weight so a
+// forecast still has a baseline when nothing in the history matches.
+const (
+	forecastBaseWeight    = 0.5
+	forecastGenreWeight   = 3.0
+	forecastSlotWeight    = 2.0
+	forecastWeekdayWeight = 1.0
+)
+
+// GetForecast projects seats, bookings and revenue for upcoming shows. Each show starts
+// from the weighted final sales of past shows that share its genre, slot and weekday, and
+// the remaining sales are scaled by how this show is selling compared with those shows at
+// the same number of days before start.
+func (s *revenueService) GetForecast(ctx context.Context, req request.RevenueDashboardRequest) (*response.RevenueForecastResponse, error) {
+	movieNames := make(map[string]string)
+
+	filter, err := buildReportFilter(ctx, s.movieService, req, movieNames)
+	if err != nil {
+		return nil, err
+	}
+
+	horizon := constants.DEFAULT_REVENUE_FORECAST_DAYS
+	if req.ForecastDays != nil {
+		horizon = *req.ForecastDays
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	upcoming, err := s.reportingRepo.GetShowSales(ctx, filter, today, today.AddDate(0, 0, horizon))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch upcoming shows for revenue forecast")
+		return nil, err
+	}
+
+	history, err := s.reportingRepo.GetShowSales(ctx, models.RevenueFilter{Statuses: filter.Statuses}, today.AddDate(0, 0, -constants.REVENUE_FORECAST_HISTORY_DAYS), today)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch booking history for revenue forecast")
+		return nil, err
+	}
+
+	genres := s.movieGenres(ctx, movieNames)
+
+	resp := &response.RevenueForecastResponse{
+		HorizonDays:     horizon,
+		ConfidenceLevel: forecastConfidenceLevel,
+		Forecasts:       []response.ShowRevenueForecast{},
+	}
+
+	for _, show := range upcoming {
+		if !show.StartsAt.After(now) {
+			continue
+		}
+
+		forecast := forecastShow(show, history, genres, show.StartsAt.Sub(now))
+		forecast.MovieName = lookupMovieName(ctx, s.movieService, show.MovieID, movieNames)
+
+		resp.ExpectedRevenue.Low += forecast.ExpectedRevenue.Low
+		resp.ExpectedRevenue.Expected += forecast.ExpectedRevenue.Expected
+		resp.ExpectedRevenue.High += forecast.ExpectedRevenue.High
+		resp.Forecasts = append(resp.Forecasts, forecast)
+	}
+
+	resp.ExpectedRevenue = roundForecastRange(resp.ExpectedRevenue, 2)
+
+	return resp, nil
+}
+
+// movieGenres maps movie IDs to their lower-cased genres. A movie service failure only
+// costs the genre signal, so it is logged rather than returned.
+func (s *revenueService) movieGenres(ctx context.Context, movieNames map[string]string) map[string][]string {
+	genres := make(map[string][]string)
+
+	movies, err := s.movieService.GetAllMovies(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch movies, forecasting without genre similarity")
+		return genres
+	}
+
+	for _, movie := range movies {
+		movieNames[movie.MovieId] = movie.Name
+		for _, genre := range strings.Split(movie.Genre, ",") {
+			if genre = strings.ToLower(strings.TrimSpace(genre)); genre != "" {
+				genres[movie.MovieId] = append(genres[movie.MovieId], genre)
+			}
+		}
+	}
+
+	return genres
+}
+
+func forecastShow(show models.ShowSales, history []models.ShowSales, genres map[string][]string, untilStart time.Duration) response.ShowRevenueForecast {
+	leadDays := int(untilStart.Hours() / 24)
+	if leadDays > models.ShowSalesMaxLeadDays {
+		leadDays = models.ShowSalesMaxLeadDays
+	}
+
+	var weightSum, seatSum, seatSqSum, seatsByLeadSum float64
+	var priceRevenue, priceList, bookingSeats, bookingCount float64
+	sample := 0
+
+	for _, past := range history {
+		weight := forecastBaseWeight
+		if sharesGenre(genres[show.MovieID], genres[past.MovieID]) {
+			weight += forecastGenreWeight
+		}
+		if past.SlotID == show.SlotID {
+			weight += forecastSlotWeight
+		}
+		if past.Date.Weekday() == show.Date.Weekday() {
+			weight += forecastWeekdayWeight
+		}
+		if weight > forecastBaseWeight {
+			sample++
+		}
+
+		seats := float64(past.SeatsSold)
+		weightSum += weight
+		seatSum += weight * seats
+		seatSqSum += weight * seats * seats
+
+		bookedByLead := 0
+		for d := leadDays; d <= models.ShowSalesMaxLeadDays; d++ {
+			bookedByLead += past.SeatsByLeadDays[d]
+		}
+		seatsByLeadSum += weight * float64(bookedByLead)
+
+		if past.SeatsSold > 0 {
+			priceRevenue += weight * past.Revenue
+			priceList += weight * seats * past.Cost
+			bookingSeats += weight * seats
+			bookingCount += weight * float64(past.Bookings)
+		}
+	}
+
+	current := float64(show.SeatsSold)
+	capacity := float64(show.Capacity)
+	seats := response.ForecastRange{Low: current, Expected: current, High: capacity}
+
+	if weightSum > 0 {
+		mean := seatSum / weightSum
+		std := math.Sqrt(math.Max(seatSqSum/weightSum-mean*mean, 0))
+
+		completed := 0.0
+		if seatSum > 0 {
+			completed = seatsByLeadSum / seatSum
+		}
+		pace := (current + forecastPacePrior) / (completed*mean + forecastPacePrior)
+
+		remaining := (1 - completed) * mean * pace
+		spread := forecastConfidenceZ * (1 - completed) * std * pace
+
+		seats.Expected = math.Min(current+remaining, capacity)
+		seats.Low = math.Max(current, seats.Expected-spread)
+		seats.High = math.Min(capacity, seats.Expected+spread)
+	}
+
+	// Price new seats like the show's own sales so far, or like similar shows relative to their cost.
+	seatPrice := show.Cost
+	if show.SeatsSold > 0 {
+		seatPrice = show.Revenue / current
+	} else if priceList > 0 {
+		seatPrice = show.Cost * priceRevenue / priceList
+	}
+
+	seatsPerBooking := 1.0
+	if bookingCount > 0 {
+		seatsPerBooking = bookingSeats / bookingCount
+	} else if show.Bookings > 0 {
+		seatsPerBooking = current / float64(show.Bookings)
+	}
+
+	project := func(f func(float64) float64) response.ForecastRange {
+		return response.ForecastRange{Low: f(seats.Low), Expected: f(seats.Expected), High: f(seats.High)}
+	}
+
+	return response.ShowRevenueForecast{
+		Label:         show.Date.Format("2006-01-02") + " " + show.SlotName,
+		ShowID:        show.ShowID,
+		MovieID:       show.MovieID,
+		Date:          show.Date.Format("2006-01-02"),
+		Slot:          show.SlotName,
+		Cost:          show.Cost,
+		Capacity:      show.Capacity,
+		SeatsSold:     show.SeatsSold,
+		RevenueToDate: show.Revenue,
+		ExpectedSeats: roundForecastRange(seats, 1),
+		ExpectedBookings: roundForecastRange(project(func(v float64) float64 {
+			return float64(show.Bookings) + (v-current)/seatsPerBooking
+		}), 1),
+		ExpectedRevenue: roundForecastRange(project(func(v float64) float64 {
+			return show.Revenue + (v-current)*seatPrice
+		}), 2),
+		SampleSize: sample,
+	}
+}
+
+func sharesGenre(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func roundForecastRange(r response.ForecastRange, places int) response.ForecastRange {
+	scale := math.Pow(10, float64(places))
+	round := func(v float64) float64 { return math.Round(v*scale) / scale }
+	return response.ForecastRange{Low: round(r.Low), Expected: round(r.Expected), High: round(r.High)}
+}
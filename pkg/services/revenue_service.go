@@ -2,13 +2,11 @@ package services
 
 import (
 	"context"
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/govalues/decimal"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
@@ -19,368 +17,213 @@ import (
 
 type RevenueService interface {
 	GetRevenue(ctx context.Context, req request.RevenueDashboardRequest) (*response.RevenueDashboardResponse, error)
+	GetForecast(ctx context.Context, req request.RevenueDashboardRequest) (*response.RevenueForecastResponse, error)
 }
 
 type revenueService struct {
-	bookingRepo  repositories.BookingRepository
-	showRepo     repositories.ShowRepository
-	slotRepo     repositories.SlotRepository
-	movieService movieservice.MovieService
+	reportingRepo repositories.ReportingRepository
+	movieService  movieservice.MovieService
 }
 
 func NewRevenueService(
-	bookingRepo repositories.BookingRepository,
-	showRepo repositories.ShowRepository,
-	slotRepo repositories.SlotRepository,
+	reportingRepo repositories.ReportingRepository,
 	movieService movieservice.MovieService,
 ) RevenueService {
 	return &revenueService{
-		bookingRepo:  bookingRepo,
-		showRepo:     showRepo,
-		slotRepo:     slotRepo,
-		movieService: movieService,
+		reportingRepo: reportingRepo,
+		movieService:  movieService,
 	}
 }
 
+type revenueLabelPart struct {
+	name  string
+	value string
+}
+
 func (s *revenueService) GetRevenue(ctx context.Context, req request.RevenueDashboardRequest) (*response.RevenueDashboardResponse, error) {
 	if req.Timeframe == "all" {
 		req.Timeframe = ""
 	}
 
-	bookings, err := s.bookingRepo.FindBookingsByStatus(ctx, []string{"Confirmed", "CheckedIn"})
+	movieNames := make(map[string]string)
+
+	filter, err := buildReportFilter(ctx, s.movieService, req, movieNames)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch bookings for revenue calculation")
 		return nil, err
 	}
 
-	filteredBookings := s.filterBookings(ctx, bookings, req)
-
-	totalRevenue, meanRevenue, medianRevenue, totalBookings, totalSeats := s.calculateOverallStats(filteredBookings)
+	summary, err := s.reportingRepo.GetRevenueSummary(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch revenue summary")
+		return nil, err
+	}
 
-	groups, err := s.groupBookingData(ctx, filteredBookings, req)
+	groups, err := s.groupRevenue(ctx, filter, req, movieNames)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to group booking data for revenue analysis")
 		return nil, err
 	}
 
-	totalRevenueFloat, _ := totalRevenue.Float64()
-	meanRevenueFloat, _ := meanRevenue.Float64()
-	medianRevenueFloat, _ := medianRevenue.Float64()
-
-	return &response.RevenueDashboardResponse{
-		TotalRevenue:     totalRevenueFloat,
-		MeanRevenue:      meanRevenueFloat,
-		MedianRevenue:    medianRevenueFloat,
-		TotalBookings:    totalBookings,
-		TotalSeatsBooked: totalSeats,
+	resp := &response.RevenueDashboardResponse{
+		TotalRevenue:     summary.TotalRevenue,
+		MeanRevenue:      summary.MeanRevenue,
+		MedianRevenue:    summary.MedianRevenue,
+		TotalBookings:    summary.TotalBookings,
+		TotalSeatsBooked: summary.TotalSeats,
 		Groups:           groups,
-	}, nil
-}
-
-func (s *revenueService) filterBookings(ctx context.Context, bookings []*models.Booking, req request.RevenueDashboardRequest) []*models.Booking {
-	var filtered []*models.Booking
-
-	var startLimit time.Time
-	now := time.Now()
-
-	if req.Timeframe != "" {
-		switch req.Timeframe {
-		case "daily":
-			startLimit = now.AddDate(0, 0, -30)
-		case "weekly":
-			startLimit = now.AddDate(0, 0, -16*7)
-		case "monthly":
-			startLimit = now.AddDate(0, -12, 0)
-		}
 	}
 
-	for _, booking := range bookings {
-		if booking.Status != "Confirmed" && booking.Status != "CheckedIn" {
-			continue
+	if req.IncludeForecast {
+		resp.Forecast, err = s.GetForecast(ctx, req)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to forecast revenue for upcoming shows")
+			return nil, err
 		}
-
-		include := true
-
-		if req.Timeframe != "" && !startLimit.IsZero() {
-			if booking.BookingTime.Before(startLimit) {
-				include = false
-			}
-		}
-
-		if req.Month != nil {
-			bookingMonth := int(booking.BookingTime.Month())
-			if bookingMonth != *req.Month {
-				include = false
-			}
-		}
-
-		if req.Year != nil {
-			bookingYear := booking.BookingTime.Year()
-			if bookingYear != *req.Year {
-				include = false
-			}
-		}
-
-		if req.MovieID != nil {
-			show, err := s.showRepo.FindById(ctx, booking.ShowId)
-			if err != nil || show == nil || show.MovieId != *req.MovieID {
-				include = false
-			}
-		}
-
-		if req.SlotID != nil {
-			show, err := s.showRepo.FindById(ctx, booking.ShowId)
-			if err != nil || show == nil || show.SlotId != *req.SlotID {
-				include = false
-			}
-		}
-
-		if req.Genre != nil && len(*req.Genre) > 0 {
-			show, err := s.showRepo.FindById(ctx, booking.ShowId)
-			if err != nil || show == nil {
-				include = false
-			} else {
-				movie, err := s.movieService.GetMovieById(ctx, show.MovieId)
-				if err != nil || movie == nil {
-					include = false
-				} else {
-					if !strings.Contains(movie.Genre, *req.Genre) {
-						include = false
-					}
-				}
-			}
-		}
-
-		if include {
-			filtered = append(filtered, booking)
-		}
-	}
-
-	return filtered
-}
-
-func (s *revenueService) calculateOverallStats(bookings []*models.Booking) (decimal.Decimal, decimal.Decimal, decimal.Decimal, int, int) {
-	if len(bookings) == 0 {
-		return decimal.Zero, decimal.Zero, decimal.Zero, 0, 0
-	}
-
-	var totalRevenue decimal.Decimal
-	var amounts []decimal.Decimal
-	totalSeats := 0
-
-	for _, booking := range bookings {
-		totalRevenue, _ = totalRevenue.Add(booking.AmountPaid)
-		amounts = append(amounts, booking.AmountPaid)
-		totalSeats += booking.NoOfSeats
 	}
 
-	lenOfBookings, _ := decimal.NewFromInt64(int64(len(bookings)), 0, 0)
-
-	meanRevenue, _ := totalRevenue.Quo(lenOfBookings)
-	medianRevenue := calculateMedian(amounts)
-
-	return totalRevenue, meanRevenue, medianRevenue, len(bookings), totalSeats
+	return resp, nil
 }
 
-func calculateMedian(values []decimal.Decimal) decimal.Decimal {
-	if len(values) == 0 {
-		return decimal.Zero
+// buildReportFilter translates the dashboard request into SQL filters. Genre lives in the
+// movie service, so it is resolved to the matching movie IDs up front.
+func buildReportFilter(ctx context.Context, movieService movieservice.MovieService, req request.RevenueDashboardRequest, movieNames map[string]string) (models.RevenueFilter, error) {
+	filter := models.RevenueFilter{
+		Statuses: []string{"Confirmed", "CheckedIn"},
+		Month:    req.Month,
+		Year:     req.Year,
+		MovieID:  req.MovieID,
+		SlotID:   req.SlotID,
 	}
 
-	sort.Slice(values, func(i, j int) bool {
-		return values[i].Cmp(values[j]) < 0
-	})
-
-	middle := len(values) / 2
-	if len(values)%2 == 0 {
-		sum, _ := values[middle-1].Add(values[middle])
-		two := decimal.MustParse("2")
-		median, _ := sum.Quo(two)
-		return median
+	now := time.Now()
+	var since time.Time
+	switch req.Timeframe {
+	case "daily":
+		since = now.AddDate(0, 0, -30)
+	case "weekly":
+		since = now.AddDate(0, 0, -16*7)
+	case "monthly":
+		since = now.AddDate(0, -12, 0)
+	}
+	if !since.IsZero() {
+		filter.Since = &since
+	}
+
+	if req.Genre != nil && len(*req.Genre) > 0 {
+		movies, err := movieService.GetAllMovies(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to fetch movies for genre filter")
+			return filter, err
+		}
+
+		filter.MovieIDsIn = []string{}
+		for _, movie := range movies {
+			movieNames[movie.MovieId] = movie.Name
+			if strings.Contains(movie.Genre, *req.Genre) {
+				filter.MovieIDsIn = append(filter.MovieIDsIn, movie.MovieId)
+			}
+		}
 	}
 
-	return values[middle]
+	return filter, nil
 }
 
-func (s *revenueService) groupBookingData(ctx context.Context, bookings []*models.Booking, req request.RevenueDashboardRequest) ([]response.RevenueGroupStats, error) {
+func (s *revenueService) groupRevenue(ctx context.Context, filter models.RevenueFilter, req request.RevenueDashboardRequest, movieNames map[string]string) ([]response.RevenueGroupStats, error) {
 	result := []response.RevenueGroupStats{}
 
-	filterMap := make(map[string]struct {
-		name  string
-		value string
-	})
+	filterMap := make(map[string]revenueLabelPart)
 
 	if req.Genre != nil {
-		filterMap["genre"] = struct {
-			name  string
-			value string
-		}{name: "genre", value: *req.Genre}
+		filterMap["genre"] = revenueLabelPart{name: "genre", value: *req.Genre}
 	}
 
 	if req.MovieID != nil {
-		filterMap["movie_id"] = struct {
-			name  string
-			value string
-		}{name: "movie", value: ""}
+		filterMap["movie_id"] = revenueLabelPart{name: "movie"}
 	}
 
 	if req.SlotID != nil {
-		filterMap["slot_id"] = struct {
-			name  string
-			value string
-		}{name: "slot", value: ""}
+		filterMap["slot_id"] = revenueLabelPart{name: "slot"}
 	}
 
 	if req.Timeframe != "" {
-		filterMap["timeframe"] = struct {
-			name  string
-			value string
-		}{name: "timeframe", value: req.Timeframe}
+		filterMap["timeframe"] = revenueLabelPart{name: "timeframe", value: req.Timeframe}
 	} else {
 		if req.Month != nil {
-			monthName := time.Month(*req.Month).String()
-			filterMap["month"] = struct {
-				name  string
-				value string
-			}{name: "month", value: monthName}
+			filterMap["month"] = revenueLabelPart{name: "month", value: time.Month(*req.Month).String()}
 		}
 
 		if req.Year != nil {
-			yearStr := strconv.Itoa(*req.Year)
-			filterMap["year"] = struct {
-				name  string
-				value string
-			}{name: "year", value: yearStr}
+			filterMap["year"] = revenueLabelPart{name: "year", value: strconv.Itoa(*req.Year)}
 		}
 	}
 
 	if len(filterMap) == 0 {
-		filterMap["all"] = struct {
-			name  string
-			value string
-		}{name: "all", value: "All"}
+		filterMap["all"] = revenueLabelPart{name: "all", value: "All"}
 		if len(req.ParamOrder) == 0 {
 			req.ParamOrder = []string{"all"}
 		}
 	}
 
-	groups := make(map[string][]*models.Booking)
-
-	showCache := make(map[int]*models.Show)
-	movieCache := make(map[string]*models.Movie)
-	slotCache := make(map[int]*models.Slot)
-
-	showIDs := make(map[int]bool)
-	for _, booking := range bookings {
-		showIDs[booking.ShowId] = true
-	}
-
-	for showID := range showIDs {
-		show, err := s.showRepo.FindById(ctx, showID)
-		if err != nil || show == nil {
+	var labelParts []revenueLabelPart
+	var groupBy []models.RevenueGroupKey
+	for _, paramName := range req.ParamOrder {
+		part, exists := filterMap[paramName]
+		if !exists {
 			continue
 		}
+		labelParts = append(labelParts, part)
 
-		showCache[showID] = show
-
-		if req.SlotID != nil || contains(req.ParamOrder, "slot_id") {
-			slot, err := s.slotRepo.GetSlotById(ctx, show.SlotId)
-			if err == nil && slot != nil {
-				slotCache[show.SlotId] = slot
-			}
-		}
-
-		if req.MovieID != nil || req.Genre != nil || contains(req.ParamOrder, "movie_id") {
-			movie, err := s.movieService.GetMovieById(ctx, show.MovieId)
-			if err == nil && movie != nil {
-				movieCache[show.MovieId] = movie
-			}
+		switch part.name {
+		case "movie":
+			groupBy = append(groupBy, models.RevenueGroupByMovie)
+		case "slot":
+			groupBy = append(groupBy, models.RevenueGroupBySlot)
+		case "timeframe":
+			groupBy = append(groupBy, models.RevenueGroupByBucket)
 		}
 	}
 
-	for _, booking := range bookings {
-		show, exists := showCache[booking.ShowId]
-		if !exists {
-			continue
-		}
+	aggregates, err := s.reportingRepo.GetRevenueGroups(ctx, filter, groupBy, req.Timeframe)
+	if err != nil {
+		return nil, err
+	}
 
+	for _, aggregate := range aggregates {
 		var keyParts []string
+		var movieID string
 
-		for _, paramName := range req.ParamOrder {
-			filter, exists := filterMap[paramName]
-			if !exists {
-				continue
-			}
-
-			var labelValue string
-			switch filter.name {
-			case "genre":
-				labelValue = filter.value
-
+		for _, part := range labelParts {
+			switch part.name {
 			case "movie":
-				movie, exists := movieCache[show.MovieId]
-				if !exists {
-					continue
+				// Groups are keyed by movie_id; a movie the movie service no longer knows is
+				// labelled by its ID so it is not merged with other unknown movies.
+				movieID = aggregate.MovieID
+				name := lookupMovieName(ctx, s.movieService, aggregate.MovieID, movieNames)
+				if name == "" {
+					name = aggregate.MovieID
 				}
-				labelValue = movie.Name
-
+				keyParts = append(keyParts, name)
 			case "slot":
-				slot, exists := slotCache[show.SlotId]
-				if !exists {
-					continue
-				}
-				labelValue = slot.Name
-
+				keyParts = append(keyParts, aggregate.SlotName)
 			case "timeframe":
-				switch filter.value {
-				case "daily":
-					labelValue = booking.BookingTime.Format("2006-01-02")
-				case "weekly":
-					year, week := booking.BookingTime.ISOWeek()
-					labelValue = fmt.Sprintf("%d-W%02d", year, week)
-				case "monthly":
-					labelValue = booking.BookingTime.Format("2006-01")
-				case "yearly":
-					labelValue = booking.BookingTime.Format("2006")
-				default:
-					labelValue = "All"
-				}
-
-			case "month":
-				labelValue = filter.value
-
-			case "year":
-				labelValue = filter.value
-
-			case "all":
-				labelValue = "All"
+				keyParts = append(keyParts, aggregate.Bucket)
+			default:
+				keyParts = append(keyParts, part.value)
 			}
-
-			keyParts = append(keyParts, labelValue)
 		}
 
 		if len(keyParts) == 0 {
 			keyParts = append(keyParts, "All")
 		}
 
-		key := strings.Join(keyParts, ";")
-		groups[key] = append(groups[key], booking)
-	}
-
-	for label, groupBookings := range groups {
-		totalRev, meanRev, medianRev, totalBook, totalSeats := s.calculateOverallStats(groupBookings)
-
-		totalRevenueFloat, _ := totalRev.Float64()
-		meanRevenueFloat, _ := meanRev.Float64()
-		medianRevenueFloat, _ := medianRev.Float64()
-
 		result = append(result, response.RevenueGroupStats{
-			Label:            label,
-			TotalRevenue:     totalRevenueFloat,
-			MeanRevenue:      meanRevenueFloat,
-			MedianRevenue:    medianRevenueFloat,
-			TotalBookings:    totalBook,
-			TotalSeatsBooked: totalSeats,
+			Label:            strings.Join(keyParts, ";"),
+			MovieID:          movieID,
+			TotalRevenue:     aggregate.TotalRevenue,
+			MeanRevenue:      aggregate.MeanRevenue,
+			MedianRevenue:    aggregate.MedianRevenue,
+			TotalBookings:    aggregate.TotalBookings,
+			TotalSeatsBooked: aggregate.TotalSeats,
 		})
 	}
 
@@ -391,11 +234,18 @@ func (s *revenueService) groupBookingData(ctx context.Context, bookings []*model
 	return result, nil
 }
 
-func contains(slice []string, str string) bool {
-	for _, s := range slice {
-		if s == str {
-			return true
-		}
+func lookupMovieName(ctx context.Context, movieService movieservice.MovieService, movieID string, movieNames map[string]string) string {
+	if name, exists := movieNames[movieID]; exists {
+		return name
 	}
-	return false
+
+	movie, err := movieService.GetMovieById(ctx, movieID)
+	if err != nil || movie == nil {
+		log.Warn().Err(err).Str("movieId", movieID).Msg("Movie not found for report label")
+		movieNames[movieID] = ""
+		return ""
+	}
+
+	movieNames[movieID] = movie.Name
+	return movie.Name
 }
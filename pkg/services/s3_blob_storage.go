@@ -0,0 +1,149 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// s3BlobStorage stores objects privately in an S3 bucket. With an endpoint set it talks to
+// an S3-compatible server such as MinIO, using path-style addressing.
+type s3BlobStorage struct {
+	name     string
+	s3Client *s3.S3
+	bucket   string
+}
+
+func newS3BlobStorage(name, region, bucket, endpoint string) (*s3BlobStorage, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(region),
+	}
+	if endpoint != "" {
+		awsConfig.Endpoint = aws.String(endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3BlobStorage{
+		name:     name,
+		s3Client: s3.New(sess),
+		bucket:   bucket,
+	}, nil
+}
+
+func (s *s3BlobStorage) Name() string {
+	return s.name
+}
+
+func (s *s3BlobStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateObjectKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+		ACL:         aws.String("private"),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to upload object to S3")
+		return utils.NewInternalServerError("STORAGE_UPLOAD_FAILED", "Failed to upload file", err)
+	}
+
+	return nil
+}
+
+func (s *s3BlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := validateObjectKey(key); err != nil {
+		return nil, err
+	}
+
+	output, err := s.s3Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to download object from S3")
+		return nil, utils.NewInternalServerError("STORAGE_DOWNLOAD_FAILED", "Failed to download file", err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to read object from S3")
+		return nil, utils.NewInternalServerError("STORAGE_DOWNLOAD_FAILED", "Failed to download file", err)
+	}
+
+	return data, nil
+}
+
+func (s *s3BlobStorage) Delete(ctx context.Context, key string) error {
+	if err := validateObjectKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.s3Client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to delete object from S3")
+		return utils.NewInternalServerError("STORAGE_DELETE_FAILED", "Failed to delete file", err)
+	}
+
+	return nil
+}
+
+func (s *s3BlobStorage) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validateObjectKey(key); err != nil {
+		return "", err
+	}
+
+	req, _ := s.s3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+
+	presignedURL, err := req.Presign(expiry)
+	if err != nil {
+		log.Error().Err(err).Str("objectKey", key).Msg("Failed to generate presigned URL")
+		return "", utils.NewInternalServerError("PRESIGNED_URL_GENERATION_FAILED", "Failed to generate presigned URL", err)
+	}
+
+	return presignedURL, nil
+}
+
+func (s *s3BlobStorage) List(ctx context.Context, prefix string) ([]BlobObject, error) {
+	var objects []BlobObject
+	err := s.s3Client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			objects = append(objects, BlobObject{
+				Key:          aws.StringValue(object.Key),
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		log.Error().Err(err).Str("prefix", prefix).Msg("Failed to list objects in S3")
+		return nil, utils.NewInternalServerError("STORAGE_LIST_FAILED", "Failed to list files", err)
+	}
+
+	return objects, nil
+}
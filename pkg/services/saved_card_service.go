@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	paymentservice "github.com/iamsuteerth/skyfox-backend/pkg/payment-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type SavedCardService interface {
+	SaveCard(ctx context.Context, username string, req request.SaveCardRequest) (*response.SavedCardResponse, error)
+	GetSavedCards(ctx context.Context, username string) (*response.SavedCardsResponse, error)
+	DeleteSavedCard(ctx context.Context, username string, cardID int64) error
+}
+
+type savedCardService struct {
+	savedCardRepo  repositories.SavedCardRepository
+	paymentService paymentservice.PaymentService
+}
+
+func NewSavedCardService(savedCardRepo repositories.SavedCardRepository, paymentService paymentservice.PaymentService) SavedCardService {
+	return &savedCardService{
+		savedCardRepo:  savedCardRepo,
+		paymentService: paymentService,
+	}
+}
+
+func (s *savedCardService) SaveCard(ctx context.Context, username string, req request.SaveCardRequest) (*response.SavedCardResponse, error) {
+	// Checked up front so a full account never reaches the payment provider; Create enforces
+	// the limit again for saves that race past this check.
+	count, err := s.savedCardRepo.CountByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= constants.MAX_SAVED_CARDS_PER_CUSTOMER {
+		return nil, savedCardLimitError()
+	}
+
+	expiryMonth, _ := strconv.Atoi(req.ExpiryMonth)
+	expiryYear, _ := strconv.Atoi(req.ExpiryYear)
+
+	if expiryMonth < 1 || expiryMonth > 12 {
+		return nil, utils.NewBadRequestError("INVALID_EXPIRY", "Expiry month must be between 1 and 12", nil)
+	}
+
+	if isCardExpired(expiryMonth, expiryYear, time.Now()) {
+		return nil, utils.NewBadRequestError("CARD_EXPIRED", "The card has expired", nil)
+	}
+
+	expiry := fmt.Sprintf("%s/%s", req.ExpiryMonth, req.ExpiryYear)
+	tokenResp, err := s.paymentService.TokenizeCard(ctx, req.CardNumber, req.CVV, expiry, req.CardholderName)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Card tokenization failed")
+		return nil, err
+	}
+
+	last4 := tokenResp.Last4
+	if last4 == "" {
+		last4 = req.CardNumber[len(req.CardNumber)-4:]
+	}
+
+	brand := tokenResp.Brand
+	if brand == "" {
+		brand = "UNKNOWN"
+	}
+
+	card := &models.SavedCard{
+		Username:      username,
+		ProviderToken: tokenResp.Token,
+		Last4:         last4,
+		Brand:         brand,
+		ExpiryMonth:   expiryMonth,
+		ExpiryYear:    expiryYear,
+	}
+
+	created, err := s.savedCardRepo.Create(ctx, card, constants.MAX_SAVED_CARDS_PER_CUSTOMER)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, savedCardLimitError()
+	}
+
+	cardResponse := toSavedCardResponse(*card)
+	return &cardResponse, nil
+}
+
+func (s *savedCardService) GetSavedCards(ctx context.Context, username string) (*response.SavedCardsResponse, error) {
+	cards, err := s.savedCardRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	cardResponses := make([]response.SavedCardResponse, 0, len(cards))
+	for _, card := range cards {
+		cardResponses = append(cardResponses, toSavedCardResponse(card))
+	}
+
+	return &response.SavedCardsResponse{
+		Cards: cardResponses,
+	}, nil
+}
+
+func (s *savedCardService) DeleteSavedCard(ctx context.Context, username string, cardID int64) error {
+	deleted, err := s.savedCardRepo.DeleteByIdAndUsername(ctx, cardID, username)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return utils.NewNotFoundError("SAVED_CARD_NOT_FOUND", "Saved card not found", nil)
+	}
+
+	return nil
+}
+
+func savedCardLimitError() error {
+	return utils.NewBadRequestError("SAVED_CARD_LIMIT_REACHED", fmt.Sprintf("A maximum of %d cards can be saved", constants.MAX_SAVED_CARDS_PER_CUSTOMER), nil)
+}
+
+// chargeSavedCard charges one of the customer's saved cards through the payment
+// provider using its stored token. Shared by the wallet top-up and booking flows.
+func chargeSavedCard(ctx context.Context, savedCardRepo repositories.SavedCardRepository, paymentService paymentservice.PaymentService, username string, cardID int64, amount decimal.Decimal) (string, error) {
+	card, err := savedCardRepo.FindByIdAndUsername(ctx, cardID, username)
+	if err != nil {
+		return "", err
+	}
+
+	if card == nil {
+		return "", utils.NewNotFoundError("SAVED_CARD_NOT_FOUND", "Saved card not found", nil)
+	}
+
+	if isCardExpired(card.ExpiryMonth, card.ExpiryYear, time.Now()) {
+		return "", utils.NewBadRequestError("SAVED_CARD_EXPIRED", "The selected saved card has expired", nil)
+	}
+
+	transactionID, err := paymentService.ProcessTokenPayment(ctx, card.ProviderToken, amount)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Int64("savedCardID", cardID).Msg("Saved card payment failed")
+		return "", err
+	}
+
+	return transactionID, nil
+}
+
+// isCardExpired treats a card as valid through the last day of its expiry month.
+func isCardExpired(expiryMonth, expiryYear int, now time.Time) bool {
+	if expiryYear < 100 {
+		expiryYear += 2000
+	}
+	firstOfNextMonth := time.Date(expiryYear, time.Month(expiryMonth)+1, 1, 0, 0, 0, 0, now.Location())
+	return !now.Before(firstOfNextMonth)
+}
+
+func toSavedCardResponse(card models.SavedCard) response.SavedCardResponse {
+	return response.SavedCardResponse{
+		ID:          card.ID,
+		Last4:       card.Last4,
+		Brand:       card.Brand,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+		CreatedAt:   card.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/rs/zerolog/log"
+)
+
+// SeatEventBroker fans seat state changes out to the seat map streams of a show.
+type SeatEventBroker interface {
+	Publish(ctx context.Context, event models.SeatEvent)
+	// Subscribe returns a channel of events for the show and a function that ends the
+	// subscription. The channel is closed if the subscriber falls too far behind, in which
+	// case it should fetch the seat map again.
+	Subscribe(showID int) (<-chan models.SeatEvent, func())
+}
+
+func NewSeatEventBroker(cfg config.SeatEventsConfig, seatEventRepo repositories.SeatEventRepository) SeatEventBroker {
+	hub := &seatEventHub{subscribers: make(map[int]map[chan models.SeatEvent]struct{})}
+
+	switch cfg.Backend {
+	case "postgres":
+		return &postgresSeatEventBroker{seatEventHub: hub, seatEventRepo: seatEventRepo}
+	case "memory", "":
+		return &memorySeatEventBroker{seatEventHub: hub}
+	default:
+		log.Warn().Str("backend", cfg.Backend).Msg("Unknown SEAT_EVENTS_BACKEND, falling back to in-process seat events")
+		return &memorySeatEventBroker{seatEventHub: hub}
+	}
+}
+
+// StartSeatEventListener relays seat events published by other instances. It only does
+// anything for the Postgres backend.
+func StartSeatEventListener(ctx context.Context, broker SeatEventBroker) {
+	if pg, ok := broker.(*postgresSeatEventBroker); ok {
+		go pg.listen(ctx)
+	}
+}
+
+// publishSeatEvent is a shorthand for the booking flows, which only know the show and seats.
+func publishSeatEvent(ctx context.Context, broker SeatEventBroker, showID int, state string, seatNumbers []string) {
+	if len(seatNumbers) == 0 {
+		return
+	}
+	broker.Publish(ctx, models.SeatEvent{
+		ShowID:      showID,
+		State:       state,
+		SeatNumbers: seatNumbers,
+		At:          time.Now(),
+	})
+}
+
+// seatEventHub delivers events to the subscribers in this instance.
+type seatEventHub struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan models.SeatEvent]struct{}
+}
+
+func (h *seatEventHub) Subscribe(showID int) (<-chan models.SeatEvent, func()) {
+	ch := make(chan models.SeatEvent, constants.SEAT_EVENTS_SUBSCRIBER_BUFFER)
+
+	h.mu.Lock()
+	if h.subscribers[showID] == nil {
+		h.subscribers[showID] = make(map[chan models.SeatEvent]struct{})
+	}
+	h.subscribers[showID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(showID, ch)
+	}
+	return ch, unsubscribe
+}
+
+// dispatch never blocks the publisher: a subscriber whose buffer is full is dropped. A
+// RESYNC event without a show goes to every subscriber.
+func (h *seatEventHub) dispatch(event models.SeatEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for showID, subscribers := range h.subscribers {
+		if event.ShowID != 0 && event.ShowID != showID {
+			continue
+		}
+		for ch := range subscribers {
+			select {
+			case ch <- event:
+			default:
+				log.Warn().Int("showId", showID).Msg("Dropping slow seat map subscriber")
+				h.remove(showID, ch)
+			}
+		}
+	}
+}
+
+// remove must be called with mu held. It is safe to call more than once for a channel.
+func (h *seatEventHub) remove(showID int, ch chan models.SeatEvent) {
+	subscribers := h.subscribers[showID]
+	if _, ok := subscribers[ch]; !ok {
+		return
+	}
+	delete(subscribers, ch)
+	close(ch)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, showID)
+	}
+}
+
+// memorySeatEventBroker delivers events within this instance only. It is the default and
+// is enough for a single server.
+type memorySeatEventBroker struct {
+	*seatEventHub
+}
+
+func (b *memorySeatEventBroker) Publish(ctx context.Context, event models.SeatEvent) {
+	b.dispatch(event)
+}
+
+// postgresSeatEventBroker publishes with NOTIFY and delivers what it receives on LISTEN,
+// including its own events, so every instance sees the same stream.
+type postgresSeatEventBroker struct {
+	*seatEventHub
+	seatEventRepo repositories.SeatEventRepository
+}
+
+func (b *postgresSeatEventBroker) Publish(ctx context.Context, event models.SeatEvent) {
+	if err := b.seatEventRepo.Notify(ctx, event); err != nil {
+		// Other instances miss this event, but local streams still get it.
+		b.dispatch(event)
+	}
+}
+
+func (b *postgresSeatEventBroker) listen(ctx context.Context) {
+	listening := false
+	for {
+		err := b.seatEventRepo.Listen(ctx, func() {
+			if listening {
+				// Events published while the listener was down were lost.
+				b.dispatch(models.SeatEvent{State: models.SeatStateResync, SeatNumbers: []string{}, At: time.Now()})
+			}
+			listening = true
+			log.Info().Msg("Listening for seat events")
+		}, b.dispatch)
+
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Dur("retryIn", constants.SEAT_EVENTS_RECONNECT_DELAY).Msg("Seat event listener stopped")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(constants.SEAT_EVENTS_RECONNECT_DELAY):
+		}
+	}
+}
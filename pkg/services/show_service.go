@@ -10,6 +10,7 @@ import (
 	"github.com/govalues/decimal"
 	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
@@ -24,13 +25,18 @@ type ShowService interface {
 	GetMovies(ctx context.Context) ([]*models.Movie, error)
 	CreateShow(ctx context.Context, showRequest request.ShowRequest) (*models.Show, error)
 	AvailableSeats(ctx context.Context, showId int) int
+	// SearchShows finds shows across a date range. Customers only see shows that have not
+	// started yet, within the customer booking window.
+	SearchShows(ctx context.Context, req request.ShowSearchRequest, customerView bool) (*response.ShowSearchResponse, error)
 }
 
 type showService struct {
-	showRepo     repositories.ShowRepository
-	bookingRepo  repositories.BookingRepository
-	movieService movieservice.MovieService
-	slotRepo     repositories.SlotRepository
+	showRepo           repositories.ShowRepository
+	bookingRepo        repositories.BookingRepository
+	movieService       movieservice.MovieService
+	slotRepo           repositories.SlotRepository
+	watchlistService   WatchlistService
+	movieReviewService MovieReviewService
 }
 
 func NewShowService(
@@ -38,12 +44,16 @@ func NewShowService(
 	bookingRepo repositories.BookingRepository,
 	movieService movieservice.MovieService,
 	slotRepo repositories.SlotRepository,
+	watchlistService WatchlistService,
+	movieReviewService MovieReviewService,
 ) ShowService {
 	return &showService{
-		showRepo:     showRepo,
-		bookingRepo:  bookingRepo,
-		movieService: movieService,
-		slotRepo:     slotRepo,
+		showRepo:           showRepo,
+		bookingRepo:        bookingRepo,
+		movieService:       movieService,
+		slotRepo:           slotRepo,
+		watchlistService:   watchlistService,
+		movieReviewService: movieReviewService,
 	}
 }
 
@@ -71,6 +81,10 @@ func (s *showService) GetMovies(ctx context.Context) ([]*models.Movie, error) {
 		log.Error().Err(err).Msg("Failed to get movies from movie service")
 		return nil, err
 	}
+	if err := s.movieReviewService.ApplyRatings(ctx, movies); err != nil {
+		log.Error().Err(err).Msg("Failed to get Skyfox ratings for movies")
+		return nil, err
+	}
 	return movies, nil
 }
 
@@ -80,6 +94,10 @@ func (s *showService) GetMovieById(ctx context.Context, id string) (*models.Movi
 		log.Error().Err(err).Str("movieId", id).Msg("Failed to get movie by ID")
 		return nil, err
 	}
+	if err := s.movieReviewService.ApplyRatings(ctx, []*models.Movie{movie}); err != nil {
+		log.Error().Err(err).Str("movieId", id).Msg("Failed to get Skyfox rating for movie")
+		return nil, err
+	}
 	return movie, nil
 }
 
@@ -176,6 +194,12 @@ func (s *showService) CreateShow(ctx context.Context, showRequest request.ShowRe
 		return nil, err
 	}
 
+	// Alerts are only queued here and delivered by the watchlist alert worker. A failure to queue
+	// them must not fail a show that has already been created.
+	if err := s.watchlistService.NotifyNewShow(ctx, completeShow); err != nil {
+		log.Error().Err(err).Int("showId", completeShow.Id).Msg("Failed to queue watchlist alerts for new show")
+	}
+
 	return completeShow, nil
 }
 
@@ -183,3 +207,116 @@ func (s *showService) AvailableSeats(ctx context.Context, showId int) int {
 	bookedSeats := s.bookingRepo.BookedSeatsByShow(ctx, showId)
 	return constants.TOTAL_NO_OF_SEATS - bookedSeats
 }
+
+// showTimeOfDayBuckets maps each time of day to the slot start times it covers. Night wraps
+// past midnight.
+var showTimeOfDayBuckets = map[string][2]string{
+	"morning":   {"05:00:00", "12:00:00"},
+	"afternoon": {"12:00:00", "17:00:00"},
+	"evening":   {"17:00:00", "21:00:00"},
+	"night":     {"21:00:00", "05:00:00"},
+}
+
+func (s *showService) SearchShows(ctx context.Context, req request.ShowSearchRequest, customerView bool) (*response.ShowSearchResponse, error) {
+	from, err := utils.GetDateFromDateStringDefaultToday(req.From)
+	if err != nil {
+		return nil, utils.NewBadRequestError("INVALID_DATE", "Invalid date format. Use YYYY-MM-DD", err)
+	}
+
+	until := from.AddDate(0, 0, constants.CUSTOMER_BOOKING_WINDOW_DAYS-1)
+	if req.To != "" {
+		if until, err = time.Parse("2006-01-02", req.To); err != nil {
+			return nil, utils.NewBadRequestError("INVALID_DATE", "Invalid date format. Use YYYY-MM-DD", err)
+		}
+	}
+
+	if until.Before(from) {
+		return nil, utils.NewBadRequestError("INVALID_DATE_RANGE", "The to date must not be before the from date", nil)
+	}
+
+	if customerView {
+		today, _ := utils.GetDateFromDateStringDefaultToday("")
+		lastDay := today.AddDate(0, 0, constants.CUSTOMER_BOOKING_WINDOW_DAYS-1)
+		if req.To == "" && until.After(lastDay) {
+			until = lastDay
+		}
+		if from.Before(today) || until.After(lastDay) {
+			return nil, utils.NewBadRequestError("DATE_OUT_OF_RANGE", fmt.Sprintf("Customers can only view shows from today to the next %d days", constants.CUSTOMER_BOOKING_WINDOW_DAYS-1), nil)
+		}
+	} else if until.After(from.AddDate(0, 0, constants.SHOW_SEARCH_MAX_DAYS-1)) {
+		return nil, utils.NewBadRequestError("DATE_RANGE_TOO_LONG", fmt.Sprintf("Shows can be searched at most %d days at a time", constants.SHOW_SEARCH_MAX_DAYS), nil)
+	}
+
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return nil, utils.NewBadRequestError("INVALID_PRICE_RANGE", "The minimum price must not be above the maximum price", nil)
+	}
+
+	movies, err := s.GetMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]*models.Movie, len(movies))
+	for _, movie := range movies {
+		if movie != nil {
+			catalog[movie.MovieId] = movie
+		}
+	}
+
+	// Shows are limited to movies the movie service knows, so every result has its movie.
+	movieIDs := make([]string, 0, len(catalog))
+	for id := range catalog {
+		movieIDs = append(movieIDs, id)
+	}
+	if req.Genre != "" {
+		movieIDs = moviesInGenre(catalog, req.Genre, nil)
+	}
+
+	filter := models.ShowSearchFilter{
+		From:         from,
+		Until:        until,
+		MovieIDs:     movieIDs,
+		SlotID:       req.SlotID,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		MinSeats:     req.MinSeats,
+		UpcomingOnly: customerView,
+		Sort:         req.Sort,
+		Limit:        constants.DEFAULT_SHOW_SEARCH_PAGE_SIZE,
+	}
+	if bucket, ok := showTimeOfDayBuckets[req.TimeOfDay]; ok {
+		filter.StartFrom, filter.StartBefore = bucket[0], bucket[1]
+	}
+	if req.Limit != nil {
+		filter.Limit = *req.Limit
+	}
+	if req.Offset != nil {
+		filter.Offset = *req.Offset
+	}
+
+	shows, total, err := s.showRepo.SearchShows(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search shows")
+		return nil, err
+	}
+
+	deluxeOffset, _ := decimal.NewFromFloat64(constants.DELUXE_OFFSET)
+	result := &response.ShowSearchResponse{
+		Shows:  make([]response.ShowSearchResult, 0, len(shows)),
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for _, show := range shows {
+		availableSeats := constants.TOTAL_NO_OF_SEATS - show.BookedSeats
+		deluxeCost, _ := show.Cost.Add(deluxeOffset)
+		deluxePrice, _ := deluxeCost.Float64()
+
+		result.Shows = append(result.Shows, response.ShowSearchResult{
+			ShowResponse: *response.NewShowResponse(*catalog[show.MovieId], show.Slot, show.Show, availableSeats),
+			DeluxeCost:   deluxePrice,
+		})
+	}
+
+	return result, nil
+}
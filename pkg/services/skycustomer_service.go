@@ -7,43 +7,46 @@ import (
 	"time"
 
 	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
 )
 
 type SkyCustomerService interface {
 	ValidateUserDetails(ctx context.Context, username, email, phoneNumber string) error
 	CreateCustomer(ctx context.Context, customer *models.SkyCustomer, user *models.User, passwordHistory *models.PasswordHistory, securityQuestionID int, securityAnswer string, profileImgBytes []byte, profileImgSHA string) error
-	GetProfileImagePresignedURL(ctx context.Context, username string) (string, time.Time, error)
+	GetProfileImagePresignedURL(ctx context.Context, username, variant string) (string, time.Time, error)
 	GetCustomerProfile(ctx context.Context, username string) (*response.CustomerProfileResponse, error)
 	UpdateProfileImage(ctx context.Context, username string, imageBytes []byte, imageSHA string) error
 	UpdateCustomerProfile(ctx context.Context, username string, request *request.UpdateCustomerProfileRequest) (*response.UpdateCustomerProfileResponse, error)
+	CleanupOrphanedProfileImages(ctx context.Context) (int, error)
 }
 
 type skyCustomerService struct {
 	skyCustomerRepo      repositories.SkyCustomerRepository
 	userRepo             repositories.UserRepository
 	securityQuestionRepo repositories.SecurityQuestionRepository
-	walletRepo           repositories.CustomerWalletRepository
-	s3Service            S3Service
+	storageService       StorageService
+	watchlistService     WatchlistService
 }
 
 func NewSkyCustomerService(
 	skyCustomerRepo repositories.SkyCustomerRepository,
 	userRepo repositories.UserRepository,
 	securityQuestionRepo repositories.SecurityQuestionRepository,
-	walletRepo repositories.CustomerWalletRepository,
-	s3Service S3Service,
+	storageService StorageService,
+	watchlistService WatchlistService,
 ) SkyCustomerService {
 	return &skyCustomerService{
 		skyCustomerRepo:      skyCustomerRepo,
 		userRepo:             userRepo,
 		securityQuestionRepo: securityQuestionRepo,
-		walletRepo:           walletRepo,
-		s3Service:            s3Service,
+		storageService:       storageService,
+		watchlistService:     watchlistService,
 	}
 }
 
@@ -100,44 +103,91 @@ func (s *skyCustomerService) CreateCustomer(
 		return utils.NewInternalServerError("SECURITY_ANSWER_HASH_ERROR", "Failed to hash security answer", err)
 	}
 
-	if len(profileImgBytes) > 0 && profileImgSHA != "" {
-		imageURL, err := s.s3Service.UploadProfileImage(ctx, profileImgBytes, user.Username, profileImgSHA)
-		if err != nil {
-			return err
-		}
-		customer.ProfileImg = imageURL
-	} else {
+	if len(profileImgBytes) == 0 || profileImgSHA == "" {
 		return utils.NewBadRequestError("INVALID_IMAGE_HASH", "The hash or the image bytes provided are invalid.", nil)
 	}
 
+	imageKey, err := s.storageService.UploadProfileImage(ctx, profileImgBytes, user.Username, profileImgSHA)
+	if err != nil {
+		return err
+	}
+
+	customer.ProfileImg = imageKey
 	customer.SecurityQuestionID = securityQuestionID
 	customer.SecurityAnswerHash = securityAnswerHash
 
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		return err
+	wallet := &models.CustomerWallet{
+		Username: customer.Username,
+		Balance:  decimal.Zero,
 	}
 
-	if err := s.userRepo.SavePasswordHistory(ctx, passwordHistory); err != nil {
+	if err := s.skyCustomerRepo.CreateCustomerAccount(ctx, user, passwordHistory, customer, wallet); err != nil {
+		// Nothing was written to the database, so the uploaded image belongs to no one. Delete
+		// it even if the request was cancelled; whatever is missed is caught by the orphan sweep.
+		if delErr := s.storageService.DeleteProfileImage(context.WithoutCancel(ctx), imageKey); delErr != nil {
+			log.Warn().Err(delErr).Str("username", user.Username).Str("imageKey", imageKey).Msg("Failed to delete profile image after signup failed")
+		}
 		return err
 	}
 
-	if err := s.skyCustomerRepo.Create(ctx, customer); err != nil {
-		if customer.ProfileImg != "" {
-			_ = s.s3Service.DeleteProfileImage(ctx, customer.ProfileImg)
-		}
-		return err
+	return nil
+}
+
+// CleanupOrphanedProfileImages deletes stored profile images that no customer points at, such
+// as uploads from a signup or image update that failed before the database write. Images newer
+// than the grace period are left alone because their database write may still be in flight.
+func (s *skyCustomerService) CleanupOrphanedProfileImages(ctx context.Context) (int, error) {
+	baseKeys, err := s.storageService.ListProfileImages(ctx, time.Now().Add(-constants.PROFILE_IMAGE_ORPHAN_GRACE_PERIOD))
+	if err != nil {
+		return 0, err
+	}
+	if len(baseKeys) == 0 {
+		return 0, nil
 	}
 
-	wallet := &models.CustomerWallet{
-		Username: customer.Username,
-		Balance:  decimal.Zero,
+	// Read the referenced keys after listing storage, so an image that became referenced
+	// between the two reads is seen as referenced.
+	referenced, err := s.skyCustomerRepo.GetReferencedProfileImageKeys(ctx)
+	if err != nil {
+		return 0, err
 	}
 
-	if err := s.walletRepo.CreateWallet(ctx, wallet); err != nil {
-		return err
+	deleted := 0
+	for _, baseKey := range baseKeys {
+		if referenced[baseKey] {
+			continue
+		}
+		if err := s.storageService.DeleteProfileImage(ctx, baseKey); err != nil {
+			log.Error().Err(err).Str("imageKey", baseKey).Msg("Failed to delete orphaned profile image")
+			continue
+		}
+		deleted++
 	}
 
-	return nil
+	if deleted > 0 {
+		log.Info().Int("count", deleted).Msg("Deleted orphaned profile images")
+	}
+	return deleted, nil
+}
+
+// StartProfileImageCleanupWorker runs CleanupOrphanedProfileImages every interval until ctx
+// is cancelled.
+func StartProfileImageCleanupWorker(ctx context.Context, skyCustomerService SkyCustomerService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := skyCustomerService.CleanupOrphanedProfileImages(ctx); err != nil {
+					log.Error().Err(err).Msg("Orphaned profile image cleanup failed")
+				}
+			}
+		}
+	}()
 }
 
 func (s *skyCustomerService) GetCustomerProfile(ctx context.Context, username string) (*response.CustomerProfileResponse, error) {
@@ -161,33 +211,43 @@ func (s *skyCustomerService) GetCustomerProfile(ctx context.Context, username st
 
 	createdAtStr := user.CreatedAt.Format(time.RFC3339)
 
+	watchlist, err := s.watchlistService.GetWatchlist(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
 	profile := &response.CustomerProfileResponse{
 		Username:               customer.Username,
 		Name:                   customer.Name,
 		Email:                  customer.Email,
 		PhoneNumber:            customer.Number,
+		EmailVerified:          customer.EmailVerifiedAt != nil,
+		PhoneNumberVerified:    customer.NumberVerifiedAt != nil,
+		PendingEmail:           customer.PendingEmail,
+		PendingPhoneNumber:     customer.PendingNumber,
 		SecurityQuestionExists: customer.SecurityQuestionID > 0 && customer.SecurityAnswerHash != "",
 		CreatedAt:              createdAtStr,
+		Watchlist:              watchlist.Movies,
 	}
 
 	return profile, nil
 }
 
-func (s *skyCustomerService) GetProfileImagePresignedURL(ctx context.Context, username string) (string, time.Time, error) {
+func (s *skyCustomerService) GetProfileImagePresignedURL(ctx context.Context, username, variant string) (string, time.Time, error) {
 	durationMinutes := 1440
 
 	duration := time.Duration(durationMinutes) * time.Minute
 
-	rawURL, err := s.skyCustomerRepo.GetCustomerProfileImg(ctx, username)
+	imageKey, err := s.skyCustomerRepo.GetCustomerProfileImg(ctx, username)
 	if err != nil {
 		return "", time.Time{}, err
 	}
 
-	if rawURL == "" {
+	if imageKey == "" {
 		return "", time.Time{}, utils.NewNotFoundError("PROFILE_IMAGE_NOT_FOUND", "No profile image found for this user", nil)
 	}
 
-	presignedURL, err := s.s3Service.GeneratePresignedURL(ctx, rawURL, duration)
+	presignedURL, err := s.storageService.GenerateProfileImageURL(ctx, imageKey, variant, duration)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -216,10 +276,10 @@ func (s *skyCustomerService) UpdateCustomerProfile(ctx context.Context, username
 	if request.Name == customer.Name {
 		updateName = false
 	}
-	if request.Email == customer.Email {
+	if request.Email == customer.Email || (customer.PendingEmail != nil && request.Email == *customer.PendingEmail) {
 		updateEmail = false
 	}
-	if request.PhoneNumber == customer.Number {
+	if request.PhoneNumber == customer.Number || (customer.PendingNumber != nil && request.PhoneNumber == *customer.PendingNumber) {
 		updatePhone = false
 	}
 
@@ -227,6 +287,12 @@ func (s *skyCustomerService) UpdateCustomerProfile(ctx context.Context, username
 		updates["name"] = request.Name
 	}
 
+	pendingEmail := customer.PendingEmail
+	pendingNumber := customer.PendingNumber
+
+	// A new email or phone number only becomes current once it is verified, see
+	// ContactVerificationService. Submitting the current or pending value again leaves a pending
+	// change alone, so editing only the name does not cancel it.
 	if updateEmail {
 		exists, err := s.skyCustomerRepo.ExistsByEmail(ctx, request.Email)
 		if err != nil {
@@ -235,7 +301,8 @@ func (s *skyCustomerService) UpdateCustomerProfile(ctx context.Context, username
 		if exists {
 			return nil, utils.NewBadRequestError("EMAIL_EXISTS", "Email already exists", nil)
 		}
-		updates["email"] = request.Email
+		pendingEmail = &request.Email
+		updates["pending_email"] = request.Email
 	}
 
 	if updatePhone {
@@ -246,7 +313,8 @@ func (s *skyCustomerService) UpdateCustomerProfile(ctx context.Context, username
 		if exists {
 			return nil, utils.NewBadRequestError("NUMBER_EXISTS", "Phone Number already exists", nil)
 		}
-		updates["number"] = request.PhoneNumber
+		pendingNumber = &request.PhoneNumber
+		updates["pending_number"] = request.PhoneNumber
 	}
 
 	if len(updates) > 0 {
@@ -257,10 +325,12 @@ func (s *skyCustomerService) UpdateCustomerProfile(ctx context.Context, username
 	}
 
 	return &response.UpdateCustomerProfileResponse{
-		Username:    username,
-		Name:        request.Name,
-		Email:       request.Email,
-		PhoneNumber: request.PhoneNumber,
+		Username:           username,
+		Name:               request.Name,
+		Email:              customer.Email,
+		PhoneNumber:        customer.Number,
+		PendingEmail:       pendingEmail,
+		PendingPhoneNumber: pendingNumber,
 	}, nil
 }
 
@@ -272,9 +342,9 @@ func (s *skyCustomerService) UpdateProfileImage(ctx context.Context, username st
 	if customer == nil {
 		return utils.NewNotFoundError("USER_NOT_FOUND", fmt.Sprintf("No user found with username: %s", username), nil)
 	}
-	var newImageURL string
+	var newImageKey string
 	if len(imageBytes) > 0 && imageSHA != "" {
-		newImageURL, err = s.s3Service.UploadProfileImage(ctx, imageBytes, customer.Username, imageSHA)
+		newImageKey, err = s.storageService.UploadProfileImage(ctx, imageBytes, customer.Username, imageSHA)
 		if err != nil {
 			return err
 		}
@@ -282,16 +352,16 @@ func (s *skyCustomerService) UpdateProfileImage(ctx context.Context, username st
 		return utils.NewBadRequestError("INVALID_IMAGE_HASH", "The hash or the image bytes provided are invalid.", nil)
 	}
 
-	if err := s.skyCustomerRepo.UpdateProfileImageURL(ctx, username, newImageURL); err != nil {
-		_ = s.s3Service.DeleteProfileImage(ctx, newImageURL)
-		return utils.NewInternalServerError("DB_UPDATE_FAILED", "Failed to update profile image URL in database", err)
+	if err := s.skyCustomerRepo.UpdateProfileImageKey(ctx, username, newImageKey); err != nil {
+		_ = s.storageService.DeleteProfileImage(ctx, newImageKey)
+		return utils.NewInternalServerError("DB_UPDATE_FAILED", "Failed to update profile image in database", err)
 	}
 
-	if oldImageURL := customer.ProfileImg; oldImageURL != "" {
-		if delErr := s.s3Service.DeleteProfileImage(ctx, oldImageURL); delErr != nil {
+	if oldImageKey := customer.ProfileImg; oldImageKey != "" {
+		if delErr := s.storageService.DeleteProfileImage(ctx, oldImageKey); delErr != nil {
 			return utils.NewInternalServerError(
 				"PARTIAL_UPDATE",
-				"Profile image updated, but failed to delete old image from S3",
+				"Profile image updated, but failed to delete old image from storage",
 				delErr,
 			)
 		}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"path"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// StorageService stores profile images and reports in the configured BlobStorage. Every
+// method takes and returns object keys, never provider URLs. A profile image is stored as
+// several variants under one base key; the base key is what customertable keeps.
+type StorageService interface {
+	UploadProfileImage(ctx context.Context, imageBytes []byte, username string, sha string) (string, error)
+	DeleteProfileImage(ctx context.Context, baseKey string) error
+	ListProfileImages(ctx context.Context, modifiedBefore time.Time) ([]string, error)
+	GetProfileImage(ctx context.Context, baseKey, variant string) ([]byte, string, error)
+	GenerateProfileImageURL(ctx context.Context, baseKey, variant string, duration time.Duration) (string, error)
+	GeneratePresignedURL(ctx context.Context, objectKey string, duration time.Duration) (string, error)
+	UploadReport(ctx context.Context, data []byte, objectKey string, contentType string) error
+}
+
+type storageService struct {
+	storage   BlobStorage
+	moderator ImageModerator
+}
+
+func NewStorageService(storage BlobStorage, moderator ImageModerator) StorageService {
+	return &storageService{
+		storage:   storage,
+		moderator: moderator,
+	}
+}
+
+// UploadProfileImage validates the image, passes it to the moderator and stores every variant.
+// It returns the base key of the stored variants.
+func (s *storageService) UploadProfileImage(ctx context.Context, imageBytes []byte, username string, providedSHA string) (string, error) {
+	if !validateSHA(imageBytes, providedSHA) {
+		return "", utils.NewBadRequestError("INVALID_IMAGE_HASH", "The image hash does not match", nil)
+	}
+
+	img, contentType, err := decodeProfileImage(imageBytes)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Rejected profile image")
+		return "", err
+	}
+
+	decision, err := s.moderator.Moderate(ctx, ProfileImageReview{Username: username, ContentType: contentType, Image: img})
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("moderator", s.moderator.Name()).Msg("Profile image moderation failed")
+		return "", utils.NewInternalServerError("MODERATION_FAILED", "Failed to review profile image", err)
+	}
+	if !decision.Approved {
+		log.Info().Str("username", username).Str("moderator", s.moderator.Name()).Str("reason", decision.Reason).Msg("Profile image rejected by moderation")
+		return "", utils.NewBadRequestError("IMAGE_REJECTED", fmt.Sprintf("Profile image was rejected: %s", decision.Reason), nil)
+	}
+
+	baseKey := fmt.Sprintf("%s%s/%d", profileImageKeyPrefix, username, time.Now().Unix())
+
+	stored := make([]string, 0, len(profileImageVariants))
+	for _, variant := range profileImageVariants {
+		data, err := encodeProfileImageVariant(img, variant)
+		if err == nil {
+			key := profileImageVariantKey(baseKey, variant.name)
+			if err = s.storage.Put(ctx, key, data, "image/webp"); err == nil {
+				stored = append(stored, key)
+				continue
+			}
+		}
+
+		log.Error().Err(err).Str("username", username).Str("variant", variant.name).Msg("Failed to store profile image variant")
+		for _, key := range stored {
+			_ = s.storage.Delete(ctx, key)
+		}
+		return "", err
+	}
+
+	return baseKey, nil
+}
+
+func (s *storageService) DeleteProfileImage(ctx context.Context, baseKey string) error {
+	var firstErr error
+	deleted := make(map[string]bool)
+	for _, variant := range profileImageVariants {
+		key := profileImageVariantKey(baseKey, variant.name)
+		if deleted[key] {
+			continue
+		}
+		deleted[key] = true
+		if err := s.storage.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// ListProfileImages returns the base key of every stored profile image whose objects were all
+// last modified before modifiedBefore. The cutoff keeps uploads whose database write has not
+// happened yet out of the result.
+func (s *storageService) ListProfileImages(ctx context.Context, modifiedBefore time.Time) ([]string, error) {
+	objects, err := s.storage.List(ctx, profileImageKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	settled := make(map[string]bool)
+	var baseKeys []string
+	for _, object := range objects {
+		baseKey := profileImageBaseKey(object.Key)
+		old, seen := settled[baseKey]
+		if !seen {
+			baseKeys = append(baseKeys, baseKey)
+			old = true
+		}
+		settled[baseKey] = old && object.LastModified.Before(modifiedBefore)
+	}
+
+	result := baseKeys[:0]
+	for _, baseKey := range baseKeys {
+		if settled[baseKey] {
+			result = append(result, baseKey)
+		}
+	}
+	return result, nil
+}
+
+// GetProfileImage returns one variant of a profile image and its content type.
+func (s *storageService) GetProfileImage(ctx context.Context, baseKey, variant string) ([]byte, string, error) {
+	if !isProfileImageVariant(variant) {
+		return nil, "", utils.NewBadRequestError("INVALID_VARIANT", fmt.Sprintf("Unknown profile image variant %q", variant), nil)
+	}
+
+	key := profileImageVariantKey(baseKey, variant)
+	data, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, mime.TypeByExtension(path.Ext(key)), nil
+}
+
+func (s *storageService) GenerateProfileImageURL(ctx context.Context, baseKey, variant string, duration time.Duration) (string, error) {
+	if !isProfileImageVariant(variant) {
+		return "", utils.NewBadRequestError("INVALID_VARIANT", fmt.Sprintf("Unknown profile image variant %q", variant), nil)
+	}
+	return s.storage.PresignedURL(ctx, profileImageVariantKey(baseKey, variant), duration)
+}
+
+func (s *storageService) GeneratePresignedURL(ctx context.Context, objectKey string, duration time.Duration) (string, error) {
+	return s.storage.PresignedURL(ctx, objectKey, duration)
+}
+
+func (s *storageService) UploadReport(ctx context.Context, data []byte, objectKey string, contentType string) error {
+	return s.storage.Put(ctx, objectKey, data, contentType)
+}
+
+func validateSHA(imageBytes []byte, providedSHA string) bool {
+	hash := sha256.Sum256(imageBytes)
+	calculatedSHA := hex.EncodeToString(hash[:])
+
+	return calculatedSHA == providedSHA
+}
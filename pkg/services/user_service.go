@@ -3,49 +3,137 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
 )
 
+// LoginResult is the outcome of a password login. Token is set when the user is fully signed
+// in. Otherwise MFAToken is a short-lived token for the next step: completing an MFA challenge
+// if MFARequired, or enrolling if MFAEnrollmentRequired. If PasswordChangeRequired, the password
+// has expired and PasswordChangeToken can only be used to change it.
+type LoginResult struct {
+	User                   *models.User
+	Token                  string
+	MFAToken               string
+	MFARequired            bool
+	MFAEnrollmentRequired  bool
+	PasswordChangeRequired bool
+	PasswordChangeToken    string
+}
+
 type UserService interface {
-	Login(ctx context.Context, username, password string) (*models.User, string, error)
+	Login(ctx context.Context, username, password string) (*LoginResult, error)
+	CompleteMFALogin(ctx context.Context, mfaToken, code string) (*LoginResult, error)
 }
 
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	mfaService     MFAService
+	passwordPolicy PasswordPolicyService
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, mfaService MFAService, passwordPolicy PasswordPolicyService) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		mfaService:     mfaService,
+		passwordPolicy: passwordPolicy,
 	}
 }
 
-func (s *userService) Login(ctx context.Context, username, password string) (*models.User, string, error) {
+func (s *userService) Login(ctx context.Context, username, password string) (*LoginResult, error) {
 	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	if user == nil {
-		return nil, "", utils.NewUnauthorizedError("INVALID_CREDENTIALS", "Invalid username or password", nil)
+		return nil, utils.NewUnauthorizedError("INVALID_CREDENTIALS", "Invalid username or password", nil)
 	}
 
 	if !utils.CheckPasswordHash(password, user.Password) {
-		return nil, "", utils.NewUnauthorizedError("INVALID_CREDENTIALS", "Invalid username or password", nil)
+		return nil, utils.NewUnauthorizedError("INVALID_CREDENTIALS", "Invalid username or password", nil)
+	}
+
+	enrolled, required, err := s.mfaService.LoginRequirement(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if enrolled {
+		mfaToken, err := generateScopedToken(user, constants.TOKEN_PURPOSE_MFA_CHALLENGE, constants.MFA_CHALLENGE_TOKEN_TTL)
+		if err != nil {
+			return nil, utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+		}
+		return &LoginResult{User: user, MFAToken: mfaToken, MFARequired: true}, nil
+	}
+
+	// An expired password is changed before MFA enrollment, so enrollment always ends in a
+	// usable session.
+	if s.passwordPolicy.IsExpired(user) {
+		return passwordChangeResult(user)
+	}
+
+	if required {
+		mfaToken, err := generateScopedToken(user, constants.TOKEN_PURPOSE_MFA_ENROLLMENT, constants.MFA_ENROLLMENT_TOKEN_TTL)
+		if err != nil {
+			return nil, utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+		}
+		return &LoginResult{User: user, MFAToken: mfaToken, MFAEnrollmentRequired: true}, nil
 	}
 
 	token, err := generateToken(user)
 	if err != nil {
-		return nil, "", utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+		return nil, utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+	}
+
+	return &LoginResult{User: user, Token: token}, nil
+}
+
+// CompleteMFALogin exchanges a challenge token from Login and a valid MFA code for a session
+// token, or for a password change token if the password has expired.
+func (s *userService) CompleteMFALogin(ctx context.Context, mfaToken, code string) (*LoginResult, error) {
+	username, err := parseScopedToken(mfaToken, constants.TOKEN_PURPOSE_MFA_CHALLENGE)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("INVALID_MFA_TOKEN", "MFA token is invalid or has expired, please log in again", err)
 	}
 
-	return user, token, nil
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, utils.NewUnauthorizedError("INVALID_MFA_TOKEN", "MFA token is invalid or has expired, please log in again", nil)
+	}
+
+	if err := s.mfaService.VerifyCode(ctx, username, code); err != nil {
+		return nil, err
+	}
+
+	if s.passwordPolicy.IsExpired(user) {
+		return passwordChangeResult(user)
+	}
+
+	token, err := generateToken(user)
+	if err != nil {
+		return nil, utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+	}
+
+	return &LoginResult{User: user, Token: token}, nil
+}
+
+func passwordChangeResult(user *models.User) (*LoginResult, error) {
+	token, err := generateScopedToken(user, constants.TOKEN_PURPOSE_PASSWORD_CHANGE, constants.PASSWORD_CHANGE_TOKEN_TTL)
+	if err != nil {
+		return nil, utils.NewInternalServerError("TOKEN_GENERATION_FAILED", "Failed to generate token", err)
+	}
+	return &LoginResult{User: user, PasswordChangeRequired: true, PasswordChangeToken: token}, nil
 }
 
 func generateToken(user *models.User) (string, error) {
@@ -63,3 +151,54 @@ func generateToken(user *models.User) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secretKey))
 }
+
+// generateScopedToken issues a token carrying a purpose claim. AuthMiddleware rejects such
+// tokens, so they can only be used where that purpose is expected.
+func generateScopedToken(user *models.User, purpose string, ttl time.Duration) (string, error) {
+	secretKey := os.Getenv("JWT_SECRET_KEY")
+	if secretKey == "" {
+		return "", errors.New("JWT secret key not set")
+	}
+
+	claims := jwt.MapClaims{
+		"username": user.Username,
+		"role":     user.Role,
+		"purpose":  purpose,
+		"exp":      time.Now().Add(ttl).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secretKey))
+}
+
+// parseScopedToken validates a token from generateScopedToken and returns its username.
+func parseScopedToken(tokenString, purpose string) (string, error) {
+	secretKey := os.Getenv("JWT_SECRET_KEY")
+	if secretKey == "" {
+		return "", errors.New("JWT secret key not set")
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method")
+		}
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", errors.New("invalid token")
+	}
+	if tokenPurpose, _ := claims["purpose"].(string); tokenPurpose != purpose {
+		return "", errors.New("unexpected token purpose")
+	}
+	username, ok := claims["username"].(string)
+	if !ok || username == "" {
+		return "", errors.New("token has no username")
+	}
+
+	return username, nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// VerificationMessage is a one-time code addressed to an email address or phone number.
+type VerificationMessage struct {
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	Username    string    `json:"username"`
+	Code        string    `json:"code"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// VerificationSender delivers verification codes. An email or SMS provider only needs to
+// implement this interface and be selected in NewVerificationSender.
+type VerificationSender interface {
+	Name() string
+	Send(ctx context.Context, message VerificationMessage) error
+}
+
+func NewVerificationSender(cfg config.VerificationConfig) VerificationSender {
+	switch cfg.Sender {
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			log.Warn().Msg("VERIFICATION_WEBHOOK_URL is not set, falling back to logging verification codes")
+			return &logVerificationSender{}
+		}
+		return &webhookVerificationSender{url: cfg.WebhookURL, client: &http.Client{Timeout: 10 * time.Second}}
+	case "log", "":
+		return &logVerificationSender{}
+	default:
+		log.Warn().Str("sender", cfg.Sender).Msg("Unknown VERIFICATION_SENDER, falling back to logging verification codes")
+		return &logVerificationSender{}
+	}
+}
+
+// logVerificationSender writes codes to the application log. It is the default and is
+// meant for local development only, since anyone with log access can read the codes.
+type logVerificationSender struct{}
+
+func (s *logVerificationSender) Name() string {
+	return "log"
+}
+
+func (s *logVerificationSender) Send(ctx context.Context, message VerificationMessage) error {
+	log.Info().
+		Str("channel", message.Channel).
+		Str("destination", message.Destination).
+		Str("username", message.Username).
+		Str("code", message.Code).
+		Time("expiresAt", message.ExpiresAt).
+		Msg("Verification code issued")
+	return nil
+}
+
+// webhookVerificationSender posts the message as JSON to a mailer or SMS gateway.
+type webhookVerificationSender struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookVerificationSender) Name() string {
+	return "webhook"
+}
+
+func (s *webhookVerificationSender) Send(ctx context.Context, message VerificationMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return utils.NewInternalServerError("REQUEST_PREPARATION_FAILED", "Failed to prepare verification message", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return utils.NewInternalServerError("REQUEST_CREATION_FAILED", "Failed to create verification request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return utils.NewInternalServerError("VERIFICATION_DELIVERY_FAILED", "Failed to reach verification webhook", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return utils.NewInternalServerError("VERIFICATION_DELIVERY_FAILED", fmt.Sprintf("Verification webhook responded with status %d", resp.StatusCode), nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+)
+
+func newTopUpEntry(walletID int64, reference string, amount decimal.Decimal) *models.LedgerJournalEntry {
+	return &models.LedgerJournalEntry{
+		Reference:   reference,
+		EntryType:   "TOP_UP",
+		Description: "Wallet top-up by card",
+		Postings: []models.LedgerPosting{
+			{AccountCode: constants.LEDGER_ACCOUNT_CARD_CLEARING, Direction: "DEBIT", Amount: amount},
+			{WalletID: &walletID, Direction: "CREDIT", Amount: amount},
+		},
+	}
+}
+
+func newBookingPaymentEntry(walletID int64, reference string, bookingID int, amount decimal.Decimal) *models.LedgerJournalEntry {
+	return &models.LedgerJournalEntry{
+		Reference:   reference,
+		EntryType:   "BOOKING_PAYMENT",
+		Description: "Booking paid from wallet",
+		BookingID:   toPtr(int64(bookingID)),
+		Postings: []models.LedgerPosting{
+			{WalletID: &walletID, Direction: "DEBIT", Amount: amount},
+			{AccountCode: constants.LEDGER_ACCOUNT_BOOKING_REVENUE, Direction: "CREDIT", Amount: amount},
+		},
+	}
+}
+
+func newBookingRefundEntry(walletID int64, reference string, bookingID int, reason, refundedBy string, amount decimal.Decimal) *models.LedgerJournalEntry {
+	return &models.LedgerJournalEntry{
+		Reference:   reference,
+		EntryType:   "BOOKING_REFUND",
+		Description: reason,
+		BookingID:   toPtr(int64(bookingID)),
+		CreatedBy:   &refundedBy,
+		Postings: []models.LedgerPosting{
+			{AccountCode: constants.LEDGER_ACCOUNT_BOOKING_REVENUE, Direction: "DEBIT", Amount: amount},
+			{WalletID: &walletID, Direction: "CREDIT", Amount: amount, TransactionType: "REFUND"},
+		},
+	}
+}
+
+func newAdjustmentEntry(walletID int64, reference, direction, reason, createdBy string, amount decimal.Decimal) *models.LedgerJournalEntry {
+	offsetDirection := "DEBIT"
+	if direction == "DEBIT" {
+		offsetDirection = "CREDIT"
+	}
+
+	return &models.LedgerJournalEntry{
+		Reference:   reference,
+		EntryType:   "ADJUSTMENT",
+		Description: reason,
+		CreatedBy:   &createdBy,
+		Postings: []models.LedgerPosting{
+			{WalletID: &walletID, Direction: direction, Amount: amount},
+			{AccountCode: constants.LEDGER_ACCOUNT_ADJUSTMENTS, Direction: offsetDirection, Amount: amount},
+		},
+	}
+}
+
+func newTransferEntry(senderWalletID, recipientWalletID int64, reference, sender, recipient string, note *string, amount decimal.Decimal) *models.LedgerJournalEntry {
+	return &models.LedgerJournalEntry{
+		Reference:   reference,
+		EntryType:   "TRANSFER",
+		Description: "Wallet transfer from " + sender + " to " + recipient,
+		CreatedBy:   &sender,
+		Postings: []models.LedgerPosting{
+			{WalletID: &senderWalletID, Direction: "DEBIT", Amount: amount, TransactionType: "TRANSFER_OUT", CounterpartyUsername: &recipient, Note: note},
+			{WalletID: &recipientWalletID, Direction: "CREDIT", Amount: amount, TransactionType: "TRANSFER_IN", CounterpartyUsername: &sender, Note: note},
+		},
+	}
+}
+
+func newGoodwillCreditEntry(walletID int64, reference, reason, grantedBy string, amount decimal.Decimal) *models.LedgerJournalEntry {
+	return &models.LedgerJournalEntry{
+		Reference:   reference,
+		EntryType:   "GOODWILL_CREDIT",
+		Description: reason,
+		CreatedBy:   &grantedBy,
+		Postings: []models.LedgerPosting{
+			{AccountCode: constants.LEDGER_ACCOUNT_GOODWILL, Direction: "DEBIT", Amount: amount},
+			{WalletID: &walletID, Direction: "CREDIT", Amount: amount, TransactionType: "CREDIT", Note: &reason},
+		},
+	}
+}
@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/govalues/decimal"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
 	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
 	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
 	paymentservice "github.com/iamsuteerth/skyfox-backend/pkg/payment-service"
 	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
 	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
@@ -18,27 +21,42 @@ import (
 type WalletService interface {
 	AddFunds(ctx context.Context, username string, req *request.AddWalletFundsRequest) (*response.WalletResponse, error)
 	GetWalletBalance(ctx context.Context, username string) (*response.WalletResponse, error)
-	GetTransactions(ctx context.Context, username string) (*response.WalletTransactionsResponse, error)
+	GetTransactions(ctx context.Context, username string, req request.WalletTransactionsRequest) (*response.WalletTransactionsResponse, error)
+	PostAdjustment(ctx context.Context, adminUsername string, req request.WalletAdjustmentRequest) (*response.WalletAdjustmentResponse, error)
+	IssueCredit(ctx context.Context, adminUsername string, req request.WalletCreditRequest) (*response.WalletCreditResponse, error)
+	ExpireCredits(ctx context.Context) (int, error)
 }
 
 type walletService struct {
 	customerWalletRepo     repositories.CustomerWalletRepository
 	walletTxdRepo          repositories.WalletTransactionRepository
+	walletLedgerRepo       repositories.WalletLedgerRepository
 	paymentTransactionRepo repositories.PaymentTransactionRepository
+	savedCardRepo          repositories.SavedCardRepository
 	paymentService         paymentservice.PaymentService
+	movieService           movieservice.MovieService
+	contactVerification    ContactVerificationService
 }
 
 func NewWalletService(
 	customerWalletRepo repositories.CustomerWalletRepository,
 	walletTxdRepo repositories.WalletTransactionRepository,
+	walletLedgerRepo repositories.WalletLedgerRepository,
 	paymentTransactionRepo repositories.PaymentTransactionRepository,
+	savedCardRepo repositories.SavedCardRepository,
 	paymentService paymentservice.PaymentService,
+	movieService movieservice.MovieService,
+	contactVerification ContactVerificationService,
 ) WalletService {
 	return &walletService{
 		customerWalletRepo:     customerWalletRepo,
 		walletTxdRepo:          walletTxdRepo,
+		walletLedgerRepo:       walletLedgerRepo,
 		paymentTransactionRepo: paymentTransactionRepo,
+		savedCardRepo:          savedCardRepo,
 		paymentService:         paymentService,
+		movieService:           movieService,
+		contactVerification:    contactVerification,
 	}
 }
 
@@ -47,25 +65,15 @@ func (s *walletService) AddFunds(ctx context.Context, username string, req *requ
 		return nil, utils.NewBadRequestError("INVALID_AMOUNT", "Amount must be greater than zero", nil)
 	}
 
+	if err := s.contactVerification.CheckVerifiedContact(ctx, username, verifiedContactActionWalletTopUp); err != nil {
+		return nil, err
+	}
+
 	maxAmount, _ := decimal.NewFromFloat64(10000)
 
 	if req.Amount.Cmp(maxAmount) > 0 {
 		return nil, utils.NewBadRequestError("AMOUNT_TOO_LARGE", "Maximum amount allowed is 10000", nil)
 	}
-	expiry := fmt.Sprintf("%s/%s", req.ExpiryMonth, req.ExpiryYear)
-	transactionID, err := s.paymentService.ProcessPayment(
-		ctx,
-		req.CardNumber,
-		req.CVV,
-		expiry,
-		req.CardholderName,
-		req.Amount,
-	)
-	if err != nil {
-		log.Error().Err(err).Str("username", username).Msg("Card payment failed when adding funds to wallet")
-		return nil, err
-	}
-
 	wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, username)
 	if err != nil {
 		log.Error().Err(err).Str("username", username).Msg("Failed to retrieve wallet")
@@ -76,22 +84,28 @@ func (s *walletService) AddFunds(ctx context.Context, username string, req *requ
 		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for user", nil)
 	}
 
-	if err := s.customerWalletRepo.AddToWalletBalance(ctx, username, req.Amount); err != nil {
-		log.Error().Err(err).Str("username", username).Msg("Failed to update wallet balance")
-		return nil, err
+	var transactionID string
+	if req.SavedCardID != nil {
+		transactionID, err = chargeSavedCard(ctx, s.savedCardRepo, s.paymentService, username, *req.SavedCardID, req.Amount)
+	} else {
+		expiry := fmt.Sprintf("%s/%s", req.ExpiryMonth, req.ExpiryYear)
+		transactionID, err = s.paymentService.ProcessPayment(
+			ctx,
+			req.CardNumber,
+			req.CVV,
+			expiry,
+			req.CardholderName,
+			req.Amount,
+		)
 	}
-
-	walletTxn := &models.WalletTransaction{
-		WalletID:        wallet.ID,
-		Username:        username,
-		BookingID:       nil,
-		TransactionID:   transactionID,
-		Amount:          req.Amount,
-		TransactionType: "ADD",
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Card payment failed when adding funds to wallet")
+		return nil, err
 	}
 
-	if err := s.walletTxdRepo.AddWalletTransaction(ctx, walletTxn); err != nil {
-		log.Error().Err(err).Str("username", username).Msg("Failed to record wallet transaction")
+	if err := s.walletLedgerRepo.PostEntry(ctx, newTopUpEntry(wallet.ID, transactionID, req.Amount)); err != nil {
+		log.Error().Err(err).Str("username", username).Str("transactionID", transactionID).
+			Msg("Failed to credit wallet after card was charged")
 		return nil, err
 	}
 
@@ -129,7 +143,12 @@ func (s *walletService) GetWalletBalance(ctx context.Context, username string) (
 	}, nil
 }
 
-func (s *walletService) GetTransactions(ctx context.Context, username string) (*response.WalletTransactionsResponse, error) {
+// walletTransactionTypes mirrors the wallet_transaction_type enum.
+var walletTransactionTypes = map[string]bool{
+	"ADD": true, "DEDUCT": true, "TRANSFER_IN": true, "TRANSFER_OUT": true, "CREDIT": true, "CREDIT_EXPIRY": true, "REFUND": true,
+}
+
+func (s *walletService) GetTransactions(ctx context.Context, username string, req request.WalletTransactionsRequest) (*response.WalletTransactionsResponse, error) {
 	wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, username)
 	if err != nil {
 		return nil, err
@@ -139,30 +158,213 @@ func (s *walletService) GetTransactions(ctx context.Context, username string) (*
 		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for user", nil)
 	}
 
-	transactions, err := s.walletTxdRepo.GetWalletTransactionsForUser(ctx, username)
+	page, err := parseHistoryPage(req.Cursor, req.From, req.To, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
-	var transactionResponses []response.WalletTransactionResponse
-	for _, t := range transactions {
-		amount, _ := t.Amount.Float64()
-		response := response.WalletTransactionResponse{
-			ID:              t.ID,
-			Amount:          amount,
-			TransactionType: t.TransactionType,
-			TransactionID:   t.TransactionID,
-			Timestamp:       t.Timestamp.Format(time.RFC3339),
+	filter := models.WalletTransactionFilter{
+		Username: username,
+		Since:    page.since,
+		Until:    page.until,
+		After:    page.after,
+		Limit:    page.limit + 1,
+	}
+
+	for _, txnType := range strings.Split(req.Type, ",") {
+		if txnType = strings.TrimSpace(txnType); txnType == "" {
+			continue
+		}
+		if !walletTransactionTypes[txnType] {
+			return nil, utils.NewBadRequestError("INVALID_TRANSACTION_TYPE", fmt.Sprintf("Unknown transaction type %q", txnType), nil)
 		}
+		filter.Types = append(filter.Types, txnType)
+	}
+
+	transactions, err := s.walletTxdRepo.GetWalletTransactionsPage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 
-		if t.BookingID != nil {
-			response.BookingID = t.BookingID
+	resp := &response.WalletTransactionsResponse{Transactions: []response.WalletTransactionResponse{}}
+	if len(transactions) > page.limit {
+		transactions = transactions[:page.limit]
+		last := transactions[len(transactions)-1]
+		cursor := utils.EncodeCursor(last.Timestamp, last.ID)
+		resp.NextCursor = &cursor
+	}
+
+	movies := make(map[string]*models.Movie)
+	for _, t := range transactions {
+		resp.Transactions = append(resp.Transactions, toWalletTransactionResponse(ctx, s.movieService, t, movies))
+	}
+
+	return resp, nil
+}
+
+func toWalletTransactionResponse(ctx context.Context, movieService movieservice.MovieService, t *models.WalletTransaction, movies map[string]*models.Movie) response.WalletTransactionResponse {
+	amount, _ := t.Amount.Float64()
+	txnResponse := response.WalletTransactionResponse{
+		ID:                   t.ID,
+		Amount:               amount,
+		TransactionType:      t.TransactionType,
+		BookingID:            t.BookingID,
+		TransactionID:        t.TransactionID,
+		Timestamp:            t.Timestamp.Format(time.RFC3339),
+		CounterpartyUsername: t.CounterpartyUsername,
+		Note:                 t.Note,
+		MovieID:              t.MovieID,
+	}
+
+	if t.MovieID != nil {
+		if movie := lookupMovie(ctx, movieService, *t.MovieID, movies); movie != nil {
+			txnResponse.MovieName = &movie.Name
+			txnResponse.MoviePoster = &movie.MoviePoster
 		}
+	}
+
+	return txnResponse
+}
+
+func (s *walletService) PostAdjustment(ctx context.Context, adminUsername string, req request.WalletAdjustmentRequest) (*response.WalletAdjustmentResponse, error) {
+	if req.Amount.Cmp(decimal.Zero) <= 0 {
+		return nil, utils.NewBadRequestError("INVALID_AMOUNT", "Amount must be greater than zero", nil)
+	}
+
+	wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, req.Username)
+	if err != nil {
+		log.Error().Err(err).Str("username", req.Username).Msg("Failed to retrieve wallet for adjustment")
+		return nil, err
+	}
+
+	if wallet == nil {
+		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for user", nil)
+	}
 
-		transactionResponses = append(transactionResponses, response)
+	entry := newAdjustmentEntry(wallet.ID, uuid.New().String(), req.Direction, req.Reason, adminUsername, req.Amount)
+	if err := s.walletLedgerRepo.PostEntry(ctx, entry); err != nil {
+		log.Error().Err(err).Str("username", req.Username).Str("admin", adminUsername).Msg("Failed to post wallet adjustment")
+		return nil, err
 	}
 
-	return &response.WalletTransactionsResponse{
-		Transactions: transactionResponses,
+	updatedWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, req.Username)
+	if err != nil {
+		log.Error().Err(err).Str("username", req.Username).Msg("Failed to retrieve updated wallet")
+		return nil, err
+	}
+
+	amount, _ := req.Amount.Float64()
+	balance, _ := updatedWallet.Balance.Float64()
+
+	return &response.WalletAdjustmentResponse{
+		Reference: entry.Reference,
+		Username:  req.Username,
+		Direction: req.Direction,
+		Amount:    amount,
+		Reason:    req.Reason,
+		Balance:   balance,
+		CreatedBy: adminUsername,
+		CreatedAt: entry.CreatedAt.Format(time.RFC3339),
 	}, nil
 }
+
+func (s *walletService) IssueCredit(ctx context.Context, adminUsername string, req request.WalletCreditRequest) (*response.WalletCreditResponse, error) {
+	if req.Amount.Cmp(decimal.Zero) <= 0 {
+		return nil, utils.NewBadRequestError("INVALID_AMOUNT", "Amount must be greater than zero", nil)
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, utils.NewBadRequestError("INVALID_EXPIRY", "Credit expiry must be in the future", nil)
+	}
+
+	wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, req.Username)
+	if err != nil {
+		log.Error().Err(err).Str("username", req.Username).Msg("Failed to retrieve wallet for credit")
+		return nil, err
+	}
+
+	if wallet == nil {
+		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for user", nil)
+	}
+
+	reference := uuid.New().String()
+	grant := &models.WalletCreditGrant{
+		WalletID:  wallet.ID,
+		Reference: reference,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+		GrantedBy: adminUsername,
+		ExpiresAt: req.ExpiresAt,
+	}
+	entry := newGoodwillCreditEntry(wallet.ID, reference, req.Reason, adminUsername, req.Amount)
+
+	if err := s.walletLedgerRepo.PostCreditGrant(ctx, grant, entry); err != nil {
+		log.Error().Err(err).Str("username", req.Username).Str("admin", adminUsername).Msg("Failed to issue wallet credit")
+		return nil, err
+	}
+
+	updatedWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, req.Username)
+	if err != nil {
+		log.Error().Err(err).Str("username", req.Username).Msg("Failed to retrieve updated wallet")
+		return nil, err
+	}
+
+	amount, _ := req.Amount.Float64()
+	balance, _ := updatedWallet.Balance.Float64()
+
+	resp := &response.WalletCreditResponse{
+		Reference: reference,
+		Username:  req.Username,
+		Amount:    amount,
+		Reason:    req.Reason,
+		Balance:   balance,
+		GrantedBy: adminUsername,
+		CreatedAt: grant.CreatedAt.Format(time.RFC3339),
+	}
+	if grant.ExpiresAt != nil {
+		resp.ExpiresAt = grant.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return resp, nil
+}
+
+// ExpireCredits claws back whatever is left of credits past their expiry and
+// returns how many grants were expired.
+func (s *walletService) ExpireCredits(ctx context.Context) (int, error) {
+	grants, err := s.walletLedgerRepo.FindExpiredCreditGrants(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, grant := range grants {
+		clawback, err := s.walletLedgerRepo.ExpireCreditGrant(ctx, grant.ID, grant.Reference+"-expiry")
+		if err != nil {
+			log.Error().Err(err).Int64("grantId", grant.ID).Msg("Failed to expire wallet credit")
+			continue
+		}
+		log.Info().Int64("grantId", grant.ID).Str("clawback", clawback.String()).Msg("Expired wallet credit")
+		expired++
+	}
+
+	return expired, nil
+}
+
+// StartWalletCreditExpiryWorker periodically expires goodwill credits until ctx is cancelled.
+func StartWalletCreditExpiryWorker(ctx context.Context, walletService WalletService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := walletService.ExpireCredits(ctx); err != nil {
+					log.Error().Err(err).Msg("Wallet credit expiry sweep failed")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/rs/zerolog/log"
+)
+
+// Statements are limited to a year so a single request cannot pull a customer's entire history.
+const maxWalletStatementDays = 366
+
+type WalletStatementService interface {
+	GetStatement(ctx context.Context, username string, from, to time.Time) (*response.WalletStatement, error)
+	GenerateStatementCSV(statement *response.WalletStatement) ([]byte, error)
+	GenerateStatementPDF(statement *response.WalletStatement) ([]byte, error)
+}
+
+type walletStatementService struct {
+	customerWalletRepo repositories.CustomerWalletRepository
+	walletTxdRepo      repositories.WalletTransactionRepository
+	walletLedgerRepo   repositories.WalletLedgerRepository
+	bookingRepo        repositories.BookingRepository
+	showRepo           repositories.ShowRepository
+	skyCustomerRepo    repositories.SkyCustomerRepository
+	movieService       movieservice.MovieService
+}
+
+func NewWalletStatementService(
+	customerWalletRepo repositories.CustomerWalletRepository,
+	walletTxdRepo repositories.WalletTransactionRepository,
+	walletLedgerRepo repositories.WalletLedgerRepository,
+	bookingRepo repositories.BookingRepository,
+	showRepo repositories.ShowRepository,
+	skyCustomerRepo repositories.SkyCustomerRepository,
+	movieService movieservice.MovieService,
+) WalletStatementService {
+	return &walletStatementService{
+		customerWalletRepo: customerWalletRepo,
+		walletTxdRepo:      walletTxdRepo,
+		walletLedgerRepo:   walletLedgerRepo,
+		bookingRepo:        bookingRepo,
+		showRepo:           showRepo,
+		skyCustomerRepo:    skyCustomerRepo,
+		movieService:       movieService,
+	}
+}
+
+// GetStatement builds the statement for the calendar days from..to, both inclusive.
+// Opening and closing balances come from the ledger journal.
+func (s *walletStatementService) GetStatement(ctx context.Context, username string, from, to time.Time) (*response.WalletStatement, error) {
+	if to.Before(from) {
+		return nil, utils.NewBadRequestError("INVALID_DATE_RANGE", "'from' must be on or before 'to'", nil)
+	}
+	if to.Sub(from) > maxWalletStatementDays*24*time.Hour {
+		return nil, utils.NewBadRequestError("INVALID_DATE_RANGE", "Statement period cannot be longer than one year", nil)
+	}
+
+	// Balances held before the ledger existed were carried into it as one opening entry when
+	// it was introduced, so earlier periods cannot be balanced against their transactions.
+	ledgerStart, err := s.walletLedgerRepo.GetLedgerStartTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ledgerStart != nil {
+		started := ledgerStart.In(from.Location())
+		firstDay := time.Date(started.Year(), started.Month(), started.Day(), 0, 0, 0, 0, from.Location())
+		if firstDay.Before(started) {
+			firstDay = firstDay.AddDate(0, 0, 1)
+		}
+		if from.Before(firstDay) {
+			return nil, utils.NewBadRequestError("STATEMENT_PERIOD_UNAVAILABLE",
+				fmt.Sprintf("Statements are available for periods starting on or after %s", firstDay.Format("2006-01-02")), nil)
+		}
+	}
+
+	wallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for user", nil)
+	}
+
+	periodEnd := to.AddDate(0, 0, 1)
+
+	openingBalance, err := s.walletLedgerRepo.GetWalletJournalBalanceAsOf(ctx, wallet.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	closingBalance, err := s.walletLedgerRepo.GetWalletJournalBalanceAsOf(ctx, wallet.ID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.walletTxdRepo.GetWalletTransactionsForUserBetween(ctx, username, from, periodEnd)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to fetch wallet transactions for statement")
+		return nil, err
+	}
+
+	customerName := username
+	if customer, err := s.skyCustomerRepo.FindByUsername(ctx, username); err == nil && customer != nil {
+		customerName = customer.Name
+	}
+
+	movieNames := make(map[int64]string)
+	totalCredits, totalDebits := decimal.Zero, decimal.Zero
+	lines := make([]response.WalletStatementLine, 0, len(transactions))
+
+	for _, t := range transactions {
+		amount, _ := t.Amount.Float64()
+		line := response.WalletStatementLine{
+			Timestamp:            t.Timestamp.Format(time.RFC3339),
+			TransactionType:      t.TransactionType,
+			TransactionID:        t.TransactionID,
+			BookingID:            t.BookingID,
+			CounterpartyUsername: t.CounterpartyUsername,
+			Note:                 t.Note,
+		}
+
+		if isWalletCreditType(t.TransactionType) {
+			line.Credit = amount
+			totalCredits, _ = totalCredits.Add(t.Amount)
+		} else {
+			line.Debit = amount
+			totalDebits, _ = totalDebits.Add(t.Amount)
+		}
+
+		if t.BookingID != nil {
+			name, cached := movieNames[*t.BookingID]
+			if !cached {
+				name = s.movieNameForBooking(ctx, *t.BookingID)
+				movieNames[*t.BookingID] = name
+			}
+			line.MovieName = name
+		}
+
+		lines = append(lines, line)
+	}
+
+	opening, _ := openingBalance.Float64()
+	closing, _ := closingBalance.Float64()
+	credits, _ := totalCredits.Float64()
+	debits, _ := totalDebits.Float64()
+
+	return &response.WalletStatement{
+		Username:       username,
+		CustomerName:   customerName,
+		From:           from.Format("2006-01-02"),
+		To:             to.Format("2006-01-02"),
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		TotalCredits:   credits,
+		TotalDebits:    debits,
+		Lines:          lines,
+		GeneratedAt:    time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *walletStatementService) GenerateStatementCSV(statement *response.WalletStatement) ([]byte, error) {
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+
+	rows := [][]string{
+		{"Statement For", statement.CustomerName},
+		{"Username", statement.Username},
+		{"Period", statement.From + " to " + statement.To},
+		{"Opening Balance", fmt.Sprintf("%.2f", statement.OpeningBalance)},
+		{},
+		{"Timestamp", "Type", "Transaction ID", "Booking ID", "Movie", "Counterparty", "Note", "Credit", "Debit"},
+	}
+
+	for _, line := range statement.Lines {
+		bookingID := ""
+		if line.BookingID != nil {
+			bookingID = fmt.Sprintf("%d", *line.BookingID)
+		}
+
+		rows = append(rows, []string{
+			line.Timestamp,
+			line.TransactionType,
+			line.TransactionID,
+			bookingID,
+			line.MovieName,
+			stringOrEmpty(line.CounterpartyUsername),
+			stringOrEmpty(line.Note),
+			formatStatementAmount(line.Credit),
+			formatStatementAmount(line.Debit),
+		})
+	}
+
+	rows = append(rows,
+		[]string{},
+		[]string{"Total Credits", fmt.Sprintf("%.2f", statement.TotalCredits)},
+		[]string{"Total Debits", fmt.Sprintf("%.2f", statement.TotalDebits)},
+		[]string{"Closing Balance", fmt.Sprintf("%.2f", statement.ClosingBalance)},
+	)
+
+	if err := csvWriter.WriteAll(rows); err != nil {
+		log.Error().Err(err).Str("username", statement.Username).Msg("Failed to write wallet statement CSV")
+		return nil, utils.NewInternalServerError("CSV_ERROR", "Failed to write wallet statement", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *walletStatementService) GenerateStatementPDF(statement *response.WalletStatement) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+
+	addFontsWithFallback(pdf)
+
+	pdf.SetMargins(10, 50, 10)
+	pdf.SetAutoPageBreak(true, 35)
+	pdf.SetHeaderFuncMode(func() {
+		addBrandHeader(pdf)
+	}, true)
+	pdf.SetFooterFunc(func() {
+		addBrandFooter(pdf, fmt.Sprintf("Wallet statement generated on %s. Page %d", statement.GeneratedAt, pdf.PageNo()))
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Poppins", "B", 18)
+	pdf.Cell(0, 10, "WALLET STATEMENT")
+	pdf.Ln(12)
+
+	lineHeight := 7.0
+
+	// Account summary on the light background used by the ticket layout
+	summaryTop := pdf.GetY()
+	pdf.SetFillColor(240, 240, 245) // background.secondary from theme
+	pdf.Rect(5, summaryTop-3, 200, 4*lineHeight+6, "F")
+
+	summary := [][2]string{
+		{"Customer:", statement.CustomerName + " (" + statement.Username + ")"},
+		{"Period:", statement.From + " to " + statement.To},
+		{"Opening Balance:", fmt.Sprintf("₹%.2f", statement.OpeningBalance)},
+		{"Closing Balance:", fmt.Sprintf("₹%.2f", statement.ClosingBalance)},
+	}
+	for _, row := range summary {
+		pdf.SetFont("Poppins", "B", 11)
+		pdf.SetTextColor(22, 26, 30) // text.primary
+		pdf.Cell(40, lineHeight, row[0])
+		pdf.SetFont("Poppins", "", 11)
+		pdf.SetTextColor(64, 67, 72) // text.secondary
+		pdf.Cell(0, lineHeight, row[1])
+		pdf.Ln(lineHeight)
+	}
+	pdf.Ln(8)
+
+	widths := []float64{32, 30, 78, 25, 25}
+	headers := []string{"Date", "Type", "Details", "Credit", "Debit"}
+
+	writeTableHeader := func() {
+		pdf.SetFont("Poppins", "B", 9)
+		pdf.SetFillColor(255, 177, 153) // secondary color
+		pdf.SetTextColor(22, 26, 30)
+		for i, header := range headers {
+			align := "L"
+			if i >= 3 {
+				align = "R"
+			}
+			pdf.CellFormat(widths[i], lineHeight, header, "", 0, align, true, 0, "")
+		}
+		pdf.Ln(lineHeight)
+	}
+	writeTableHeader()
+
+	pdf.SetFont("Poppins", "", 9)
+	pdf.SetTextColor(64, 67, 72)
+	for _, line := range statement.Lines {
+		if pdf.GetY()+lineHeight > 262 {
+			pdf.AddPage()
+			writeTableHeader()
+			pdf.SetFont("Poppins", "", 9)
+			pdf.SetTextColor(64, 67, 72)
+		}
+
+		date := line.Timestamp
+		if t, err := time.Parse(time.RFC3339, line.Timestamp); err == nil {
+			date = t.Format("2006-01-02 15:04")
+		}
+
+		details := statementLineDetails(line)
+		if runes := []rune(details); pdf.GetStringWidth(details) > widths[2]-2 && len(runes) > 45 {
+			details = string(runes[:42]) + "..."
+		}
+
+		pdf.CellFormat(widths[0], lineHeight, date, "B", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], lineHeight, line.TransactionType, "B", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], lineHeight, details, "B", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], lineHeight, formatStatementAmount(line.Credit), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], lineHeight, formatStatementAmount(line.Debit), "B", 0, "R", false, 0, "")
+		pdf.Ln(lineHeight)
+	}
+
+	if len(statement.Lines) == 0 {
+		pdf.SetFont("Poppins", "I", 10)
+		pdf.SetTextColor(142, 144, 145) // text.quaternary
+		pdf.CellFormat(0, lineHeight*2, "No wallet activity in this period", "", 1, "C", false, 0, "")
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont("Poppins", "B", 10)
+	pdf.SetTextColor(22, 26, 30)
+	totalsLabelWidth := widths[0] + widths[1] + widths[2]
+	pdf.CellFormat(totalsLabelWidth, lineHeight, "Totals", "", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[3], lineHeight, fmt.Sprintf("%.2f", statement.TotalCredits), "", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[4], lineHeight, fmt.Sprintf("%.2f", statement.TotalDebits), "", 0, "R", false, 0, "")
+	pdf.Ln(lineHeight)
+
+	pdf.SetTextColor(224, 75, 0) // primary color from theme
+	pdf.CellFormat(totalsLabelWidth, lineHeight, "Closing Balance", "", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[3]+widths[4], lineHeight, fmt.Sprintf("₹%.2f", statement.ClosingBalance), "", 0, "R", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, utils.NewInternalServerError("PDF_GENERATION_FAILED", "Failed to generate statement PDF", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *walletStatementService) movieNameForBooking(ctx context.Context, bookingID int64) string {
+	booking, err := s.bookingRepo.GetBookingById(ctx, int(bookingID))
+	if err != nil || booking == nil {
+		log.Warn().Err(err).Int64("bookingId", bookingID).Msg("Booking not found for wallet statement line")
+		return ""
+	}
+
+	show, err := s.showRepo.FindById(ctx, booking.ShowId)
+	if err != nil || show == nil {
+		log.Warn().Err(err).Int("showId", booking.ShowId).Msg("Show not found for wallet statement line")
+		return ""
+	}
+
+	movie, err := s.movieService.GetMovieById(ctx, show.MovieId)
+	if err != nil || movie == nil {
+		log.Warn().Err(err).Str("movieId", show.MovieId).Msg("Movie not found for wallet statement line")
+		return ""
+	}
+
+	return movie.Name
+}
+
+func isWalletCreditType(transactionType string) bool {
+	switch transactionType {
+	case "ADD", "TRANSFER_IN", "CREDIT", "REFUND":
+		return true
+	}
+	return false
+}
+
+func statementLineDetails(line response.WalletStatementLine) string {
+	switch {
+	case line.BookingID != nil && line.TransactionType == "REFUND":
+		return fmt.Sprintf("Refund of booking #%d", *line.BookingID)
+	case line.BookingID != nil && line.MovieName != "":
+		return fmt.Sprintf("Booking #%d - %s", *line.BookingID, line.MovieName)
+	case line.BookingID != nil:
+		return fmt.Sprintf("Booking #%d", *line.BookingID)
+	case line.CounterpartyUsername != nil && line.TransactionType == "TRANSFER_OUT":
+		return "To " + *line.CounterpartyUsername
+	case line.CounterpartyUsername != nil:
+		return "From " + *line.CounterpartyUsername
+	case line.Note != nil:
+		return *line.Note
+	}
+	return line.TransactionID
+}
+
+func formatStatementAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
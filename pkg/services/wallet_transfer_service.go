@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/govalues/decimal"
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/request"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// A transfer is created as PENDING and only moves money once the sender
+// confirms it within this window.
+const walletTransferConfirmationWindow = 5 * time.Minute
+
+type WalletTransferService interface {
+	InitiateTransfer(ctx context.Context, senderUsername string, req request.WalletTransferRequest) (*response.WalletTransferResponse, error)
+	ConfirmTransfer(ctx context.Context, senderUsername string, transferID int64) (*response.WalletTransferResponse, error)
+}
+
+type walletTransferService struct {
+	customerWalletRepo repositories.CustomerWalletRepository
+	walletLedgerRepo   repositories.WalletLedgerRepository
+	walletTransferRepo repositories.WalletTransferRepository
+}
+
+func NewWalletTransferService(
+	customerWalletRepo repositories.CustomerWalletRepository,
+	walletLedgerRepo repositories.WalletLedgerRepository,
+	walletTransferRepo repositories.WalletTransferRepository,
+) WalletTransferService {
+	return &walletTransferService{
+		customerWalletRepo: customerWalletRepo,
+		walletLedgerRepo:   walletLedgerRepo,
+		walletTransferRepo: walletTransferRepo,
+	}
+}
+
+func (s *walletTransferService) InitiateTransfer(ctx context.Context, senderUsername string, req request.WalletTransferRequest) (*response.WalletTransferResponse, error) {
+	if req.RecipientUsername == senderUsername {
+		return nil, utils.NewBadRequestError("INVALID_RECIPIENT", "You cannot transfer funds to your own wallet", nil)
+	}
+
+	if err := validateTransferAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	senderWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, senderUsername)
+	if err != nil {
+		log.Error().Err(err).Str("username", senderUsername).Msg("Failed to retrieve sender wallet")
+		return nil, err
+	}
+	if senderWallet == nil {
+		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for user", nil)
+	}
+
+	recipientWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, req.RecipientUsername)
+	if err != nil {
+		log.Error().Err(err).Str("recipient", req.RecipientUsername).Msg("Failed to retrieve recipient wallet")
+		return nil, err
+	}
+	if recipientWallet == nil {
+		return nil, utils.NewNotFoundError("RECIPIENT_NOT_FOUND", "No wallet found for the recipient username", nil)
+	}
+
+	if senderWallet.Balance.Cmp(req.Amount) < 0 {
+		return nil, utils.NewBadRequestError("INSUFFICIENT_BALANCE", "Insufficient wallet balance for this transfer", nil)
+	}
+
+	if err := s.checkDailyLimit(ctx, senderUsername, req.Amount); err != nil {
+		return nil, err
+	}
+
+	transfer := &models.WalletTransfer{
+		Reference:         uuid.New().String(),
+		SenderUsername:    senderUsername,
+		RecipientUsername: req.RecipientUsername,
+		Amount:            req.Amount,
+		Status:            "PENDING",
+		ExpiresAt:         time.Now().Add(walletTransferConfirmationWindow),
+	}
+	if req.Note != "" {
+		transfer.Note = &req.Note
+	}
+
+	if err := s.walletTransferRepo.Create(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	balance, _ := senderWallet.Balance.Float64()
+	return toWalletTransferResponse(transfer, balance), nil
+}
+
+func (s *walletTransferService) ConfirmTransfer(ctx context.Context, senderUsername string, transferID int64) (*response.WalletTransferResponse, error) {
+	transfer, err := s.walletTransferRepo.FindByIdAndSender(ctx, transferID, senderUsername)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, utils.NewNotFoundError("TRANSFER_NOT_FOUND", "Transfer not found", nil)
+	}
+
+	if transfer.Status != "PENDING" {
+		return nil, utils.NewBadRequestError("INVALID_TRANSFER_STATUS", "Transfer is not awaiting confirmation", nil)
+	}
+
+	if time.Now().After(transfer.ExpiresAt) {
+		if err := s.walletTransferRepo.UpdateStatus(ctx, transfer.ID, "EXPIRED"); err != nil {
+			log.Error().Err(err).Int64("transferId", transfer.ID).Msg("Failed to mark transfer as expired")
+		}
+		return nil, utils.NewBadRequestError("TRANSFER_EXPIRED", "Transfer confirmation window has expired, please start a new transfer", nil)
+	}
+
+	senderWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, senderUsername)
+	if err != nil {
+		return nil, err
+	}
+	recipientWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, transfer.RecipientUsername)
+	if err != nil {
+		return nil, err
+	}
+	if senderWallet == nil || recipientWallet == nil {
+		return nil, utils.NewNotFoundError("WALLET_NOT_FOUND", "Wallet not found for transfer participant", nil)
+	}
+
+	// The daily limit is checked again while posting, under a lock on the sender's wallet, since
+	// other transfers may have completed since this one was initiated.
+	entry := newTransferEntry(senderWallet.ID, recipientWallet.ID, transfer.Reference, senderUsername, transfer.RecipientUsername, transfer.Note, transfer.Amount)
+	dailyLimit, _ := decimal.NewFromFloat64(constants.MAX_DAILY_WALLET_TRANSFER)
+	if err := s.walletLedgerRepo.PostTransfer(ctx, transfer.ID, senderWallet.ID, senderUsername, startOfToday(), dailyLimit, entry); err != nil {
+		log.Error().Err(err).Int64("transferId", transfer.ID).Str("sender", senderUsername).Msg("Failed to post wallet transfer")
+		return nil, err
+	}
+
+	updatedWallet, err := s.customerWalletRepo.GetWalletByUsername(ctx, senderUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	completedAt := time.Now()
+	transfer.Status = "COMPLETED"
+	transfer.CompletedAt = &completedAt
+
+	balance, _ := updatedWallet.Balance.Float64()
+	return toWalletTransferResponse(transfer, balance), nil
+}
+
+func (s *walletTransferService) checkDailyLimit(ctx context.Context, senderUsername string, amount decimal.Decimal) error {
+	sentToday, err := s.walletTransferRepo.GetCompletedTotalSince(ctx, senderUsername, startOfToday())
+	if err != nil {
+		return err
+	}
+
+	total, err := sentToday.Add(amount)
+	if err != nil {
+		return utils.NewBadRequestError("INVALID_AMOUNT", "Invalid transfer amount", err)
+	}
+
+	dailyLimit, _ := decimal.NewFromFloat64(constants.MAX_DAILY_WALLET_TRANSFER)
+	if total.Cmp(dailyLimit) > 0 {
+		return utils.NewBadRequestError("DAILY_TRANSFER_LIMIT_EXCEEDED", "This transfer would exceed the daily limit of 10000", nil)
+	}
+
+	return nil
+}
+
+func startOfToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+func validateTransferAmount(amount decimal.Decimal) error {
+	minAmount, _ := decimal.NewFromFloat64(constants.MIN_WALLET_TRANSFER_AMOUNT)
+	maxAmount, _ := decimal.NewFromFloat64(constants.MAX_WALLET_TRANSFER_AMOUNT)
+
+	if amount.Cmp(minAmount) < 0 {
+		return utils.NewBadRequestError("INVALID_AMOUNT", "Minimum transfer amount is 1", nil)
+	}
+	if amount.Cmp(maxAmount) > 0 {
+		return utils.NewBadRequestError("AMOUNT_TOO_LARGE", "Maximum transfer amount is 5000", nil)
+	}
+
+	return nil
+}
+
+func toWalletTransferResponse(transfer *models.WalletTransfer, balance float64) *response.WalletTransferResponse {
+	amount, _ := transfer.Amount.Float64()
+
+	resp := &response.WalletTransferResponse{
+		TransferID:        transfer.ID,
+		Reference:         transfer.Reference,
+		RecipientUsername: transfer.RecipientUsername,
+		Amount:            amount,
+		Note:              transfer.Note,
+		Status:            transfer.Status,
+		ExpiresAt:         transfer.ExpiresAt.Format(time.RFC3339),
+		Balance:           balance,
+	}
+	if transfer.CompletedAt != nil {
+		resp.CompletedAt = transfer.CompletedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// WatchlistAlertMessage announces a new show to every customer watching its movie.
+type WatchlistAlertMessage struct {
+	MovieId   string   `json:"movie_id"`
+	MovieName string   `json:"movie_name"`
+	ShowId    int      `json:"show_id"`
+	ShowDate  string   `json:"show_date"`
+	SlotName  string   `json:"slot_name"`
+	StartTime string   `json:"start_time"`
+	Usernames []string `json:"usernames"`
+}
+
+// WatchlistNotifier delivers new-show alerts outside the app. A push or email provider only
+// needs to implement this interface and be selected in NewWatchlistNotifier.
+type WatchlistNotifier interface {
+	Name() string
+	Notify(ctx context.Context, message WatchlistAlertMessage) error
+}
+
+func NewWatchlistNotifier(cfg config.WatchlistConfig) WatchlistNotifier {
+	switch cfg.Notifier {
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			log.Warn().Msg("WATCHLIST_WEBHOOK_URL is not set, falling back to logging watchlist alerts")
+			return &logWatchlistNotifier{}
+		}
+		return &webhookWatchlistNotifier{url: cfg.WebhookURL, client: &http.Client{Timeout: 10 * time.Second}}
+	case "log", "":
+		return &logWatchlistNotifier{}
+	default:
+		log.Warn().Str("notifier", cfg.Notifier).Msg("Unknown WATCHLIST_NOTIFIER, falling back to logging watchlist alerts")
+		return &logWatchlistNotifier{}
+	}
+}
+
+// logWatchlistNotifier only writes the alert to the application log. Customers still see
+// it in their in-app alerts.
+type logWatchlistNotifier struct{}
+
+func (n *logWatchlistNotifier) Name() string {
+	return "log"
+}
+
+func (n *logWatchlistNotifier) Notify(ctx context.Context, message WatchlistAlertMessage) error {
+	log.Info().
+		Str("movieId", message.MovieId).
+		Str("movieName", message.MovieName).
+		Int("showId", message.ShowId).
+		Str("showDate", message.ShowDate).
+		Str("slot", message.SlotName).
+		Int("recipients", len(message.Usernames)).
+		Msg("Watchlist alert issued")
+	return nil
+}
+
+// webhookWatchlistNotifier posts the message as JSON to a push or email gateway.
+type webhookWatchlistNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookWatchlistNotifier) Name() string {
+	return "webhook"
+}
+
+func (n *webhookWatchlistNotifier) Notify(ctx context.Context, message WatchlistAlertMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return utils.NewInternalServerError("REQUEST_PREPARATION_FAILED", "Failed to prepare watchlist alert", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return utils.NewInternalServerError("REQUEST_CREATION_FAILED", "Failed to create watchlist alert request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return utils.NewInternalServerError("WATCHLIST_ALERT_DELIVERY_FAILED", "Failed to reach watchlist webhook", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return utils.NewInternalServerError("WATCHLIST_ALERT_DELIVERY_FAILED", fmt.Sprintf("Watchlist webhook responded with status %d", resp.StatusCode), nil)
+	}
+
+	return nil
+}
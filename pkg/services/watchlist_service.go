@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/constants"
+	"github.com/iamsuteerth/skyfox-backend/pkg/dto/response"
+	"github.com/iamsuteerth/skyfox-backend/pkg/models"
+	movieservice "github.com/iamsuteerth/skyfox-backend/pkg/movie-service"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+	"github.com/iamsuteerth/skyfox-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type WatchlistService interface {
+	GetWatchlist(ctx context.Context, username string) (*response.WatchlistResponse, error)
+	AddMovie(ctx context.Context, username, movieID string) (*response.WatchlistMovieResponse, error)
+	RemoveMovie(ctx context.Context, username, movieID string) error
+	GetFeed(ctx context.Context, username string) (*response.WatchlistFeedResponse, error)
+	GetAlerts(ctx context.Context, username string) (*response.WatchlistAlertsResponse, error)
+	MarkAlertsRead(ctx context.Context, username string) (*response.MarkWatchlistAlertsReadResponse, error)
+	// NotifyNewShow records a pending alert for everyone watching the show's movie. It is called
+	// after a show has been created; DeliverPendingAlerts hands the alerts to the notifier.
+	NotifyNewShow(ctx context.Context, show *models.Show) error
+	// DeliverPendingAlerts sends due alerts to the notifier, one message per show, and returns
+	// how many were delivered. Failed deliveries are retried later with a growing delay.
+	DeliverPendingAlerts(ctx context.Context) (int, error)
+}
+
+type watchlistService struct {
+	watchlistRepo repositories.WatchlistRepository
+	movieService  movieservice.MovieService
+	notifier      WatchlistNotifier
+}
+
+func NewWatchlistService(watchlistRepo repositories.WatchlistRepository, movieService movieservice.MovieService, notifier WatchlistNotifier) WatchlistService {
+	return &watchlistService{
+		watchlistRepo: watchlistRepo,
+		movieService:  movieService,
+		notifier:      notifier,
+	}
+}
+
+func (s *watchlistService) GetWatchlist(ctx context.Context, username string) (*response.WatchlistResponse, error) {
+	items, err := s.watchlistRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	movies := s.movieLookup(ctx, len(items))
+
+	watchlist := &response.WatchlistResponse{Movies: make([]response.WatchlistMovieResponse, 0, len(items))}
+	for _, item := range items {
+		entry := response.WatchlistMovieResponse{
+			MovieId: item.MovieId,
+			AddedAt: item.CreatedAt.Format(time.RFC3339),
+		}
+		if movie, ok := movies[item.MovieId]; ok {
+			entry.Name = movie.Name
+			entry.MoviePoster = movie.MoviePoster
+		}
+		watchlist.Movies = append(watchlist.Movies, entry)
+	}
+
+	return watchlist, nil
+}
+
+func (s *watchlistService) AddMovie(ctx context.Context, username, movieID string) (*response.WatchlistMovieResponse, error) {
+	count, err := s.watchlistRepo.CountByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= constants.MAX_WATCHLIST_MOVIES_PER_CUSTOMER {
+		return nil, utils.NewBadRequestError("WATCHLIST_LIMIT_REACHED", fmt.Sprintf("A maximum of %d movies can be on a watchlist", constants.MAX_WATCHLIST_MOVIES_PER_CUSTOMER), nil)
+	}
+
+	movie, err := s.movieService.GetMovieById(ctx, movieID)
+	if err != nil {
+		log.Error().Err(err).Str("movieId", movieID).Msg("Error verifying movie existence")
+		return nil, utils.NewBadRequestError("INVALID_MOVIE", "The selected movie does not exist", err)
+	}
+
+	if movie == nil {
+		return nil, utils.NewBadRequestError("INVALID_MOVIE", "The selected movie does not exist", nil)
+	}
+
+	item := &models.WatchlistItem{
+		Username: username,
+		MovieId:  movie.MovieId,
+	}
+
+	if err := s.watchlistRepo.Add(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return &response.WatchlistMovieResponse{
+		MovieId:     item.MovieId,
+		Name:        movie.Name,
+		MoviePoster: movie.MoviePoster,
+		AddedAt:     item.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *watchlistService) RemoveMovie(ctx context.Context, username, movieID string) error {
+	removed, err := s.watchlistRepo.Remove(ctx, username, movieID)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return utils.NewNotFoundError("NOT_IN_WATCHLIST", "This movie is not on your watchlist", nil)
+	}
+
+	return nil
+}
+
+func (s *watchlistService) GetFeed(ctx context.Context, username string) (*response.WatchlistFeedResponse, error) {
+	shows, err := s.watchlistRepo.GetUpcomingShows(ctx, username, constants.WATCHLIST_FEED_LIMIT)
+	if err != nil {
+		return nil, err
+	}
+
+	movies := s.movieLookup(ctx, len(shows))
+
+	feed := &response.WatchlistFeedResponse{Shows: make([]response.WatchlistShowResponse, 0, len(shows))}
+	for _, show := range shows {
+		cost, _ := show.Cost.Float64()
+		entry := response.WatchlistShowResponse{
+			ShowId:  show.Id,
+			MovieId: show.MovieId,
+			Date:    show.Date.Format("2006-01-02"),
+			Slot:    show.Slot,
+			Cost:    cost,
+		}
+		if movie, ok := movies[show.MovieId]; ok {
+			entry.MovieName = movie.Name
+		}
+		feed.Shows = append(feed.Shows, entry)
+	}
+
+	return feed, nil
+}
+
+func (s *watchlistService) GetAlerts(ctx context.Context, username string) (*response.WatchlistAlertsResponse, error) {
+	alerts, err := s.watchlistRepo.GetAlerts(ctx, username, constants.WATCHLIST_ALERTS_LIMIT)
+	if err != nil {
+		return nil, err
+	}
+
+	movies := s.movieLookup(ctx, len(alerts))
+
+	result := &response.WatchlistAlertsResponse{Alerts: make([]response.WatchlistAlertResponse, 0, len(alerts))}
+	for _, alert := range alerts {
+		entry := response.WatchlistAlertResponse{
+			ID:        alert.ID,
+			MovieId:   alert.MovieId,
+			ShowId:    alert.ShowId,
+			ShowDate:  alert.ShowDate.Format("2006-01-02"),
+			Slot:      alert.Slot,
+			CreatedAt: alert.CreatedAt.Format(time.RFC3339),
+			Read:      alert.ReadAt != nil,
+		}
+		if movie, ok := movies[alert.MovieId]; ok {
+			entry.MovieName = movie.Name
+		}
+		if !entry.Read {
+			result.UnreadCount++
+		}
+		result.Alerts = append(result.Alerts, entry)
+	}
+
+	return result, nil
+}
+
+func (s *watchlistService) MarkAlertsRead(ctx context.Context, username string) (*response.MarkWatchlistAlertsReadResponse, error) {
+	marked, err := s.watchlistRepo.MarkAlertsRead(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return &response.MarkWatchlistAlertsReadResponse{MarkedRead: marked}, nil
+}
+
+func (s *watchlistService) NotifyNewShow(ctx context.Context, show *models.Show) error {
+	created, err := s.watchlistRepo.CreateAlertsForShow(ctx, show)
+	if err != nil {
+		return err
+	}
+
+	if created > 0 {
+		log.Info().Int("showId", show.Id).Int64("recipients", created).Msg("Watchlist alerts queued for new show")
+	}
+	return nil
+}
+
+func (s *watchlistService) DeliverPendingAlerts(ctx context.Context) (int, error) {
+	alerts, err := s.watchlistRepo.ClaimPendingAlerts(ctx, constants.WATCHLIST_ALERT_MAX_DELIVERY_ATTEMPTS,
+		constants.WATCHLIST_ALERT_DELIVERY_BATCH_SIZE, constants.WATCHLIST_ALERT_DELIVERY_LEASE)
+	if err != nil {
+		return 0, err
+	}
+	if len(alerts) == 0 {
+		return 0, nil
+	}
+
+	movies := s.movieLookup(ctx, len(alerts))
+
+	// Claimed alerts are ordered by show, so each run of the same show becomes one message.
+	delivered := 0
+	for start := 0; start < len(alerts); {
+		end := start
+		for end < len(alerts) && alerts[end].ShowId == alerts[start].ShowId {
+			end++
+		}
+		batch := alerts[start:end]
+		start = end
+
+		first := batch[0]
+		message := WatchlistAlertMessage{
+			MovieId:   first.MovieId,
+			ShowId:    first.ShowId,
+			ShowDate:  first.ShowDate.Format("2006-01-02"),
+			SlotName:  first.Slot.Name,
+			StartTime: first.Slot.StartTime,
+			Usernames: make([]string, 0, len(batch)),
+		}
+		if movie, ok := movies[first.MovieId]; ok {
+			message.MovieName = movie.Name
+		}
+
+		ids := make([]int64, 0, len(batch))
+		for _, alert := range batch {
+			message.Usernames = append(message.Usernames, alert.Username)
+			ids = append(ids, alert.ID)
+		}
+
+		if err := s.notifier.Notify(ctx, message); err != nil {
+			log.Error().Err(err).Str("notifier", s.notifier.Name()).Int("showId", first.ShowId).
+				Int("attempt", first.DeliveryAttempts).Msg("Failed to deliver watchlist alerts")
+			if first.DeliveryAttempts >= constants.WATCHLIST_ALERT_MAX_DELIVERY_ATTEMPTS {
+				log.Warn().Int("showId", first.ShowId).Int("recipients", len(ids)).Msg("Giving up on watchlist alerts after the last delivery attempt")
+				continue
+			}
+			if err := s.watchlistRepo.RescheduleAlerts(ctx, ids); err != nil {
+				log.Error().Err(err).Int("showId", first.ShowId).Msg("Failed to reschedule watchlist alerts")
+			}
+			continue
+		}
+
+		if err := s.watchlistRepo.MarkAlertsDelivered(ctx, ids); err != nil {
+			return delivered, err
+		}
+		delivered += len(ids)
+		log.Info().Int("showId", first.ShowId).Int("recipients", len(ids)).Msg("Watchlist alerts sent for new show")
+	}
+
+	return delivered, nil
+}
+
+// StartWatchlistAlertWorker delivers pending watchlist alerts on every tick until ctx is done.
+func StartWatchlistAlertWorker(ctx context.Context, watchlistService WatchlistService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := watchlistService.DeliverPendingAlerts(ctx); err != nil {
+					log.Error().Err(err).Msg("Watchlist alert delivery sweep failed")
+				}
+			}
+		}
+	}()
+}
+
+// movieLookup fetches movie details for display. Names are cosmetic, so a movie service
+// failure is logged and an empty lookup returned rather than failing the request.
+func (s *watchlistService) movieLookup(ctx context.Context, needed int) map[string]*models.Movie {
+	lookup := map[string]*models.Movie{}
+	if needed == 0 {
+		return lookup
+	}
+
+	movies, err := s.movieService.GetAllMovies(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch movie details for watchlist")
+		return lookup
+	}
+
+	for _, movie := range movies {
+		if movie != nil {
+			lookup[movie.MovieId] = movie
+		}
+	}
+	return lookup
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed breached_passwords.txt
+var breachedPasswordList string
+
+var breachedPasswords = parseBreachedPasswords(breachedPasswordList)
+
+func parseBreachedPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}
+
+// IsBreachedPassword reports whether password appears in the bundled list of passwords known
+// from public breaches. The check ignores case, so "Password@123" matches "password@123".
+func IsBreachedPassword(password string) bool {
+	_, found := breachedPasswords[strings.ToLower(password)]
+	return found
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeCursor builds an opaque keyset cursor from the sort time and id of the last item on
+// a page. The next page starts strictly after that item.
+func EncodeCursor(t time.Time, id int64) string {
+	raw := strconv.FormatInt(t.UnixNano(), 10) + ":" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("cursor is not valid base64: %w", err)
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, fmt.Errorf("cursor is malformed")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("cursor time is malformed: %w", err)
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("cursor id is malformed: %w", err)
+	}
+
+	return time.Unix(0, nanos).UTC(), id, nil
+}
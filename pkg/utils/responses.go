@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -46,6 +50,40 @@ func SendOKResponse(ctx *gin.Context, message string, requestID string, data int
 	sendSuccessResponse(ctx, http.StatusOK, message, requestID, data)
 }
 
+// SendCacheableOKResponse lets clients and shared caches keep the response for maxAge. The
+// ETag is weak because it only covers data, not the request ID in the envelope, and a request
+// whose If-None-Match still matches gets 304 Not Modified without a body.
+func SendCacheableOKResponse(ctx *gin.Context, message string, requestID string, data interface{}, maxAge time.Duration) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		HandleErrorResponse(ctx, NewInternalServerError("RESPONSE_ENCODING_FAILED", "Failed to encode response", err), requestID)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:16]))
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	sendSuccessResponse(ctx, http.StatusOK, message, requestID, data)
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func sendSuccessResponse(ctx *gin.Context, statusCode int, message string, requestID string, data interface{}) {
 	ctx.JSON(statusCode, SuccessResponse{
 		Message:   message,
@@ -158,15 +196,15 @@ func GetRequestID(ctx *gin.Context) string {
 
 func getValidationErrorMessage(tag string, param string) string {
 	messages := map[string]string{
-		"required":       "This field is required",
-		"email":          "Invalid email format",
-		"min":            "Must be at least %s characters",
-		"max":            "Must be at most %s characters",
-		"customName":     "Name must be 3-70 characters, max 4 words, letters only, no consecutive spaces",
-		"customUsername": "Username must be 3-30 characters, lowercase, no spaces, cannot start with a number, no consecutive special characters",
-		"customPhone":    "Phone number must be exactly 10 digits",
-		"customPassword": "Password must be at least 8 characters with at least one uppercase letter and one special character",
-		"securityAnswer": "Security answer must be at least 3 characters long",
+		"required":         "This field is required",
+		"required_without": "This field is required when %s is not provided",
+		"email":            "Invalid email format",
+		"min":              "Must be at least %s characters",
+		"max":              "Must be at most %s characters",
+		"customName":       "Name must be 3-70 characters, max 4 words, letters only, no consecutive spaces",
+		"customUsername":   "Username must be 3-30 characters, lowercase, no spaces, cannot start with a number, no consecutive special characters",
+		"customPhone":      "Phone number must be exactly 10 digits",
+		"securityAnswer":   "Security answer must be at least 3 characters long",
 	}
 
 	if msg, exists := messages[tag]; exists {
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedSecretPrefix marks values written by SecretCipher. Values without it were stored
+// before encryption was introduced and are plaintext.
+const encryptedSecretPrefix = "v1:"
+
+// SecretCipher encrypts secrets that must be read back, such as TOTP secrets, with AES-256-GCM
+// under a server-side key, so a database dump alone does not reveal them.
+type SecretCipher struct {
+	aead cipher.AEAD
+}
+
+// NewSecretCipher takes a base64 encoded 32 byte key.
+func NewSecretCipher(encodedKey string) (*SecretCipher, error) {
+	if encodedKey == "" {
+		return nil, errors.New("encryption key is not set")
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretCipher{aead: aead}, nil
+}
+
+func (c *SecretCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedSecretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a value from Encrypt. A value stored before encryption was
+// introduced is returned as is, with encrypted set to false so the caller can re-encrypt it.
+func (c *SecretCipher) Decrypt(value string) (plaintext string, encrypted bool, err error) {
+	encoded, ok := strings.CutPrefix(value, encryptedSecretPrefix)
+	if !ok {
+		return value, false, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", true, fmt.Errorf("encrypted secret is not valid base64: %w", err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", true, errors.New("encrypted secret is too short")
+	}
+
+	opened, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(opened), true, nil
+}
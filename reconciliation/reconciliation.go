@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/iamsuteerth/skyfox-backend/pkg/config"
+	"github.com/iamsuteerth/skyfox-backend/pkg/repositories"
+)
+
+func main() {
+	var fix bool
+	flag.BoolVar(&fix, "fix", false, "Reset mismatched cached balances to the journal-derived balance")
+	flag.Parse()
+
+	if err := godotenv.Load(".env"); err != nil {
+		fmt.Println("Warning: .env file not found")
+	}
+
+	db := config.GetDBConnection()
+	defer config.CloseDBConnection()
+
+	ctx := context.Background()
+	ledgerRepository := repositories.NewWalletLedgerRepository(db)
+
+	mismatches, err := ledgerRepository.FindMismatchedWallets(ctx)
+	if err != nil {
+		fmt.Printf("Error reconciling wallets: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(mismatches) == 0 {
+		fmt.Println("All wallet balances agree with the journal")
+		return
+	}
+
+	fmt.Printf("%-10s %-30s %15s %15s\n", "WALLET_ID", "USERNAME", "CACHED", "JOURNAL")
+	for _, m := range mismatches {
+		fmt.Printf("%-10d %-30s %15s %15s\n", m.WalletID, m.Username, m.CachedBalance.String(), m.JournalBalance.String())
+	}
+	fmt.Printf("%d wallet(s) disagree with the journal\n", len(mismatches))
+
+	if !fix {
+		os.Exit(2)
+	}
+
+	for _, m := range mismatches {
+		if err := ledgerRepository.ResyncCachedBalance(ctx, m.WalletID); err != nil {
+			fmt.Printf("Error resyncing wallet %d: %v\n", m.WalletID, err)
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("Reset %d cached balance(s) to the journal balance\n", len(mismatches))
+}
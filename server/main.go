@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 
@@ -41,7 +42,11 @@ func main() {
 	movieService := movieservice.NewMovieService(movieServiceConfig)
 	paymentServiceConfig := config.GetPaymentServiceConfig()
 	paymentService := paymentservice.NewPaymentService(paymentServiceConfig)
-	s3Service := services.NewS3Service()
+	blobStorage, err := services.NewBlobStorage(config.GetStorageConfig())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure file storage")
+	}
+	storageService := services.NewStorageService(blobStorage, services.NewNoopImageModerator())
 
 	userRepository := repositories.NewUserRepository(db)
 	staffRepository := repositories.NewStaffRepository(db)
@@ -57,26 +62,66 @@ func main() {
 	paymentTransactionRepository := repositories.NewPaymentTransactionRepository(db)
 	customerWalletRepository := repositories.NewCustomerWalletRepository(db)
 	walletTxdRepository := repositories.NewWalletTransactionRepository(db)
+	savedCardRepository := repositories.NewSavedCardRepository(db)
+	walletLedgerRepository := repositories.NewWalletLedgerRepository(db)
+	walletTransferRepository := repositories.NewWalletTransferRepository(db)
+	loyaltyRepository := repositories.NewLoyaltyRepository(db)
+	reportingRepository := repositories.NewReportingRepository(db)
+	reportRepository := repositories.NewReportRepository(db)
+	bookingAuditRepository := repositories.NewBookingAuditRepository(db)
+	seatEventRepository := repositories.NewSeatEventRepository(db)
+	contactVerificationRepository := repositories.NewContactVerificationRepository(db)
+	mfaRepository := repositories.NewMFARepository(db)
+	watchlistRepository := repositories.NewWatchlistRepository(db)
+	movieReviewRepository := repositories.NewMovieReviewRepository(db)
+
+	seatEventBroker := services.NewSeatEventBroker(config.GetSeatEventsConfig(), seatEventRepository)
+
+	mfaSecretCipher, err := utils.NewSecretCipher(config.GetMFAConfig().SecretKey)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid MFA_SECRET_KEY")
+	}
+	mfaService := services.NewMFAService(mfaRepository, userRepository, mfaSecretCipher)
+	passwordPolicyService := services.NewPasswordPolicyService(userRepository, config.GetPasswordPolicyConfig())
 
-	seed.SeedDB(userRepository, staffRepository)
+	seed.SeedDB(userRepository, staffRepository, passwordPolicyService)
 
-	userService := services.NewUserService(userRepository)
-	skyCustomerService := services.NewSkyCustomerService(skyCustomerRepository, userRepository, securityQuestionRepository, customerWalletRepository, s3Service)
+	userService := services.NewUserService(userRepository, mfaService, passwordPolicyService)
+	verificationConfig := config.GetVerificationConfig()
+	contactVerificationService := services.NewContactVerificationService(skyCustomerRepository, contactVerificationRepository, services.NewVerificationSender(verificationConfig), verificationConfig)
+	watchlistService := services.NewWatchlistService(watchlistRepository, movieService, services.NewWatchlistNotifier(config.GetWatchlistConfig()))
+	movieReviewService := services.NewMovieReviewService(movieReviewRepository)
+	skyCustomerService := services.NewSkyCustomerService(skyCustomerRepository, userRepository, securityQuestionRepository, storageService, watchlistService)
 	securityQuestionService := services.NewSecurityQuestionService(securityQuestionRepository, skyCustomerRepository, resetTokenRepository)
-	passwordResetService := services.NewPasswordResetService(resetTokenRepository, skyCustomerRepository, userRepository)
-	showService := services.NewShowService(showRepository, bookingRepository, movieService, slotRepository)
+	passwordResetService := services.NewPasswordResetService(resetTokenRepository, skyCustomerRepository, userRepository, passwordPolicyService)
+	showService := services.NewShowService(showRepository, bookingRepository, movieService, slotRepository, watchlistService, movieReviewService)
 	slotService := services.NewSlotService(slotRepository)
 	adminStaffProfileService := services.NewAdminStaffProfileService(userRepository, staffRepository)
 	bookingService := services.NewBookingService(showRepository, bookingRepository, bookingSeatMappingRepository, slotRepository, adminBookedCustomerRepository, skyCustomerRepository, movieService)
-	adminBookingService := services.NewAdminBookingService(showRepository, bookingRepository, bookingSeatMappingRepository, adminBookedCustomerRepository, slotRepository)
-	customerBookingService := services.NewCustomerBookingService(showRepository, bookingRepository, bookingSeatMappingRepository, pendingBookingRepository, paymentTransactionRepository, slotRepository, skyCustomerRepository, customerWalletRepository, walletTxdRepository, paymentService)
-	checkInService := services.NewCheckInService(bookingRepository, showRepository)
-	revenueService := services.NewRevenueService(bookingRepository, showRepository, slotRepository, movieService)
-	bookingCSVService := services.NewBookingCSVService(bookingRepository, showRepository, adminBookedCustomerRepository, skyCustomerRepository)
-	walletService := services.NewWalletService(customerWalletRepository, walletTxdRepository, paymentTransactionRepository, paymentService)
+	loyaltyService := services.NewLoyaltyService(loyaltyRepository, bookingAuditRepository, config.GetLoyaltyConfig(), bookingRepository)
+	adminBookingService := services.NewAdminBookingService(showRepository, bookingRepository, bookingSeatMappingRepository, adminBookedCustomerRepository, slotRepository, bookingAuditRepository, seatEventBroker, customerWalletRepository, walletLedgerRepository, loyaltyService)
+	customerBookingService := services.NewCustomerBookingService(showRepository, bookingRepository, bookingSeatMappingRepository, pendingBookingRepository, paymentTransactionRepository, slotRepository, skyCustomerRepository, customerWalletRepository, walletLedgerRepository, savedCardRepository, paymentService, loyaltyService, movieService, bookingAuditRepository, seatEventBroker, contactVerificationService)
+	checkInService := services.NewCheckInService(bookingRepository, showRepository, bookingAuditRepository, movieService, bookingSeatMappingRepository, seatEventBroker)
+	customerPrivacyService := services.NewCustomerPrivacyService(skyCustomerRepository, userRepository, customerWalletRepository, walletTxdRepository, bookingRepository, storageService, movieService, watchlistRepository, movieReviewRepository)
+	bookingConsoleService := services.NewBookingConsoleService(bookingRepository, paymentTransactionRepository, walletTxdRepository, bookingAuditRepository, movieService)
+	revenueService := services.NewRevenueService(reportingRepository, movieService)
+	occupancyService := services.NewOccupancyService(reportingRepository, movieService)
+	bookingCSVService := services.NewBookingCSVService(bookingRepository, movieService)
+	walletService := services.NewWalletService(customerWalletRepository, walletTxdRepository, walletLedgerRepository, paymentTransactionRepository, savedCardRepository, paymentService, movieService, contactVerificationService)
+	walletTransferService := services.NewWalletTransferService(customerWalletRepository, walletLedgerRepository, walletTransferRepository)
+	walletStatementService := services.NewWalletStatementService(customerWalletRepository, walletTxdRepository, walletLedgerRepository, bookingRepository, showRepository, skyCustomerRepository, movieService)
+	savedCardService := services.NewSavedCardService(savedCardRepository, paymentService)
+	publicListingService := services.NewPublicListingService(showRepository, movieService, movieReviewService)
+	reportService := services.NewReportService(reportRepository, reportingRepository, bookingRepository, bookingCSVService, movieService, storageService, services.NewReportSender(config.GetReportConfig()))
+
+	services.StartWalletCreditExpiryWorker(context.Background(), walletService, constants.WALLET_CREDIT_EXPIRY_SWEEP_INTERVAL)
+	services.StartReportSchedulerWorker(context.Background(), reportService, constants.REPORT_SCHEDULER_INTERVAL)
+	services.StartSeatEventListener(context.Background(), seatEventBroker)
+	services.StartProfileImageCleanupWorker(context.Background(), skyCustomerService, constants.PROFILE_IMAGE_CLEANUP_INTERVAL)
+	services.StartWatchlistAlertWorker(context.Background(), watchlistService, constants.WATCHLIST_ALERT_DELIVERY_INTERVAL)
 
 	authController := controllers.NewAuthController(userService)
-	skyCustomerController := controllers.NewSkyCustomerController(userService, skyCustomerService, securityQuestionService)
+	skyCustomerController := controllers.NewSkyCustomerController(userService, skyCustomerService, securityQuestionService, passwordPolicyService)
 	securityQuestionController := controllers.NewSecurityQuestionController(securityQuestionService)
 	passwordResetController := controllers.NewPasswordResetController(passwordResetService, skyCustomerService)
 	showController := controllers.NewShowController(showService)
@@ -84,7 +129,20 @@ func main() {
 	adminStaffController := controllers.NewAdminStaffController(adminStaffProfileService)
 	bookingController := controllers.NewBookingController(bookingService, adminBookingService, customerBookingService, checkInService, bookingCSVService)
 	revenueController := controllers.NewDashboardRevenueController(revenueService)
-	walletController := controllers.NewWalletController(walletService)
+	occupancyController := controllers.NewDashboardOccupancyController(occupancyService)
+	walletController := controllers.NewWalletController(walletService, walletTransferService, walletStatementService)
+	savedCardController := controllers.NewSavedCardController(savedCardService)
+	loyaltyController := controllers.NewLoyaltyController(loyaltyService)
+	reportController := controllers.NewReportController(reportService)
+	bookingConsoleController := controllers.NewBookingConsoleController(bookingConsoleService)
+	seatMapStreamController := controllers.NewSeatMapStreamController(bookingService, seatEventBroker)
+	customerPrivacyController := controllers.NewCustomerPrivacyController(customerPrivacyService)
+	contactVerificationController := controllers.NewContactVerificationController(contactVerificationService)
+	mfaController := controllers.NewMFAController(mfaService)
+	watchlistController := controllers.NewWatchlistController(watchlistService)
+	movieReviewController := controllers.NewMovieReviewController(movieReviewService)
+	publicAPIConfig := config.GetPublicAPIConfig()
+	publicListingController := controllers.NewPublicListingController(publicListingService, publicAPIConfig.CacheMaxAge)
 
 	binding.Validator = new(customValidator.DtoValidator)
 
@@ -96,6 +154,12 @@ func main() {
 
 	router := gin.Default()
 
+	// Only believe X-Forwarded-For from configured proxies, otherwise callers could spoof their
+	// IP to dodge the public API rate limit.
+	if err := router.SetTrustedProxies(publicAPIConfig.TrustedProxies); err != nil {
+		log.Fatal().Err(err).Msg("Invalid TRUSTED_PROXIES")
+	}
+
 	router.Use(observability.PrometheusMiddleware())
 
 	router.Use(cors.SetupCORS())
@@ -106,6 +170,21 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
 
+	// Signed file URLs stand in for S3 presigned URLs, so like them they need no API key.
+	if fileServer, ok := blobStorage.(services.SignedFileServer); ok {
+		files := router.Group(constants.FilesEndPoint)
+		files.GET(constants.FileKeyEndPoint, controllers.NewFileController(fileServer).ServeFile) // Serve A Locally Stored File From A Signed URL
+	}
+
+	// Public listings feed the website for anonymous visitors, so they need no API key and get their own rate limit.
+	public := router.Group(constants.PublicEndPoint)
+	public.Use(security.RateLimitMiddleware(publicAPIConfig.RateLimitPerMinute, publicAPIConfig.RateLimitBurst))
+	{
+		public.GET(constants.PublicShowsEndPoint, publicListingController.GetShows)   // List Upcoming Shows With Seat Availability
+		public.GET(constants.PublicMoviesEndPoint, publicListingController.GetMovies) // List Movies With Upcoming Shows
+		public.GET(constants.PublicMovieIdEndPoint, publicListingController.GetMovie) // Get A Movie With Its Upcoming Shows
+	}
+
 	router.Use(security.APIKeyAuthMiddleware())
 
 	noAuthRouter := router.Group("")
@@ -125,11 +204,19 @@ func main() {
 	adminStaffRouter.Use(security.AuthMiddleware())
 	adminStaffRouter.Use(security.AdminStaffMiddleware())
 
+	passwordChangeRouter := router.Group("")
+	passwordChangeRouter.Use(security.PasswordChangeAuthMiddleware())
+
+	mfaRouter := router.Group("")
+	mfaRouter.Use(security.MFAEnrollmentAuthMiddleware())
+	mfaRouter.Use(security.AdminStaffMiddleware())
+
 	noAuthAPIs := noAuthRouter.Group("")
 	{
 		login := noAuthAPIs.Group("")
 		{
 			login.POST(constants.LoginEndPoint, authController.Login)                            // Login
+			login.POST(constants.MFALoginEndPoint, authController.LoginMFA)                      // Complete Login With An MFA Code
 			login.POST(constants.ForgotPasswordEndPoint, passwordResetController.ForgotPassword) // Forgot Password
 		}
 
@@ -146,14 +233,19 @@ func main() {
 		}
 	}
 
-	authAPIs := authRouter.Group("")
+	passwordChangeAPIs := passwordChangeRouter.Group("")
 	{
-		authAPIs.POST(constants.ChangePasswordEndPoint, passwordResetController.ChangePassword) // Change Password for User
+		passwordChangeAPIs.POST(constants.ChangePasswordEndPoint, passwordResetController.ChangePassword) // Change Password for User, also with an expired password
+	}
 
+	authAPIs := authRouter.Group("")
+	{
 		showsAPIs := authAPIs.Group(constants.ShowsEndPoint)
 		{
-			showsAPIs.GET("", showController.GetShows)                                    // Get Shows (RBAC-based)
-			showsAPIs.GET(constants.BookingSeatMapEndPoint, bookingController.GetSeatMap) // Get Seat Map Data
+			showsAPIs.GET("", showController.GetShows)                                            // Get Shows (RBAC-based)
+			showsAPIs.GET(constants.ShowSearchEndPoint, showController.SearchShows)               // Search Shows By Date Range, Genre, Time Of Day, Price And Seats
+			showsAPIs.GET(constants.BookingSeatMapEndPoint, bookingController.GetSeatMap)         // Get Seat Map Data
+			showsAPIs.GET(constants.SeatMapStreamEndPoint, seatMapStreamController.StreamSeatMap) // Stream Seat Map Changes (SSE)
 		}
 
 		showAPIs := authAPIs.Group(constants.ShowEndPoint)
@@ -161,6 +253,8 @@ func main() {
 			showAPIs.GET("", showController.GetShowById)
 		}
 
+		authAPIs.GET(constants.MovieReviewsEndpoint, movieReviewController.GetMovieReviews) // Get Visible Reviews And Skyfox Rating Of A Movie
+
 		bookingHelpers := authAPIs.Group(constants.BookingIdEndpoint)
 		{
 			bookingHelpers.GET(constants.QREndpoint, bookingController.GetQRCode) // Get QR Code Image as Base64
@@ -174,6 +268,14 @@ func main() {
 		customerAPIs.GET(constants.ProfileImageEndPoint, skyCustomerController.GetProfileImagePresignedURL) // Get Profile Image
 		customerAPIs.POST(constants.UpdateProfileEndPoint, skyCustomerController.UpdateCustomerProfile)     // Update Customer Profile
 		customerAPIs.POST(constants.UpdateProfileImageEndPoint, skyCustomerController.UpdateProfileImage)   // Update Customer Profile Image
+		customerAPIs.GET(constants.DataExportEndPoint, customerPrivacyController.ExportCustomerData)        // Download All Customer Data As JSON Or ZIP
+		customerAPIs.POST(constants.DeleteAccountEndPoint, customerPrivacyController.DeleteAccount)         // Delete Customer Account
+
+		verification := customerAPIs.Group(constants.VerificationEndPoint)
+		{
+			verification.POST(constants.RequestVerificationEndPoint, contactVerificationController.RequestVerificationCode) // Send A One-Time Code To The Email Or Phone Number
+			verification.POST(constants.ConfirmVerificationEndPoint, contactVerificationController.ConfirmVerificationCode) // Confirm A Code And Mark The Contact Verified
+		}
 
 		booking := customerAPIs.Group(constants.BookingEndpoint)
 		{
@@ -190,9 +292,38 @@ func main() {
 
 		wallet := customerAPIs.Group(constants.WalletEndpoint)
 		{
-			wallet.GET("", walletController.GetWalletBalance)                            // Get Wallet Balance
-			wallet.GET(constants.TransactionsEndpoint, walletController.GetTransactions) // Get All Transactions From Token Claims For A User
-			wallet.POST(constants.AddFundsEndpoint, walletController.AddFunds)           // Add Funds To A User's Wallet
+			wallet.GET("", walletController.GetWalletBalance)                                // Get Wallet Balance
+			wallet.GET(constants.TransactionsEndpoint, walletController.GetTransactions)     // Get All Transactions From Token Claims For A User
+			wallet.GET(constants.StatementEndpoint, walletController.DownloadStatement)      // Download A Wallet Statement As PDF Or CSV
+			wallet.POST(constants.AddFundsEndpoint, walletController.AddFunds)               // Add Funds To A User's Wallet
+			wallet.POST(constants.TransfersEndpoint, walletController.InitiateTransfer)      // Start A Transfer To Another Customer's Wallet
+			wallet.POST(constants.ConfirmTransferEndpoint, walletController.ConfirmTransfer) // Confirm A Pending Wallet Transfer
+		}
+
+		customerAPIs.GET(constants.LoyaltyEndpoint, loyaltyController.GetLoyaltySummary) // Get Loyalty Points Balance, Tier And History
+
+		savedCards := customerAPIs.Group(constants.SavedCardsEndpoint)
+		{
+			savedCards.GET("", savedCardController.GetSavedCards)                                 // List Saved Cards
+			savedCards.POST("", savedCardController.SaveCard)                                     // Tokenize And Save A Card
+			savedCards.DELETE(constants.SavedCardIdEndpoint, savedCardController.DeleteSavedCard) // Delete A Saved Card
+		}
+
+		watchlist := customerAPIs.Group(constants.WatchlistEndpoint)
+		{
+			watchlist.GET("", watchlistController.GetWatchlist)                                       // List Watchlisted Movies
+			watchlist.POST("", watchlistController.AddMovie)                                          // Add A Movie To The Watchlist
+			watchlist.DELETE(constants.WatchlistMovieIdEndpoint, watchlistController.RemoveMovie)     // Remove A Movie From The Watchlist
+			watchlist.GET(constants.WatchlistFeedEndpoint, watchlistController.GetFeed)               // Upcoming Shows For Watchlisted Movies
+			watchlist.GET(constants.WatchlistAlertsEndpoint, watchlistController.GetAlerts)           // New Show Alerts
+			watchlist.POST(constants.WatchlistAlertsReadEndpoint, watchlistController.MarkAlertsRead) // Mark All Alerts As Read
+		}
+
+		reviews := customerAPIs.Group(constants.ReviewsEndpoint)
+		{
+			reviews.GET("", movieReviewController.GetCustomerReviews)                        // List The Customer's Own Reviews
+			reviews.POST("", movieReviewController.SubmitReview)                             // Review A Movie The Customer Checked In To
+			reviews.PUT(constants.ReviewMovieIdEndpoint, movieReviewController.UpdateReview) // Edit The Customer's Review Of A Movie
 		}
 	}
 
@@ -211,16 +342,57 @@ func main() {
 		bookingAPIs := adminAPIs.Group(constants.AdminEndPoint)
 		{
 			bookingAPIs.POST(constants.CreateCustomerBookingEndpoint, bookingController.CreateAdminBooking) // Create Booking Through Admin
+			bookingAPIs.POST(constants.RefundBookingEndpoint, bookingController.RefundBooking)              // Refund A Confirmed Booking
+
+			adminWallet := bookingAPIs.Group(constants.WalletEndpoint)
+			{
+				adminWallet.POST(constants.AdjustmentEndpoint, walletController.PostAdjustment) // Post A Correcting Wallet Adjustment
+				adminWallet.POST(constants.CreditEndpoint, walletController.IssueCredit)        // Issue A Goodwill Credit To A Customer's Wallet
+			}
+
+			bookingAPIs.POST(constants.LoyaltyReverseEndpoint, loyaltyController.ReverseBookingPoints) // Reverse Loyalty Points For A Refunded Booking
+
+			bookingAPIs.GET(constants.MFAPolicyEndPoint, mfaController.GetRolePolicies)  // Get MFA Requirement Per Role
+			bookingAPIs.PUT(constants.MFAPolicyEndPoint, mfaController.UpdateRolePolicy) // Require Or Stop Requiring MFA For A Role
+
+			adminReviews := bookingAPIs.Group(constants.ReviewsEndpoint)
+			{
+				adminReviews.GET("", movieReviewController.ListReviews)                               // List Reviews For Moderation, Hidden Ones Included
+				adminReviews.POST(constants.HideReviewEndpoint, movieReviewController.HideReview)     // Hide A Review From Listings And Ratings
+				adminReviews.POST(constants.UnhideReviewEndpoint, movieReviewController.UnhideReview) // Show A Hidden Review Again
+			}
+
+			reportAPIs := bookingAPIs.Group(constants.ReportsEndpoint)
+			{
+				reportAPIs.POST(constants.ReportSchedulesEndpoint, reportController.CreateSchedule)    // Create A Scheduled Report
+				reportAPIs.GET(constants.ReportSchedulesEndpoint, reportController.GetSchedules)       // List Report Schedules
+				reportAPIs.DELETE(constants.ReportScheduleIdEndpoint, reportController.DeleteSchedule) // Delete A Report Schedule
+				reportAPIs.POST(constants.RunReportScheduleEndpoint, reportController.RunSchedule)     // Run A Scheduled Report Now
+				reportAPIs.GET(constants.ReportRunsEndpoint, reportController.GetRuns)                 // List Past Report Runs
+				reportAPIs.GET(constants.ReportDownloadEndpoint, reportController.DownloadRun)         // Get A Download Link For A Report Run
+			}
 		}
 
 		revenueAPIs := adminAPIs.Group(constants.RevenueEndpoint)
 		{
-			revenueAPIs.GET("", revenueController.GetRevenue) // Revenue API with query param filtering
+			revenueAPIs.GET("", revenueController.GetRevenue)                          // Revenue API with query param filtering
+			revenueAPIs.GET(constants.ForecastEndpoint, revenueController.GetForecast) // Forecast bookings and revenue for upcoming shows
 		}
 
+		adminAPIs.GET(constants.OccupancyEndpoint, occupancyController.GetOccupancy) // Occupancy and seat utilization analytics with revenue filters
+
 		adminAPIs.GET(constants.BookingCSVEndpoint, bookingController.DownloadBookingsCSV) // Download booking data as csv with query param filters
 	}
 
+	mfaAPIs := mfaRouter.Group(constants.MFAEndPoint)
+	{
+		mfaAPIs.GET("", mfaController.GetStatus)                                                // Get MFA Status
+		mfaAPIs.POST(constants.MFAEnrollEndPoint, mfaController.BeginEnrollment)                // Start MFA Enrollment With A QR Code
+		mfaAPIs.POST(constants.MFAEnrollConfirmEndPoint, mfaController.ConfirmEnrollment)       // Confirm MFA Enrollment And Get Recovery Codes
+		mfaAPIs.POST(constants.MFARecoveryCodesEndPoint, mfaController.RegenerateRecoveryCodes) // Regenerate MFA Recovery Codes
+		mfaAPIs.POST(constants.MFADisableEndPoint, mfaController.Disable)                       // Disable MFA
+	}
+
 	adminStaffAPIs := adminStaffRouter.Group("")
 	{
 		admin := adminStaffAPIs.Group(constants.AdminEndPoint)
@@ -235,9 +407,16 @@ func main() {
 
 		checkin := adminStaffAPIs.Group(constants.CheckinEndpoint)
 		{
-			checkin.GET(constants.BookingsEndpoint, bookingController.GetCheckInBookings)   // Get all confirmed bookings
-			checkin.POST(constants.BookingsEndpoint, bookingController.BulkCheckInBookings) // Mark bookings as checked-in in bulk
-			checkin.POST(constants.BookingEndpoint, bookingController.SingleCheckInBooking) // Mark a booking as checked-in
+			checkin.GET(constants.BookingsEndpoint, bookingController.GetCheckInBookings)      // Get confirmed bookings for shows in scope, paginated
+			checkin.POST(constants.BookingsEndpoint, bookingController.BulkCheckInBookings)    // Mark bookings as checked-in in bulk
+			checkin.POST(constants.BookingEndpoint, bookingController.SingleCheckInBooking)    // Mark a booking as checked-in
+			checkin.GET(constants.CheckinSummaryEndpoint, bookingController.GetCheckInSummary) // Per-show expected, admitted and remaining attendees
+		}
+
+		console := adminStaffAPIs.Group(constants.ConsoleEndpoint)
+		{
+			console.GET(constants.BookingsEndpoint, bookingConsoleController.SearchBookings)           // Search bookings by id, customer, show, date and payment type
+			console.GET(constants.ConsoleBookingIdEndpoint, bookingConsoleController.GetBookingDetail) // Get booking detail with payments, wallet movements, check-in state and audit trail
 		}
 	}
 
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_saved_card_username;
+
+DROP TABLE IF EXISTS saved_card;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE saved_card (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    provider_token VARCHAR(255) UNIQUE NOT NULL,
+    last4 CHAR(4) NOT NULL,
+    brand VARCHAR(20) NOT NULL,
+    expiry_month SMALLINT NOT NULL,
+    expiry_year SMALLINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_saved_card_username FOREIGN KEY (username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT check_saved_card_expiry_month CHECK (expiry_month BETWEEN 1 AND 12)
+);
+
+CREATE INDEX idx_saved_card_username ON saved_card(username);
+
+COMMIT;
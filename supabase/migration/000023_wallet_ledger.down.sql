@@ -0,0 +1,16 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_customer_wallet_ledger_account ON customer_wallet;
+DROP FUNCTION IF EXISTS create_wallet_ledger_account();
+
+DROP TABLE IF EXISTS ledger_posting;
+DROP TABLE IF EXISTS ledger_journal_entry;
+DROP TABLE IF EXISTS ledger_account;
+
+DROP FUNCTION IF EXISTS check_ledger_entry_balanced();
+DROP FUNCTION IF EXISTS prevent_ledger_mutation();
+
+DROP TYPE IF EXISTS ledger_posting_direction;
+DROP TYPE IF EXISTS ledger_entry_type;
+
+COMMIT;
@@ -0,0 +1,122 @@
+BEGIN;
+
+CREATE TYPE ledger_entry_type AS ENUM ('OPENING_BALANCE', 'TOP_UP', 'BOOKING_PAYMENT', 'ADJUSTMENT');
+CREATE TYPE ledger_posting_direction AS ENUM ('DEBIT', 'CREDIT');
+
+CREATE TABLE ledger_account (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    account_type VARCHAR(20) NOT NULL,
+    code VARCHAR(50) UNIQUE,
+    wallet_id BIGINT UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_ledger_account_wallet FOREIGN KEY (wallet_id) REFERENCES customer_wallet(id) ON DELETE SET NULL,
+    CONSTRAINT check_ledger_account_type CHECK (account_type IN ('SYSTEM', 'CUSTOMER_WALLET')),
+    CONSTRAINT check_ledger_account_owner CHECK (
+        (account_type = 'SYSTEM' AND code IS NOT NULL) OR account_type = 'CUSTOMER_WALLET'
+    )
+);
+
+CREATE TABLE ledger_journal_entry (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    reference VARCHAR(255) UNIQUE NOT NULL,
+    entry_type ledger_entry_type NOT NULL,
+    description TEXT NOT NULL,
+    booking_id BIGINT,
+    created_by VARCHAR(30),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ledger_posting (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    journal_entry_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    direction ledger_posting_direction NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    CONSTRAINT fk_ledger_posting_entry FOREIGN KEY (journal_entry_id) REFERENCES ledger_journal_entry(id),
+    CONSTRAINT fk_ledger_posting_account FOREIGN KEY (account_id) REFERENCES ledger_account(id),
+    CONSTRAINT check_ledger_posting_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_ledger_posting_account ON ledger_posting(account_id);
+CREATE INDEX idx_ledger_posting_entry ON ledger_posting(journal_entry_id);
+CREATE INDEX idx_ledger_journal_entry_booking ON ledger_journal_entry(booking_id) WHERE booking_id IS NOT NULL;
+
+-- Journal entries and postings are append-only.
+CREATE FUNCTION prevent_ledger_mutation() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger records are immutable; post a correcting entry instead';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_ledger_journal_entry_immutable
+    BEFORE UPDATE OR DELETE ON ledger_journal_entry
+    FOR EACH ROW EXECUTE FUNCTION prevent_ledger_mutation();
+
+CREATE TRIGGER trg_ledger_posting_immutable
+    BEFORE UPDATE OR DELETE ON ledger_posting
+    FOR EACH ROW EXECUTE FUNCTION prevent_ledger_mutation();
+
+-- Every journal entry must balance by the time its transaction commits.
+CREATE FUNCTION check_ledger_entry_balanced() RETURNS TRIGGER AS $$
+DECLARE
+    imbalance DECIMAL(12, 2);
+BEGIN
+    SELECT COALESCE(SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END), 0)
+    INTO imbalance
+    FROM ledger_posting
+    WHERE journal_entry_id = NEW.journal_entry_id;
+
+    IF imbalance <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced by %', NEW.journal_entry_id, imbalance;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_ledger_posting_balanced
+    AFTER INSERT ON ledger_posting
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_ledger_entry_balanced();
+
+-- Every wallet gets a ledger account as soon as it is created.
+CREATE FUNCTION create_wallet_ledger_account() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO ledger_account (account_type, wallet_id) VALUES ('CUSTOMER_WALLET', NEW.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_customer_wallet_ledger_account
+    AFTER INSERT ON customer_wallet
+    FOR EACH ROW EXECUTE FUNCTION create_wallet_ledger_account();
+
+INSERT INTO ledger_account (account_type, code) VALUES
+    ('SYSTEM', 'OPENING_BALANCE'),
+    ('SYSTEM', 'CARD_CLEARING'),
+    ('SYSTEM', 'BOOKING_REVENUE'),
+    ('SYSTEM', 'ADJUSTMENTS');
+
+INSERT INTO ledger_account (account_type, wallet_id)
+SELECT 'CUSTOMER_WALLET', id FROM customer_wallet;
+
+-- Carry existing balances into the journal so cached and derived balances agree.
+INSERT INTO ledger_journal_entry (reference, entry_type, description)
+SELECT 'OPENING-' || id, 'OPENING_BALANCE', 'Opening balance carried over from customer_wallet'
+FROM customer_wallet
+WHERE balance > 0;
+
+INSERT INTO ledger_posting (journal_entry_id, account_id, direction, amount)
+SELECT je.id, la.id, 'CREDIT', cw.balance
+FROM customer_wallet cw
+JOIN ledger_journal_entry je ON je.reference = 'OPENING-' || cw.id
+JOIN ledger_account la ON la.wallet_id = cw.id
+WHERE cw.balance > 0;
+
+INSERT INTO ledger_posting (journal_entry_id, account_id, direction, amount)
+SELECT je.id, (SELECT id FROM ledger_account WHERE code = 'OPENING_BALANCE'), 'DEBIT', cw.balance
+FROM customer_wallet cw
+JOIN ledger_journal_entry je ON je.reference = 'OPENING-' || cw.id
+WHERE cw.balance > 0;
+
+COMMIT;
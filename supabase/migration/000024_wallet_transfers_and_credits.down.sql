@@ -0,0 +1,17 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_wallet_credit_grant_active;
+DROP TABLE IF EXISTS wallet_credit_grant;
+
+DROP INDEX IF EXISTS idx_wallet_transfer_sender_status;
+DROP TABLE IF EXISTS wallet_transfer;
+
+ALTER TABLE wallet_transaction
+    DROP COLUMN IF EXISTS note,
+    DROP COLUMN IF EXISTS counterparty_username;
+
+-- Postgres doesn't allow removing values from an enum, so the new
+-- wallet_transaction_type and ledger_entry_type values and the GOODWILL
+-- ledger account (referenced by immutable postings) are left in place.
+
+COMMIT;
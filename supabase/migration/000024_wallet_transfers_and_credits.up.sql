@@ -0,0 +1,57 @@
+BEGIN;
+
+ALTER TYPE wallet_transaction_type ADD VALUE IF NOT EXISTS 'TRANSFER_IN';
+ALTER TYPE wallet_transaction_type ADD VALUE IF NOT EXISTS 'TRANSFER_OUT';
+ALTER TYPE wallet_transaction_type ADD VALUE IF NOT EXISTS 'CREDIT';
+ALTER TYPE wallet_transaction_type ADD VALUE IF NOT EXISTS 'CREDIT_EXPIRY';
+
+ALTER TYPE ledger_entry_type ADD VALUE IF NOT EXISTS 'TRANSFER';
+ALTER TYPE ledger_entry_type ADD VALUE IF NOT EXISTS 'GOODWILL_CREDIT';
+ALTER TYPE ledger_entry_type ADD VALUE IF NOT EXISTS 'CREDIT_EXPIRY';
+
+ALTER TABLE wallet_transaction
+    ADD COLUMN counterparty_username VARCHAR(30),
+    ADD COLUMN note TEXT;
+
+INSERT INTO ledger_account (account_type, code) VALUES ('SYSTEM', 'GOODWILL');
+
+CREATE TABLE wallet_transfer (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    reference VARCHAR(255) UNIQUE NOT NULL,
+    sender_username VARCHAR(30) NOT NULL,
+    recipient_username VARCHAR(30) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    note VARCHAR(140),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_wallet_transfer_sender FOREIGN KEY (sender_username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT fk_wallet_transfer_recipient FOREIGN KEY (recipient_username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT check_wallet_transfer_amount CHECK (amount > 0),
+    CONSTRAINT check_wallet_transfer_parties CHECK (sender_username <> recipient_username),
+    CONSTRAINT check_wallet_transfer_status CHECK (status IN ('PENDING', 'COMPLETED', 'EXPIRED'))
+);
+
+CREATE INDEX idx_wallet_transfer_sender_status ON wallet_transfer(sender_username, status, completed_at);
+
+CREATE TABLE wallet_credit_grant (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    wallet_id BIGINT NOT NULL,
+    reference VARCHAR(255) UNIQUE NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    remaining_amount DECIMAL(10, 2) NOT NULL,
+    reason TEXT NOT NULL,
+    granted_by VARCHAR(30) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_wallet_credit_grant_wallet FOREIGN KEY (wallet_id) REFERENCES customer_wallet(id) ON DELETE CASCADE,
+    CONSTRAINT check_wallet_credit_grant_amount CHECK (amount > 0),
+    CONSTRAINT check_wallet_credit_grant_remaining CHECK (remaining_amount >= 0 AND remaining_amount <= amount)
+);
+
+CREATE INDEX idx_wallet_credit_grant_active ON wallet_credit_grant(wallet_id, expires_at)
+    WHERE expires_at IS NOT NULL AND expired_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_loyalty_transaction_username_created;
+DROP INDEX IF EXISTS idx_loyalty_transaction_booking_type;
+DROP TABLE IF EXISTS loyalty_transaction;
+DROP TABLE IF EXISTS loyalty_account;
+DROP TYPE IF EXISTS loyalty_transaction_type;
+
+-- Postgres doesn't allow removing values from an enum, so the 'Points'
+-- payment_mode_enum value is left in place.
+
+COMMIT;
@@ -0,0 +1,35 @@
+BEGIN;
+
+ALTER TYPE payment_mode_enum ADD VALUE IF NOT EXISTS 'Points';
+
+CREATE TYPE loyalty_transaction_type AS ENUM ('EARN', 'REDEEM', 'EARN_REVERSAL', 'REDEEM_REVERSAL');
+
+CREATE TABLE loyalty_account (
+    username VARCHAR(30) PRIMARY KEY,
+    points_balance INTEGER NOT NULL DEFAULT 0,
+    lifetime_points INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_loyalty_account_username FOREIGN KEY (username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT check_loyalty_points_balance CHECK (points_balance >= 0),
+    CONSTRAINT check_loyalty_lifetime_points CHECK (lifetime_points >= 0)
+);
+
+CREATE TABLE loyalty_transaction (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    booking_id BIGINT,
+    transaction_type loyalty_transaction_type NOT NULL,
+    points INTEGER NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    created_by VARCHAR(30),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_loyalty_transaction_account FOREIGN KEY (username) REFERENCES loyalty_account(username) ON DELETE CASCADE,
+    CONSTRAINT fk_loyalty_transaction_booking FOREIGN KEY (booking_id) REFERENCES booking(id) ON DELETE SET NULL,
+    CONSTRAINT check_loyalty_transaction_points CHECK (points > 0)
+);
+
+-- A booking earns, redeems and is reversed at most once
+CREATE UNIQUE INDEX idx_loyalty_transaction_booking_type ON loyalty_transaction(booking_id, transaction_type) WHERE booking_id IS NOT NULL;
+CREATE INDEX idx_loyalty_transaction_username_created ON loyalty_transaction(username, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_report_run_schedule_started;
+DROP INDEX IF EXISTS idx_report_schedule_due;
+DROP TABLE IF EXISTS report_run;
+DROP TABLE IF EXISTS report_schedule;
+
+COMMIT;
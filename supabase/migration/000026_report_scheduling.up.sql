@@ -0,0 +1,45 @@
+BEGIN;
+
+CREATE TABLE report_schedule (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    name VARCHAR(100) NOT NULL,
+    report_type VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    period VARCHAR(10) NOT NULL,
+    cron_expression VARCHAR(100) NOT NULL,
+    recipients TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_report_schedule_type CHECK (report_type IN ('REVENUE', 'BOOKINGS')),
+    CONSTRAINT check_report_schedule_format CHECK (format IN ('CSV', 'PDF')),
+    CONSTRAINT check_report_schedule_period CHECK (period IN ('DAY', 'WEEK', 'MONTH'))
+);
+
+CREATE TABLE report_run (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    schedule_id BIGINT,
+    report_type VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'RUNNING',
+    object_key VARCHAR(255),
+    file_name VARCHAR(255),
+    size_bytes BIGINT,
+    delivery_status VARCHAR(20),
+    error TEXT,
+    triggered_by VARCHAR(30),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_report_run_schedule FOREIGN KEY (schedule_id) REFERENCES report_schedule(id) ON DELETE SET NULL,
+    CONSTRAINT check_report_run_status CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    CONSTRAINT check_report_run_delivery CHECK (delivery_status IN ('SENT', 'FAILED', 'SKIPPED'))
+);
+
+CREATE INDEX idx_report_schedule_due ON report_schedule(next_run_at) WHERE enabled;
+CREATE INDEX idx_report_run_schedule_started ON report_run(schedule_id, started_at DESC);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_booking_customer_history ON booking(customer_username, booking_time DESC, id DESC);
+COMMENT ON INDEX idx_booking_customer_history IS 'Supports keyset pagination of customer booking history';
+
+COMMIT;
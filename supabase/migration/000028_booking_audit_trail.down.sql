@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_booking_time_id;
+DROP INDEX IF EXISTS idx_admin_booked_customer_number;
+DROP TABLE IF EXISTS booking_audit_event;
+
+COMMIT;
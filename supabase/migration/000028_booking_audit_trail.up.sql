@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Rows are kept after a booking is deleted (cancelled or expired), so booking_id has no foreign key
+CREATE TABLE booking_audit_event (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    booking_id INTEGER NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    actor VARCHAR(30),
+    details VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_booking_audit_event_booking ON booking_audit_event(booking_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_admin_booked_customer_number ON admin_booked_customer(number);
+COMMENT ON INDEX idx_admin_booked_customer_number IS 'Supports booking search by walk-in customer phone number';
+
+CREATE INDEX IF NOT EXISTS idx_booking_time_id ON booking(booking_time DESC, id DESC);
+COMMENT ON INDEX idx_booking_time_id IS 'Supports keyset pagination of admin booking search';
+
+COMMIT;
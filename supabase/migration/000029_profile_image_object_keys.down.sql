@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Object keys are not rewritten back into URLs: the bucket and region are deployment
+-- settings, and earlier releases also accept a bare object key.
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Profile images were stored as public S3 URLs; store the object key so any storage driver can resolve it
+UPDATE customertable
+SET profile_img = regexp_replace(profile_img, '^https?://[^/]+/', '')
+WHERE profile_img ~ '^https?://';
+
+COMMIT;
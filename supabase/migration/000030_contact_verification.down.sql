@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS contact_verification_code;
+
+ALTER TABLE customertable
+    DROP CONSTRAINT IF EXISTS check_pending_number,
+    DROP COLUMN IF EXISTS pending_number,
+    DROP COLUMN IF EXISTS pending_email,
+    DROP COLUMN IF EXISTS number_verified_at,
+    DROP COLUMN IF EXISTS email_verified_at;
+
+COMMIT;
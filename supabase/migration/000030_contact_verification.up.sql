@@ -0,0 +1,28 @@
+BEGIN;
+
+-- Existing customers start unverified; a changed email or number waits in the pending column until confirmed
+ALTER TABLE customertable
+    ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN number_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN pending_email VARCHAR(255),
+    ADD COLUMN pending_number VARCHAR(10),
+    ADD CONSTRAINT check_pending_number CHECK (pending_number IS NULL OR pending_number ~ '^[0-9]{10}$');
+
+-- Only the hash of a code is stored; destination records which address the code was sent to
+CREATE TABLE contact_verification_code (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    channel VARCHAR(10) NOT NULL,
+    destination VARCHAR(255) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_verification_channel CHECK (channel IN ('email', 'phone')),
+    CONSTRAINT fk_contact_verification_code_username FOREIGN KEY (username) REFERENCES usertable(username) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_contact_verification_code_username ON contact_verification_code(username, channel, created_at DESC);
+
+COMMIT;
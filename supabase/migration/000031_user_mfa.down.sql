@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS mfa_role_policy;
+DROP TABLE IF EXISTS mfa_recovery_code;
+DROP TABLE IF EXISTS user_mfa;
+
+COMMIT;
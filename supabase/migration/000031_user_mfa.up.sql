@@ -0,0 +1,36 @@
+BEGIN;
+
+-- enabled_at stays NULL until the first code from the authenticator app is confirmed
+CREATE TABLE user_mfa (
+    username VARCHAR(30) PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_mfa_username FOREIGN KEY (username) REFERENCES usertable(username) ON DELETE CASCADE
+);
+
+CREATE TABLE mfa_recovery_code (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_mfa_recovery_code_username FOREIGN KEY (username) REFERENCES usertable(username) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mfa_recovery_code_username ON mfa_recovery_code(username) WHERE used_at IS NULL;
+
+CREATE TABLE mfa_role_policy (
+    role user_role_enum PRIMARY KEY,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(30),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_mfa_role CHECK (role IN ('admin', 'staff'))
+);
+
+INSERT INTO mfa_role_policy (role, required) VALUES ('admin', FALSE), ('staff', FALSE);
+
+COMMIT;
@@ -0,0 +1,35 @@
+BEGIN;
+
+-- Only the three most recent passwords fit back into the fixed columns
+CREATE TABLE password_history_legacy (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) UNIQUE NOT NULL,
+    previous_password_1 VARCHAR(255),
+    previous_password_2 VARCHAR(255),
+    previous_password_3 VARCHAR(255),
+    CONSTRAINT fk_password_history_legacy_username FOREIGN KEY (username) REFERENCES usertable(username) ON DELETE CASCADE
+);
+
+INSERT INTO password_history_legacy (username, previous_password_1, previous_password_2, previous_password_3)
+SELECT username,
+    COALESCE(MAX(password_hash) FILTER (WHERE n = 1), ''),
+    COALESCE(MAX(password_hash) FILTER (WHERE n = 2), ''),
+    COALESCE(MAX(password_hash) FILTER (WHERE n = 3), '')
+FROM (
+    SELECT username, password_hash,
+        ROW_NUMBER() OVER (PARTITION BY username ORDER BY created_at DESC, id DESC) AS n
+    FROM password_history
+) AS ranked
+WHERE n <= 3
+GROUP BY username;
+
+DROP TABLE password_history;
+
+ALTER TABLE password_history_legacy RENAME TO password_history;
+ALTER TABLE password_history RENAME CONSTRAINT fk_password_history_legacy_username TO fk_password_history_username;
+ALTER INDEX password_history_legacy_pkey RENAME TO password_history_pkey;
+ALTER INDEX password_history_legacy_username_key RENAME TO password_history_username_key;
+
+ALTER TABLE usertable DROP COLUMN IF EXISTS password_changed_at;
+
+COMMIT;
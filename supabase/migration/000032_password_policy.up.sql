@@ -0,0 +1,36 @@
+BEGIN;
+
+-- Used to expire passwords after the maximum age configured for the role
+ALTER TABLE usertable
+    ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Password history becomes one row per password instead of three fixed columns, so each role
+-- can keep as many previous passwords as its policy asks for
+CREATE TABLE password_history_entry (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_password_history_entry_username FOREIGN KEY (username) REFERENCES usertable(username) ON DELETE CASCADE
+);
+
+-- previous_password_1 is the newest, so older columns get slightly older timestamps to keep the order
+INSERT INTO password_history_entry (username, password_hash, created_at)
+SELECT password_history.username, previous.password_hash, CURRENT_TIMESTAMP - make_interval(secs => previous.n - 1)
+FROM password_history,
+    LATERAL (VALUES
+        (1, password_history.previous_password_1),
+        (2, password_history.previous_password_2),
+        (3, password_history.previous_password_3)
+    ) AS previous(n, password_hash)
+WHERE previous.password_hash IS NOT NULL AND previous.password_hash <> '';
+
+DROP TABLE password_history;
+
+ALTER TABLE password_history_entry RENAME TO password_history;
+ALTER TABLE password_history RENAME CONSTRAINT fk_password_history_entry_username TO fk_password_history_username;
+ALTER INDEX password_history_entry_pkey RENAME TO password_history_pkey;
+
+CREATE INDEX idx_password_history_username_created_at ON password_history(username, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS watchlist_alert;
+DROP TABLE IF EXISTS watchlist;
+
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+
+CREATE TABLE watchlist (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    movie_id VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_watchlist_username FOREIGN KEY (username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT unique_watchlist_username_movie UNIQUE (username, movie_id)
+);
+
+-- Finds the customers to alert when a show is created for a movie
+CREATE INDEX idx_watchlist_movie_id ON watchlist(movie_id);
+
+-- One alert per customer per new show of a movie on their watchlist
+CREATE TABLE watchlist_alert (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    movie_id VARCHAR(30) NOT NULL,
+    show_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_watchlist_alert_username FOREIGN KEY (username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT fk_watchlist_alert_show FOREIGN KEY (show_id) REFERENCES show(id) ON DELETE CASCADE,
+    CONSTRAINT unique_watchlist_alert_username_show UNIQUE (username, show_id)
+);
+
+CREATE INDEX idx_watchlist_alert_username_created_at ON watchlist_alert(username, created_at DESC);
+
+COMMIT;
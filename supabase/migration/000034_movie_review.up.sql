@@ -0,0 +1,25 @@
+BEGIN;
+
+-- One review per customer per movie, only accepted from customers with a CheckedIn booking
+CREATE TABLE movie_review (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    username VARCHAR(30) NOT NULL,
+    movie_id VARCHAR(30) NOT NULL,
+    rating SMALLINT NOT NULL,
+    review TEXT NOT NULL,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    hidden_at TIMESTAMP WITH TIME ZONE,
+    hidden_by VARCHAR(30),
+    hidden_reason VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_movie_review_username FOREIGN KEY (username) REFERENCES customertable(username) ON DELETE CASCADE,
+    CONSTRAINT unique_movie_review_username_movie UNIQUE (username, movie_id),
+    CONSTRAINT check_movie_review_rating CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT check_movie_review_length CHECK (char_length(review) <= 2000)
+);
+
+-- Serves the public review list and the rating aggregates, which only count visible reviews
+CREATE INDEX idx_movie_review_visible ON movie_review(movie_id, created_at DESC) WHERE hidden = FALSE;
+
+COMMIT;
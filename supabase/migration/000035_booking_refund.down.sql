@@ -0,0 +1,11 @@
+BEGIN;
+
+UPDATE loyalty_account SET points_balance = 0 WHERE points_balance < 0;
+ALTER TABLE loyalty_account
+    ADD CONSTRAINT check_loyalty_points_balance CHECK (points_balance >= 0);
+
+-- Postgres doesn't allow removing values from an enum, so the Refunded booking status and the
+-- REFUND and BOOKING_REFUND types (referenced by refunded bookings and immutable postings) are
+-- left in place.
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'Refunded';
+ALTER TYPE wallet_transaction_type ADD VALUE IF NOT EXISTS 'REFUND';
+ALTER TYPE ledger_entry_type ADD VALUE IF NOT EXISTS 'BOOKING_REFUND';
+
+-- Points earned on a booking that is later refunded are taken back in full. If the customer
+-- already spent them the balance goes negative and is paid off by future earnings.
+ALTER TABLE loyalty_account DROP CONSTRAINT IF EXISTS check_loyalty_points_balance;
+
+COMMIT;
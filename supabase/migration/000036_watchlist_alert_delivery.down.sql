@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_watchlist_alert_pending;
+
+ALTER TABLE watchlist_alert
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS delivery_attempts,
+    DROP COLUMN IF EXISTS delivered_at;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- Alerts are delivered by a background worker. delivered_at stays NULL until the notifier
+-- accepts the alert; failed deliveries are retried at next_attempt_at with a growing delay.
+ALTER TABLE watchlist_alert
+    ADD COLUMN delivered_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN delivery_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Alerts created before delivery was tracked were already handed to the notifier once
+UPDATE watchlist_alert SET delivered_at = created_at;
+
+CREATE INDEX idx_watchlist_alert_pending ON watchlist_alert(next_attempt_at) WHERE delivered_at IS NULL;
+
+COMMIT;
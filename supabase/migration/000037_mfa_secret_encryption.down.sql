@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Encrypted secrets do not fit the old VARCHAR(64) column and cannot be decrypted here, so the
+-- wider column is left in place.
+
+COMMIT;
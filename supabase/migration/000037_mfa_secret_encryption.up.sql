@@ -0,0 +1,7 @@
+BEGIN;
+
+-- TOTP secrets are now stored encrypted with MFA_SECRET_KEY, which is longer than the base32
+-- secret. Existing plaintext secrets are encrypted by the application the next time they are used.
+ALTER TABLE user_mfa ALTER COLUMN secret TYPE VARCHAR(255);
+
+COMMIT;
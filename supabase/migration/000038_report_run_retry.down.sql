@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_report_run_retry;
+
+ALTER TABLE report_run
+    DROP COLUMN IF EXISTS retry_at,
+    DROP COLUMN IF EXISTS attempt;
+
+COMMIT;
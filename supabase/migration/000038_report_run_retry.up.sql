@@ -0,0 +1,11 @@
+BEGIN;
+
+-- A failed scheduled run is retried as a new run for the same period. attempt counts the
+-- tries for that period and retry_at is set on the failed run until the retry is claimed.
+ALTER TABLE report_run
+    ADD COLUMN attempt INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN retry_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_report_run_retry ON report_run(retry_at) WHERE retry_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+ALTER TABLE wallet_transfer
+    DROP CONSTRAINT fk_wallet_transfer_sender,
+    DROP CONSTRAINT fk_wallet_transfer_recipient;
+
+-- Transfers whose sender or recipient was deleted would have been cascaded away before
+DELETE FROM wallet_transfer WHERE sender_username IS NULL OR recipient_username IS NULL;
+
+ALTER TABLE wallet_transfer
+    ALTER COLUMN sender_username SET NOT NULL,
+    ALTER COLUMN recipient_username SET NOT NULL;
+
+ALTER TABLE wallet_transfer
+    ADD CONSTRAINT fk_wallet_transfer_sender FOREIGN KEY (sender_username) REFERENCES customertable(username) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_wallet_transfer_recipient FOREIGN KEY (recipient_username) REFERENCES customertable(username) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Deleting a customer account used to cascade into wallet_transfer and remove the other
+-- party's transfer history. The deleted party's username is now set to NULL instead.
+ALTER TABLE wallet_transfer
+    DROP CONSTRAINT fk_wallet_transfer_sender,
+    DROP CONSTRAINT fk_wallet_transfer_recipient;
+
+ALTER TABLE wallet_transfer
+    ALTER COLUMN sender_username DROP NOT NULL,
+    ALTER COLUMN recipient_username DROP NOT NULL;
+
+ALTER TABLE wallet_transfer
+    ADD CONSTRAINT fk_wallet_transfer_sender FOREIGN KEY (sender_username) REFERENCES customertable(username) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_wallet_transfer_recipient FOREIGN KEY (recipient_username) REFERENCES customertable(username) ON DELETE SET NULL;
+
+COMMIT;